@@ -0,0 +1,13 @@
+package dtos
+
+type AddressRequest struct {
+	Label         string `json:"label" validate:"required,max=50"`
+	RecipientName string `json:"recipient_name" validate:"required,max=255"`
+	Phone         string `json:"phone" validate:"required,id_phone"`
+	Street        string `json:"street" validate:"required,max=500"`
+	ProvinceCode  string `json:"province_code" validate:"required,max=2"`
+	CityCode      string `json:"city_code" validate:"required,max=5"`
+	DistrictCode  string `json:"district_code" validate:"required,max=8"`
+	PostalCode    string `json:"postal_code" validate:"required,len=5,numeric"`
+	IsDefault     bool   `json:"is_default"`
+}
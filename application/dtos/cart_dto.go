@@ -0,0 +1,14 @@
+package dtos
+
+type AddCartItemRequest struct {
+	ProductID int64 `json:"product_id" validate:"required,gt=0"`
+	Quantity  int   `json:"quantity" validate:"required,min=1,max=1000"`
+}
+
+type UpdateCartItemRequest struct {
+	Quantity int `json:"quantity" validate:"required,min=1,max=1000"`
+}
+
+type ApplyCouponRequest struct {
+	Code string `json:"code" validate:"required,max=50"`
+}
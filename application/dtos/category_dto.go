@@ -0,0 +1,31 @@
+package dtos
+
+type CreateCategoryRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url" validate:"omitempty,max=500"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description"`
+	ImageURL    *string `json:"image_url" validate:"omitempty,max=500"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+type CreateTagRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+type UpdateTagRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+type AttachCategoriesRequest struct {
+	CategoryIDs []int `json:"category_ids" validate:"required,min=1,dive,gt=0"`
+}
+
+type AttachTagsRequest struct {
+	TagIDs []int `json:"tag_ids" validate:"required,min=1,dive,gt=0"`
+}
@@ -0,0 +1,24 @@
+package dtos
+
+import "time"
+
+// CouponRequest creates or replaces a coupon. Value is a percentage for
+// percentage coupons and an amount for fixed ones; free shipping coupons
+// ignore it. Without any of the *IDs the coupon applies to every product.
+type CouponRequest struct {
+	Code         string     `json:"code" validate:"required,max=50"`
+	Description  string     `json:"description" validate:"max=255"`
+	Type         string     `json:"type" validate:"required,oneof=percentage fixed free_shipping"`
+	Value        float64    `json:"value" validate:"gte=0"`
+	MaxDiscount  *float64   `json:"max_discount" validate:"omitempty,gt=0"`
+	MinSpend     float64    `json:"min_spend" validate:"gte=0"`
+	UsageLimit   *int       `json:"usage_limit" validate:"omitempty,gt=0"`
+	PerUserLimit *int       `json:"per_user_limit" validate:"omitempty,gt=0"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	IsActive     *bool      `json:"is_active"`
+	ProductIDs   []int64    `json:"product_ids" validate:"omitempty,max=100,dive,gt=0"`
+	CategoryIDs  []int      `json:"category_ids" validate:"omitempty,max=100,dive,gt=0"`
+	TagIDs       []int      `json:"tag_ids" validate:"omitempty,max=100,dive,gt=0"`
+	SellerIDs    []int64    `json:"seller_ids" validate:"omitempty,max=100,dive,gt=0"`
+}
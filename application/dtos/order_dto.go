@@ -0,0 +1,27 @@
+package dtos
+
+// CheckoutRequest places an order. Without AddressID the order ships to the
+// default address. Courier and Service name one of the shipping quotes.
+type CheckoutRequest struct {
+	AddressID int64  `json:"address_id" validate:"omitempty,gt=0"`
+	Courier   string `json:"courier" validate:"required,max=50"`
+	Service   string `json:"service" validate:"required,max=50"`
+	Notes     string `json:"notes" validate:"max=500"`
+}
+
+type ShippingQuoteQuery struct {
+	AddressID int64 `query:"address_id" validate:"omitempty,gt=0"`
+}
+
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=paid processing shipped delivered cancelled refunded"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+type OrderListQuery struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending_payment paid processing shipped delivered cancelled refunded"`
+}
+
+type RefundOrderRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
@@ -0,0 +1,9 @@
+package dtos
+
+// PageQuery holds the pagination query parameters of list endpoints. cursor
+// is the opaque next_cursor of the previous page.
+type PageQuery struct {
+	Limit        int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor       string `query:"cursor"`
+	IncludeTotal bool   `query:"include_total"`
+}
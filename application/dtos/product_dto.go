@@ -0,0 +1,63 @@
+package dtos
+
+type CreateProductRequest struct {
+	Name             string   `json:"name" validate:"required,max=255"`
+	SKU              string   `json:"sku" validate:"required,max=100"`
+	Description      string   `json:"description"`
+	ShortDescription string   `json:"short_description" validate:"max=500"`
+	Price            float64  `json:"price" validate:"required,gt=0"`
+	DiscountPrice    *float64 `json:"discount_price" validate:"omitempty,gt=0"`
+	StockQuantity    int      `json:"stock_quantity" validate:"gte=0"`
+	Weight           *float64 `json:"weight" validate:"omitempty,gt=0"`
+	IsActive         *bool    `json:"is_active"`
+	IsFeatured       bool     `json:"is_featured"`
+	Status           string   `json:"status" validate:"omitempty,oneof=draft published archived out_of_stock"`
+}
+
+// UpdateProductRequest only changes the fields that are present in the body.
+type UpdateProductRequest struct {
+	Name             *string  `json:"name" validate:"omitempty,min=1,max=255"`
+	SKU              *string  `json:"sku" validate:"omitempty,min=1,max=100"`
+	Description      *string  `json:"description"`
+	ShortDescription *string  `json:"short_description" validate:"omitempty,max=500"`
+	Price            *float64 `json:"price" validate:"omitempty,gt=0"`
+	DiscountPrice    *float64 `json:"discount_price" validate:"omitempty,gte=0"`
+	StockQuantity    *int     `json:"stock_quantity" validate:"omitempty,gte=0"`
+	Weight           *float64 `json:"weight" validate:"omitempty,gt=0"`
+	IsActive         *bool    `json:"is_active"`
+	IsFeatured       *bool    `json:"is_featured"`
+	Status           *string  `json:"status" validate:"omitempty,oneof=draft published archived out_of_stock"`
+}
+
+// AdjustStockRequest records a stock movement by hand. Quantity is the signed
+// change to the stock; restocks and returns must add stock, and only returns
+// may reference the order the goods came back from.
+type AdjustStockRequest struct {
+	Type     string `json:"type" validate:"required,oneof=restock return adjustment"`
+	Quantity int    `json:"quantity" validate:"required,ne=0"`
+	Reason   string `json:"reason" validate:"required,max=500"`
+	OrderID  *int64 `json:"order_id" validate:"omitempty,gt=0"`
+}
+
+// ProductListQuery holds the filters of product listings. category and tag
+// accept comma separated slugs.
+type ProductListQuery struct {
+	Category string   `query:"category"`
+	Tag      string   `query:"tag"`
+	MinPrice *float64 `query:"min_price" validate:"omitempty,gte=0"`
+	MaxPrice *float64 `query:"max_price" validate:"omitempty,gte=0"`
+	InStock  bool     `query:"in_stock"`
+	Featured *bool    `query:"featured"`
+	SellerID int64    `query:"seller_id" validate:"omitempty,gt=0"`
+	Status   string   `query:"status" validate:"omitempty,oneof=draft published archived out_of_stock"`
+	Sort     string   `query:"sort" validate:"omitempty,oneof=newest price_asc price_desc popular relevance"`
+}
+
+type ProductSearchQuery struct {
+	Q string `query:"q" validate:"required,max=100"`
+}
+
+type ProductSuggestQuery struct {
+	Q     string `query:"q" validate:"required,max=100"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=10"`
+}
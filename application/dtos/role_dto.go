@@ -0,0 +1,15 @@
+package dtos
+
+type CreateRoleRequest struct {
+	Name        string `json:"name" validate:"required,max=50"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+type UpdateRoleRequest struct {
+	Name        string `json:"name" validate:"max=50"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+type SetRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" validate:"dive,required"`
+}
@@ -0,0 +1,18 @@
+package dtos
+
+// ShippingRateRequest creates or replaces a row of the shipping rate table.
+// OriginCode and DestinationCode are a province or city code, or "*" for any
+// region.
+type ShippingRateRequest struct {
+	Courier         string  `json:"courier" validate:"required,max=50"`
+	Service         string  `json:"service" validate:"required,max=50"`
+	Description     string  `json:"description" validate:"max=255"`
+	OriginCode      string  `json:"origin_code" validate:"required,max=5"`
+	DestinationCode string  `json:"destination_code" validate:"required,max=5"`
+	MinWeight       float64 `json:"min_weight" validate:"gte=0"`
+	MaxWeight       float64 `json:"max_weight" validate:"gt=0"`
+	Price           float64 `json:"price" validate:"gte=0"`
+	EtdMinDays      int     `json:"etd_min_days" validate:"gte=0"`
+	EtdMaxDays      int     `json:"etd_max_days" validate:"gte=0"`
+	IsActive        *bool   `json:"is_active"`
+}
@@ -1,30 +1,57 @@
 package dtos
 
 type RegisterRequest struct {
-	Email       string `json:"email" validate:"required,email"`
-	Password    string `json:"password" validate:"required,min=6"`
-	FullName    string `json:"full_name" validate:"required"`
-	Phone       string `json:"phone"`
-	Gender      string `json:"gender"`
-	DateOfBirth string `json:"date_of_birth"`
+	Email       string `json:"email" validate:"required,email,max=255"`
+	Password    string `json:"password" validate:"required,min=6,max=72"`
+	FullName    string `json:"full_name" validate:"required,max=255"`
+	Phone       string `json:"phone" validate:"omitempty,id_phone"`
+	Gender      string `json:"gender" validate:"omitempty,gender"`
+	DateOfBirth string `json:"date_of_birth" validate:"omitempty,iso_date"`
+	// Address is optional; when given it becomes the default address of the
+	// new account.
+	Address *AddressRequest `json:"address" validate:"omitempty"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required"`
+	CartToken string `json:"cart_token"`
 }
 
 type UpdateUserRequest struct {
-	FullName    string `json:"full_name"`
-	Phone       string `json:"phone"`
-	AvatarURL   string `json:"avatar_url"`
-	Gender      string `json:"gender"`
-	DateOfBirth string `json:"date_of_birth"`
+	FullName    string `json:"full_name" validate:"omitempty,max=255"`
+	Phone       string `json:"phone" validate:"omitempty,id_phone"`
+	AvatarURL   string `json:"avatar_url" validate:"omitempty,url,max=500"`
+	Gender      string `json:"gender" validate:"omitempty,gender"`
+	DateOfBirth string `json:"date_of_birth" validate:"omitempty,iso_date"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=6,max=72"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 type AuthResponse struct {
-	Token string      `json:"token"`
-	User  interface{} `json:"user"`
+	Token        string      `json:"token"`
+	RefreshToken string      `json:"refresh_token"`
+	ExpiresIn    int64       `json:"expires_in"`
+	User         interface{} `json:"user,omitempty"`
 }
 
 type UserResponse struct {
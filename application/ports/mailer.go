@@ -0,0 +1,11 @@
+package ports
+
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(message *MailMessage) error
+}
@@ -0,0 +1,63 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid payment notification signature")
+	ErrChargeNotFound   = errors.New("charge not found")
+)
+
+// ChargeRequest asks a gateway to collect Amount for an order until
+// ExpiresAt.
+type ChargeRequest struct {
+	OrderNumber string
+	Amount      float64
+	Currency    string
+	ExpiresAt   time.Time
+}
+
+// RefundRequest asks a gateway to return Amount of the charge with
+// Reference. RefundReference identifies the refund: gateways refund a
+// reference once, and a repeated request for it succeeds without paying out
+// again.
+type RefundRequest struct {
+	Reference       string
+	RefundReference string
+	Amount          float64
+}
+
+// Charge is the state of a charge at the gateway. Status is one of the
+// entities.PaymentStatus values.
+type Charge struct {
+	Reference  string
+	Status     string
+	Amount     float64
+	PaymentURL string
+}
+
+// PaymentNotification is a verified status update sent by a gateway.
+// EventID identifies the notification, so duplicates can be recognised.
+type PaymentNotification struct {
+	EventID   string
+	Reference string
+	Status    string
+	Amount    float64
+	Payload   []byte
+}
+
+// PaymentGateway collects payments through a provider such as Midtrans or
+// Xendit.
+type PaymentGateway interface {
+	Name() string
+	CreateCharge(ctx context.Context, request *ChargeRequest) (*Charge, error)
+	GetCharge(ctx context.Context, reference string) (*Charge, error)
+	Refund(ctx context.Context, request *RefundRequest) error
+	// ParseNotification verifies the signature of a webhook call and decodes
+	// it, returning ErrInvalidSignature for calls the gateway did not send.
+	ParseNotification(ctx context.Context, payload []byte, headers http.Header) (*PaymentNotification, error)
+}
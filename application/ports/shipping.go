@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+// ShippingQuoteRequest asks for the price of shipping a parcel of Weight kg
+// between two cities, identified by their region codes.
+type ShippingQuoteRequest struct {
+	OriginCityCode          string
+	DestinationCityCode     string
+	DestinationDistrictCode string
+	Weight                  float64
+}
+
+// ShippingRateProvider quotes courier services, either from the built-in
+// rate table or from a courier API such as RajaOngkir.
+type ShippingRateProvider interface {
+	Name() string
+	// Quote returns the services able to ship the parcel, cheapest first.
+	Quote(ctx context.Context, request *ShippingQuoteRequest) ([]*entities.ShippingQuote, error)
+}
@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+var (
+	ErrInvalidRegion   = apperrors.NewAppError(422, "invalid_region", "Province, city and district do not match", nil)
+	ErrAddressBookFull = apperrors.NewAppError(422, "address_book_full", "Address book is full", nil)
+)
+
+const maxAddressesPerUser = 20
+
+type AddressUseCase interface {
+	ListAddresses(ctx context.Context, actor Actor) ([]*entities.Address, error)
+	GetAddress(ctx context.Context, actor Actor, id int64) (*entities.Address, error)
+	CreateAddress(ctx context.Context, actor Actor, address *entities.Address) error
+	UpdateAddress(ctx context.Context, actor Actor, address *entities.Address) error
+	DeleteAddress(ctx context.Context, actor Actor, id int64) error
+	ListProvinces(ctx context.Context) ([]*entities.Province, error)
+	ListCities(ctx context.Context, provinceCode string) ([]*entities.City, error)
+	ListDistricts(ctx context.Context, cityCode string) ([]*entities.District, error)
+}
+
+type addressUseCase struct {
+	addressRepo repositories.AddressRepository
+	regionRepo  repositories.RegionRepository
+}
+
+func NewAddressUseCase(addressRepo repositories.AddressRepository, regionRepo repositories.RegionRepository) AddressUseCase {
+	return &addressUseCase{
+		addressRepo: addressRepo,
+		regionRepo:  regionRepo,
+	}
+}
+
+func (u *addressUseCase) ListAddresses(ctx context.Context, actor Actor) ([]*entities.Address, error) {
+	return u.addressRepo.ListByUserID(ctx, actor.UserID)
+}
+
+// GetAddress returns an address of the actor. Addresses of other users are
+// reported as not found.
+func (u *addressUseCase) GetAddress(ctx context.Context, actor Actor, id int64) (*entities.Address, error) {
+	address, err := u.addressRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if address.UserID != actor.UserID {
+		return nil, apperrors.NewNotFoundError("Address")
+	}
+	return address, nil
+}
+
+// CreateAddress adds an address to the actor's address book. The first
+// address becomes the default.
+func (u *addressUseCase) CreateAddress(ctx context.Context, actor Actor, address *entities.Address) error {
+	addresses, err := u.addressRepo.ListByUserID(ctx, actor.UserID)
+	if err != nil {
+		return err
+	}
+	if len(addresses) >= maxAddressesPerUser {
+		return ErrAddressBookFull.WithDetails(map[string]interface{}{"max": maxAddressesPerUser})
+	}
+
+	if err := resolveRegions(ctx, u.regionRepo, address); err != nil {
+		return err
+	}
+	address.ID = 0
+	address.UserID = actor.UserID
+	return u.addressRepo.Create(ctx, address)
+}
+
+// UpdateAddress saves an address of the actor. The default address stays the
+// default until another address is made the default.
+func (u *addressUseCase) UpdateAddress(ctx context.Context, actor Actor, address *entities.Address) error {
+	existing, err := u.GetAddress(ctx, actor, address.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := resolveRegions(ctx, u.regionRepo, address); err != nil {
+		return err
+	}
+	address.UserID = existing.UserID
+	address.CreatedAt = existing.CreatedAt
+	address.IsDefault = address.IsDefault || existing.IsDefault
+	return u.addressRepo.Update(ctx, address)
+}
+
+// DeleteAddress removes an address of the actor. Past orders keep their copy
+// of it.
+func (u *addressUseCase) DeleteAddress(ctx context.Context, actor Actor, id int64) error {
+	address, err := u.GetAddress(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	return u.addressRepo.Delete(ctx, address)
+}
+
+func (u *addressUseCase) ListProvinces(ctx context.Context) ([]*entities.Province, error) {
+	return u.regionRepo.ListProvinces(ctx)
+}
+
+func (u *addressUseCase) ListCities(ctx context.Context, provinceCode string) ([]*entities.City, error) {
+	return u.regionRepo.ListCities(ctx, provinceCode)
+}
+
+func (u *addressUseCase) ListDistricts(ctx context.Context, cityCode string) ([]*entities.District, error) {
+	return u.regionRepo.ListDistricts(ctx, cityCode)
+}
+
+// resolveRegions checks that the district of address lies in its city and
+// the city in its province, and attaches the regions to the address.
+func resolveRegions(ctx context.Context, regionRepo repositories.RegionRepository, address *entities.Address) error {
+	district, err := regionRepo.GetDistrict(ctx, address.DistrictCode)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return ErrInvalidRegion.WithDetails(map[string]string{"district_code": "Unknown district"})
+	} else if err != nil {
+		return err
+	}
+
+	if district.CityCode != address.CityCode {
+		return ErrInvalidRegion.WithDetails(map[string]string{"city_code": "District " + district.Code + " is not in this city"})
+	}
+	if district.City.ProvinceCode != address.ProvinceCode {
+		return ErrInvalidRegion.WithDetails(map[string]string{"province_code": "City " + district.CityCode + " is not in this province"})
+	}
+
+	city := *district.City
+	address.Province = city.Province
+	city.Province = nil
+	address.City = &city
+	district.City = nil
+	address.District = district
+	return nil
+}
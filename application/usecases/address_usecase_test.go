@@ -0,0 +1,239 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+// MockAddressRepository keeps exactly one default address per user with
+// addresses, like the real repository does.
+type MockAddressRepository struct {
+	addresses []*entities.Address
+}
+
+func (m *MockAddressRepository) Create(ctx context.Context, address *entities.Address) error {
+	existing, _ := m.ListByUserID(ctx, address.UserID)
+	if len(existing) == 0 {
+		address.IsDefault = true
+	} else if address.IsDefault {
+		m.clearDefault(address.UserID)
+	}
+	address.ID = int64(len(m.addresses) + 1)
+	stored := *address
+	m.addresses = append(m.addresses, &stored)
+	return nil
+}
+
+func (m *MockAddressRepository) GetByID(ctx context.Context, id int64) (*entities.Address, error) {
+	for _, address := range m.addresses {
+		if address != nil && address.ID == id {
+			clone := *address
+			return &clone, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Address")
+}
+
+func (m *MockAddressRepository) GetDefault(ctx context.Context, userID int64) (*entities.Address, error) {
+	for _, address := range m.addresses {
+		if address != nil && address.UserID == userID && address.IsDefault {
+			clone := *address
+			return &clone, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Address")
+}
+
+func (m *MockAddressRepository) ListByUserID(ctx context.Context, userID int64) ([]*entities.Address, error) {
+	var addresses []*entities.Address
+	for _, address := range m.addresses {
+		if address != nil && address.UserID == userID {
+			clone := *address
+			addresses = append(addresses, &clone)
+		}
+	}
+	return addresses, nil
+}
+
+func (m *MockAddressRepository) Update(ctx context.Context, address *entities.Address) error {
+	if address.IsDefault {
+		m.clearDefault(address.UserID)
+	}
+	stored := *address
+	m.addresses[address.ID-1] = &stored
+	return nil
+}
+
+func (m *MockAddressRepository) Delete(ctx context.Context, address *entities.Address) error {
+	m.addresses[address.ID-1] = nil
+	if _, err := m.GetDefault(ctx, address.UserID); err == nil {
+		return nil
+	}
+	for i := len(m.addresses) - 1; i >= 0; i-- {
+		if m.addresses[i] != nil && m.addresses[i].UserID == address.UserID {
+			m.addresses[i].IsDefault = true
+			break
+		}
+	}
+	return nil
+}
+
+func (m *MockAddressRepository) clearDefault(userID int64) {
+	for _, address := range m.addresses {
+		if address != nil && address.UserID == userID {
+			address.IsDefault = false
+		}
+	}
+}
+
+type MockRegionRepository struct {
+	provinces []*entities.Province
+	cities    []*entities.City
+	districts []*entities.District
+}
+
+func newMockRegionRepository() *MockRegionRepository {
+	return &MockRegionRepository{
+		provinces: []*entities.Province{{Code: "31", Name: "DKI Jakarta"}, {Code: "34", Name: "Daerah Istimewa Yogyakarta"}},
+		cities: []*entities.City{
+			{Code: "31.74", ProvinceCode: "31", Name: "Kota Administrasi Jakarta Selatan"},
+			{Code: "34.71", ProvinceCode: "34", Name: "Kota Yogyakarta"},
+		},
+		districts: []*entities.District{
+			{Code: "31.74.01", CityCode: "31.74", Name: "Tebet"},
+			{Code: "31.74.02", CityCode: "31.74", Name: "Setiabudi"},
+		},
+	}
+}
+
+func (m *MockRegionRepository) ListProvinces(ctx context.Context) ([]*entities.Province, error) {
+	return m.provinces, nil
+}
+
+func (m *MockRegionRepository) ListCities(ctx context.Context, provinceCode string) ([]*entities.City, error) {
+	var cities []*entities.City
+	for _, city := range m.cities {
+		if city.ProvinceCode == provinceCode {
+			cities = append(cities, city)
+		}
+	}
+	return cities, nil
+}
+
+func (m *MockRegionRepository) ListDistricts(ctx context.Context, cityCode string) ([]*entities.District, error) {
+	var districts []*entities.District
+	for _, district := range m.districts {
+		if district.CityCode == cityCode {
+			districts = append(districts, district)
+		}
+	}
+	return districts, nil
+}
+
+func (m *MockRegionRepository) GetDistrict(ctx context.Context, code string) (*entities.District, error) {
+	for _, district := range m.districts {
+		if district.Code != code {
+			continue
+		}
+		clone := *district
+		for _, city := range m.cities {
+			if city.Code == district.CityCode {
+				cityClone := *city
+				clone.City = &cityClone
+			}
+		}
+		for _, province := range m.provinces {
+			if province.Code == clone.City.ProvinceCode {
+				clone.City.Province = province
+			}
+		}
+		return &clone, nil
+	}
+	return nil, apperrors.NewNotFoundError("District")
+}
+
+func newTestAddress(label string) *entities.Address {
+	return &entities.Address{
+		Label:         label,
+		RecipientName: "Budi Santoso",
+		Phone:         "081234567890",
+		Street:        "Jl. Tebet Raya No. 1",
+		ProvinceCode:  "31",
+		CityCode:      "31.74",
+		DistrictCode:  "31.74.01",
+		PostalCode:    "12810",
+	}
+}
+
+func newTestAddressUseCase() (usecases.AddressUseCase, *MockAddressRepository) {
+	addressRepo := &MockAddressRepository{}
+	return usecases.NewAddressUseCase(addressRepo, newMockRegionRepository()), addressRepo
+}
+
+func TestAddressUseCase_KeepsOneDefaultAddress(t *testing.T) {
+	addresses, _ := newTestAddressUseCase()
+
+	home := newTestAddress("Rumah")
+	assert.NoError(t, addresses.CreateAddress(ctx, shopper, home))
+	assert.True(t, home.IsDefault, "the first address becomes the default")
+	assert.Equal(t, "Tebet", home.District.Name)
+	assert.Equal(t, "DKI Jakarta", home.Province.Name)
+
+	office := newTestAddress("Kantor")
+	office.IsDefault = true
+	assert.NoError(t, addresses.CreateAddress(ctx, shopper, office))
+
+	stored, err := addresses.GetAddress(ctx, shopper, home.ID)
+	assert.NoError(t, err)
+	assert.False(t, stored.IsDefault)
+
+	// The default cannot be switched off, only moved.
+	office.IsDefault = false
+	assert.NoError(t, addresses.UpdateAddress(ctx, shopper, office))
+	assert.True(t, office.IsDefault)
+
+	assert.NoError(t, addresses.DeleteAddress(ctx, shopper, office.ID))
+	stored, err = addresses.GetAddress(ctx, shopper, home.ID)
+	assert.NoError(t, err)
+	assert.True(t, stored.IsDefault, "deleting the default promotes another address")
+}
+
+func TestAddressUseCase_ValidatesRegions(t *testing.T) {
+	addresses, _ := newTestAddressUseCase()
+
+	unknown := newTestAddress("Rumah")
+	unknown.DistrictCode = "31.74.99"
+	assert.True(t, errors.Is(addresses.CreateAddress(ctx, shopper, unknown), usecases.ErrInvalidRegion))
+
+	wrongCity := newTestAddress("Rumah")
+	wrongCity.CityCode = "34.71"
+	assert.True(t, errors.Is(addresses.CreateAddress(ctx, shopper, wrongCity), usecases.ErrInvalidRegion))
+
+	wrongProvince := newTestAddress("Rumah")
+	wrongProvince.ProvinceCode = "34"
+	assert.True(t, errors.Is(addresses.CreateAddress(ctx, shopper, wrongProvince), usecases.ErrInvalidRegion))
+}
+
+func TestAddressUseCase_HidesAddressesOfOtherUsers(t *testing.T) {
+	addresses, _ := newTestAddressUseCase()
+	home := newTestAddress("Rumah")
+	assert.NoError(t, addresses.CreateAddress(ctx, shopper, home))
+
+	_, err := addresses.GetAddress(ctx, other, home.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+	update := newTestAddress("Rumah saya")
+	update.ID = home.ID
+	assert.True(t, errors.Is(addresses.UpdateAddress(ctx, other, update), apperrors.ErrNotFound))
+	assert.True(t, errors.Is(addresses.DeleteAddress(ctx, other, home.ID), apperrors.ErrNotFound))
+
+	list, err := addresses.ListAddresses(ctx, other)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
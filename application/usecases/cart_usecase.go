@@ -0,0 +1,302 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
+)
+
+// CartOwner identifies whose cart an operation applies to: the signed in
+// user when UserID is set, otherwise the guest cart with GuestToken.
+type CartOwner struct {
+	UserID     int64
+	GuestToken string
+}
+
+func (o CartOwner) isGuest() bool {
+	return o.UserID == 0
+}
+
+type CartUseCase interface {
+	GetCart(ctx context.Context, owner CartOwner) (*entities.Cart, error)
+	AddItem(ctx context.Context, owner CartOwner, productID int64, quantity int) (*entities.Cart, error)
+	UpdateItem(ctx context.Context, owner CartOwner, productID int64, quantity int) (*entities.Cart, error)
+	RemoveItem(ctx context.Context, owner CartOwner, productID int64) (*entities.Cart, error)
+	ClearCart(ctx context.Context, owner CartOwner) (*entities.Cart, error)
+	ApplyCoupon(ctx context.Context, owner CartOwner, code string) (*entities.Cart, error)
+	RemoveCoupon(ctx context.Context, owner CartOwner) (*entities.Cart, error)
+	MergeGuestCart(ctx context.Context, userID int64, guestToken string) error
+}
+
+type cartUseCase struct {
+	cartRepo    repositories.CartRepository
+	productRepo repositories.ProductRepository
+	couponRepo  repositories.CouponRepository
+	cfg         *config.Config
+}
+
+func NewCartUseCase(
+	cartRepo repositories.CartRepository,
+	productRepo repositories.ProductRepository,
+	couponRepo repositories.CouponRepository,
+	cfg *config.Config,
+) CartUseCase {
+	return &cartUseCase{
+		cartRepo:    cartRepo,
+		productRepo: productRepo,
+		couponRepo:  couponRepo,
+		cfg:         cfg,
+	}
+}
+
+// GetCart returns the owner's cart with freshly calculated totals. Owners
+// without a cart get an empty one, which is not stored.
+func (u *cartUseCase) GetCart(ctx context.Context, owner CartOwner) (*entities.Cart, error) {
+	cart, err := u.findCart(ctx, owner)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		cart = &entities.Cart{Items: []entities.CartItem{}}
+		if !owner.isGuest() {
+			cart.UserID = &owner.UserID
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	cart.Recalculate()
+	return cart, nil
+}
+
+// AddItem adds quantity to the product's line in the cart. Guests without a
+// valid cart token get a new guest cart whose token is returned in
+// Cart.Token.
+func (u *cartUseCase) AddItem(ctx context.Context, owner CartOwner, productID int64, quantity int) (*entities.Cart, error) {
+	cart, err := u.cartForWrite(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	current := 0
+	for _, item := range cart.Items {
+		if item.ProductID == productID {
+			current = item.Quantity
+		}
+	}
+
+	return u.setQuantity(ctx, owner, cart, productID, current+quantity)
+}
+
+// UpdateItem sets the quantity of a product already in the cart.
+func (u *cartUseCase) UpdateItem(ctx context.Context, owner CartOwner, productID int64, quantity int) (*entities.Cart, error) {
+	cart, err := u.findCart(ctx, owner)
+	if err != nil {
+		return nil, u.cartItemNotFound(err)
+	}
+	if !hasProduct(cart, productID) {
+		return nil, apperrors.NewNotFoundError("Cart item")
+	}
+
+	return u.setQuantity(ctx, owner, cart, productID, quantity)
+}
+
+func (u *cartUseCase) RemoveItem(ctx context.Context, owner CartOwner, productID int64) (*entities.Cart, error) {
+	cart, err := u.findCart(ctx, owner)
+	if err != nil {
+		return nil, u.cartItemNotFound(err)
+	}
+
+	if err := u.cartRepo.RemoveItem(ctx, cart.ID, productID); err != nil {
+		return nil, err
+	}
+	return u.GetCart(ctx, owner)
+}
+
+func (u *cartUseCase) ClearCart(ctx context.Context, owner CartOwner) (*entities.Cart, error) {
+	cart, err := u.findCart(ctx, owner)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return u.GetCart(ctx, owner)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := u.cartRepo.ClearItems(ctx, cart.ID); err != nil {
+		return nil, err
+	}
+	return u.GetCart(ctx, owner)
+}
+
+// ApplyCoupon applies the coupon with code to the owner's cart, replacing the
+// coupon applied before. It fails when the coupon cannot be used on the cart
+// as it is; when later changes to the cart stop the coupon from applying, the
+// cart reports why in CouponIssue and checkout fails until it is fixed.
+func (u *cartUseCase) ApplyCoupon(ctx context.Context, owner CartOwner, code string) (*entities.Cart, error) {
+	cart, err := u.findCart(ctx, owner)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, ErrEmptyCart
+	} else if err != nil {
+		return nil, err
+	}
+
+	coupon, err := u.couponRepo.GetByCode(ctx, NormalizeCouponCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	redemptions := 0
+	if !owner.isGuest() {
+		if redemptions, err = u.couponRepo.CountRedemptions(ctx, coupon.ID, owner.UserID); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	if err := coupon.CheckUsable(now, redemptions); err != nil {
+		return nil, err
+	}
+
+	cart.Recalculate()
+	if _, err := coupon.ItemDiscount(cart.Items, now); err != nil {
+		return nil, err
+	}
+
+	if err := u.cartRepo.SetCoupon(ctx, cart.ID, &coupon.ID); err != nil {
+		return nil, err
+	}
+	return u.GetCart(ctx, owner)
+}
+
+func (u *cartUseCase) RemoveCoupon(ctx context.Context, owner CartOwner) (*entities.Cart, error) {
+	cart, err := u.findCart(ctx, owner)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return u.GetCart(ctx, owner)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := u.cartRepo.SetCoupon(ctx, cart.ID, nil); err != nil {
+		return nil, err
+	}
+	return u.GetCart(ctx, owner)
+}
+
+// MergeGuestCart moves the items of a guest cart into the user's cart, e.g.
+// right after login. Unknown or expired guest tokens are ignored.
+func (u *cartUseCase) MergeGuestCart(ctx context.Context, userID int64, guestToken string) error {
+	if guestToken == "" {
+		return nil
+	}
+
+	guestCart, err := u.cartRepo.GetByTokenHash(ctx, utils.HashToken(guestToken))
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	userCart, err := u.cartRepo.GetOrCreateForUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return u.cartRepo.MergeInto(ctx, guestCart.ID, userCart.ID)
+}
+
+func (u *cartUseCase) setQuantity(ctx context.Context, owner CartOwner, cart *entities.Cart, productID int64, quantity int) (*entities.Cart, error) {
+	product, err := u.productRepo.GetByID(ctx, productID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, entities.ErrProductUnavailable
+	} else if err != nil {
+		return nil, err
+	}
+	if !product.IsVisible() && !product.IsSoldOut() {
+		return nil, entities.ErrProductUnavailable
+	}
+	if quantity > product.StockQuantity {
+		return nil, entities.ErrInsufficientStock.WithDetails(map[string]interface{}{
+			"product_id": productID,
+			"available":  product.StockQuantity,
+		})
+	}
+
+	if err := u.cartRepo.SetItemQuantity(ctx, cart.ID, productID, quantity); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.GetCart(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	updated.Token = cart.Token
+	return updated, nil
+}
+
+// cartForWrite returns the owner's cart, creating it when needed.
+func (u *cartUseCase) cartForWrite(ctx context.Context, owner CartOwner) (*entities.Cart, error) {
+	if !owner.isGuest() {
+		return u.cartRepo.GetOrCreateForUser(ctx, owner.UserID)
+	}
+
+	if owner.GuestToken != "" {
+		cart, err := u.cartRepo.GetByTokenHash(ctx, utils.HashToken(owner.GuestToken))
+		if err == nil {
+			return cart, nil
+		}
+		if !errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, apperrors.NewInternalError(err)
+	}
+	tokenHash := utils.HashToken(token)
+	expiresAt := time.Now().Add(u.guestCartTTL())
+
+	cart := &entities.Cart{TokenHash: &tokenHash, ExpiresAt: &expiresAt}
+	if err := u.cartRepo.Create(ctx, cart); err != nil {
+		return nil, err
+	}
+	cart.Token = token
+	return cart, nil
+}
+
+func (u *cartUseCase) findCart(ctx context.Context, owner CartOwner) (*entities.Cart, error) {
+	if !owner.isGuest() {
+		return u.cartRepo.GetByUserID(ctx, owner.UserID)
+	}
+	if owner.GuestToken == "" {
+		return nil, apperrors.NewNotFoundError("Cart")
+	}
+	return u.cartRepo.GetByTokenHash(ctx, utils.HashToken(owner.GuestToken))
+}
+
+// cartItemNotFound reports a missing cart as a missing cart item, since
+// from the client's point of view the item is what does not exist.
+func (u *cartUseCase) cartItemNotFound(err error) error {
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return apperrors.NewNotFoundError("Cart item")
+	}
+	return err
+}
+
+func (u *cartUseCase) guestCartTTL() time.Duration {
+	ttl, err := time.ParseDuration(u.cfg.GuestCartExpiry)
+	if err != nil {
+		return 30 * 24 * time.Hour
+	}
+	return ttl
+}
+
+func hasProduct(cart *entities.Cart, productID int64) bool {
+	for _, item := range cart.Items {
+		if item.ProductID == productID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,290 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
+)
+
+// MockCartRepository keeps carts in memory and, like the real repository,
+// loads item products and the coupon when a cart is read.
+type MockCartRepository struct {
+	products *MockProductRepository
+	coupons  *MockCouponRepository
+	carts    []*entities.Cart
+}
+
+func (m *MockCartRepository) load(cart *entities.Cart) *entities.Cart {
+	clone := *cart
+	clone.Items = make([]entities.CartItem, len(cart.Items))
+	for i, item := range cart.Items {
+		product, _ := m.products.GetByID(ctx, item.ProductID)
+		item.Product = product
+		clone.Items[i] = item
+	}
+	if cart.CouponID != nil {
+		clone.Coupon, _ = m.coupons.GetByID(ctx, *cart.CouponID)
+	}
+	return &clone
+}
+
+func (m *MockCartRepository) find(id int64) *entities.Cart {
+	for _, cart := range m.carts {
+		if cart.ID == id {
+			return cart
+		}
+	}
+	return nil
+}
+
+func (m *MockCartRepository) GetOrCreateForUser(ctx context.Context, userID int64) (*entities.Cart, error) {
+	if cart, err := m.GetByUserID(ctx, userID); err == nil {
+		return cart, nil
+	}
+	cart := &entities.Cart{UserID: &userID}
+	if err := m.Create(ctx, cart); err != nil {
+		return nil, err
+	}
+	return m.load(cart), nil
+}
+
+func (m *MockCartRepository) GetByUserID(ctx context.Context, userID int64) (*entities.Cart, error) {
+	for _, cart := range m.carts {
+		if cart.UserID != nil && *cart.UserID == userID {
+			return m.load(cart), nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Cart")
+}
+
+func (m *MockCartRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.Cart, error) {
+	for _, cart := range m.carts {
+		if cart.TokenHash != nil && *cart.TokenHash == tokenHash {
+			return m.load(cart), nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Cart")
+}
+
+func (m *MockCartRepository) Create(ctx context.Context, cart *entities.Cart) error {
+	cart.ID = int64(len(m.carts) + 1)
+	stored := *cart
+	m.carts = append(m.carts, &stored)
+	return nil
+}
+
+func (m *MockCartRepository) Delete(ctx context.Context, id int64) error {
+	for i, cart := range m.carts {
+		if cart.ID == id {
+			m.carts = append(m.carts[:i], m.carts[i+1:]...)
+			return nil
+		}
+	}
+	return apperrors.NewNotFoundError("Cart")
+}
+
+func (m *MockCartRepository) SetItemQuantity(ctx context.Context, cartID, productID int64, quantity int) error {
+	cart := m.find(cartID)
+	for i := range cart.Items {
+		if cart.Items[i].ProductID == productID {
+			cart.Items[i].Quantity = quantity
+			return nil
+		}
+	}
+	cart.Items = append(cart.Items, entities.CartItem{CartID: cartID, ProductID: productID, Quantity: quantity})
+	return nil
+}
+
+func (m *MockCartRepository) RemoveItem(ctx context.Context, cartID, productID int64) error {
+	cart := m.find(cartID)
+	for i, item := range cart.Items {
+		if item.ProductID == productID {
+			cart.Items = append(cart.Items[:i], cart.Items[i+1:]...)
+			return nil
+		}
+	}
+	return apperrors.NewNotFoundError("Cart item")
+}
+
+func (m *MockCartRepository) ClearItems(ctx context.Context, cartID int64) error {
+	m.find(cartID).Items = nil
+	return nil
+}
+
+func (m *MockCartRepository) MergeInto(ctx context.Context, sourceCartID, targetCartID int64) error {
+	if target := m.find(targetCartID); target.CouponID == nil {
+		target.CouponID = m.find(sourceCartID).CouponID
+	}
+	for _, item := range m.find(sourceCartID).Items {
+		quantity := item.Quantity
+		for _, existing := range m.find(targetCartID).Items {
+			if existing.ProductID == item.ProductID {
+				quantity += existing.Quantity
+			}
+		}
+		if err := m.SetItemQuantity(ctx, targetCartID, item.ProductID, quantity); err != nil {
+			return err
+		}
+	}
+	return m.Delete(ctx, sourceCartID)
+}
+
+func (m *MockCartRepository) SetCoupon(ctx context.Context, cartID int64, couponID *int64) error {
+	m.find(cartID).CouponID = couponID
+	return nil
+}
+
+func (m *MockCartRepository) DeleteExpiredGuestCarts(ctx context.Context, now time.Time) error {
+	return nil
+}
+
+func newTestCartUseCase() (usecases.CartUseCase, *MockCartRepository) {
+	discount := 40000.0
+	productRepo := &MockProductRepository{products: []*entities.Product{
+		{ID: 1, Name: "Kaos", Price: 50000, DiscountPrice: &discount, StockQuantity: 5, IsActive: true, Status: entities.ProductStatusPublished},
+		{ID: 2, Name: "Topi", Price: 25000, StockQuantity: 10, IsActive: true, Status: entities.ProductStatusPublished},
+		{ID: 3, Name: "Draft", Price: 10000, StockQuantity: 10, IsActive: true, Status: entities.ProductStatusDraft},
+	}}
+	couponRepo := &MockCouponRepository{}
+	cartRepo := &MockCartRepository{products: productRepo, coupons: couponRepo}
+	cfg := &config.Config{GuestCartExpiry: "720h"}
+	return usecases.NewCartUseCase(cartRepo, productRepo, couponRepo, cfg), cartRepo
+}
+
+func TestCartUseCase_GuestAddItemIssuesToken(t *testing.T) {
+	useCase, cartRepo := newTestCartUseCase()
+
+	cart, err := useCase.AddItem(ctx, usecases.CartOwner{}, 1, 2)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cart.Token)
+	assert.Equal(t, utils.HashToken(cart.Token), *cartRepo.carts[0].TokenHash)
+
+	owner := usecases.CartOwner{GuestToken: cart.Token}
+	cart, err = useCase.AddItem(ctx, owner, 1, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, cart.Token)
+	assert.Len(t, cartRepo.carts, 1)
+	assert.Equal(t, 3, cart.Items[0].Quantity)
+	assert.Equal(t, 150000.0, cart.Subtotal)
+	assert.Equal(t, 30000.0, cart.DiscountTotal)
+	assert.Equal(t, 120000.0, cart.Total)
+}
+
+func TestCartUseCase_RejectsUnavailableProductsAndExcessQuantity(t *testing.T) {
+	useCase, _ := newTestCartUseCase()
+	owner := usecases.CartOwner{UserID: 7}
+
+	_, err := useCase.AddItem(ctx, owner, 3, 1)
+	assert.True(t, errors.Is(err, entities.ErrProductUnavailable))
+
+	_, err = useCase.AddItem(ctx, owner, 99, 1)
+	assert.True(t, errors.Is(err, entities.ErrProductUnavailable))
+
+	_, err = useCase.AddItem(ctx, owner, 1, 6)
+	assert.True(t, errors.Is(err, entities.ErrInsufficientStock))
+
+	_, err = useCase.UpdateItem(ctx, owner, 2, 1)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+}
+
+func TestCartUseCase_GetCartWithoutCartIsEmpty(t *testing.T) {
+	useCase, cartRepo := newTestCartUseCase()
+
+	cart, err := useCase.GetCart(ctx, usecases.CartOwner{UserID: 7})
+	assert.NoError(t, err)
+	assert.Empty(t, cart.Items)
+	assert.Equal(t, 0.0, cart.Total)
+	assert.Empty(t, cartRepo.carts)
+}
+
+func TestCartUseCase_MergeGuestCartOnLogin(t *testing.T) {
+	useCase, cartRepo := newTestCartUseCase()
+	user := usecases.CartOwner{UserID: 7}
+
+	_, err := useCase.AddItem(ctx, user, 1, 1)
+	assert.NoError(t, err)
+
+	guestCart, err := useCase.AddItem(ctx, usecases.CartOwner{}, 1, 2)
+	assert.NoError(t, err)
+	guest := usecases.CartOwner{GuestToken: guestCart.Token}
+	_, err = useCase.AddItem(ctx, guest, 2, 1)
+	assert.NoError(t, err)
+
+	assert.NoError(t, useCase.MergeGuestCart(ctx, 7, guestCart.Token))
+	assert.NoError(t, useCase.MergeGuestCart(ctx, 7, "unknown-token"))
+
+	cart, err := useCase.GetCart(ctx, user)
+	assert.NoError(t, err)
+	assert.Len(t, cartRepo.carts, 1)
+	assert.Equal(t, 4, cart.ItemCount)
+	assert.Equal(t, 3, cart.Items[0].Quantity)
+
+	cart, err = useCase.GetCart(ctx, guest)
+	assert.NoError(t, err)
+	assert.Empty(t, cart.Items)
+}
+
+func TestCart_RecalculateFlagsProblemItems(t *testing.T) {
+	cart := &entities.Cart{Items: []entities.CartItem{
+		{ProductID: 1, Quantity: 3, Product: &entities.Product{Price: 10000, StockQuantity: 2, IsActive: true, Status: entities.ProductStatusPublished}},
+		{ProductID: 2, Quantity: 1, Product: &entities.Product{Price: 5000, StockQuantity: 2, Status: entities.ProductStatusArchived}},
+	}}
+
+	cart.Recalculate()
+
+	assert.True(t, cart.HasIssues)
+	assert.Equal(t, entities.CartIssueInsufficientStock, cart.Items[0].Issue)
+	assert.Equal(t, entities.CartIssueUnavailable, cart.Items[1].Issue)
+	assert.Equal(t, 30000.0, cart.Total)
+	assert.Equal(t, 3, cart.ItemCount)
+}
+
+func TestCartUseCase_ApplyCoupon(t *testing.T) {
+	useCase, cartRepo := newTestCartUseCase()
+	owner := usecases.CartOwner{UserID: 7}
+	maxDiscount := 5000.0
+	cartRepo.coupons.coupons = []*entities.Coupon{
+		{ID: 1, Code: "KAOS10", Type: entities.CouponTypePercentage, Value: 10, MaxDiscount: &maxDiscount, ProductIDs: []int64{1}, IsActive: true},
+		{ID: 2, Code: "BELANJA100", Type: entities.CouponTypeFixed, Value: 20000, MinSpend: 100000, IsActive: true},
+	}
+
+	_, err := useCase.ApplyCoupon(ctx, owner, "KAOS10")
+	assert.True(t, errors.Is(err, usecases.ErrEmptyCart))
+
+	_, err = useCase.AddItem(ctx, owner, 2, 1)
+	assert.NoError(t, err)
+	_, err = useCase.ApplyCoupon(ctx, owner, "kaos10")
+	assert.True(t, errors.Is(err, entities.ErrCouponNotApplicable))
+	_, err = useCase.ApplyCoupon(ctx, owner, "BELANJA100")
+	assert.True(t, errors.Is(err, entities.ErrCouponMinSpend))
+	_, err = useCase.ApplyCoupon(ctx, owner, "UNKNOWN")
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+	_, err = useCase.AddItem(ctx, owner, 1, 2)
+	assert.NoError(t, err)
+	cart, err := useCase.ApplyCoupon(ctx, owner, "kaos10")
+	assert.NoError(t, err)
+	assert.Equal(t, "KAOS10", cart.Coupon.Code)
+	assert.Equal(t, 5000.0, cart.CouponDiscount)
+	assert.Equal(t, 100000.0, cart.Total)
+	assert.Equal(t, 25000.0, cart.DiscountTotal)
+
+	// Removing the eligible item keeps the coupon but flags it.
+	cart, err = useCase.RemoveItem(ctx, owner, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "coupon_not_applicable", cart.CouponIssue)
+	assert.Equal(t, 25000.0, cart.Total)
+
+	cart, err = useCase.RemoveCoupon(ctx, owner)
+	assert.NoError(t, err)
+	assert.Nil(t, cart.Coupon)
+	assert.Empty(t, cart.CouponIssue)
+}
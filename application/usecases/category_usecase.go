@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+type CategoryUseCase interface {
+	CreateCategory(ctx context.Context, category *entities.Category) error
+	GetCategoryByID(ctx context.Context, id int) (*entities.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (*entities.Category, error)
+	UpdateCategory(ctx context.Context, category *entities.Category) error
+	DeleteCategory(ctx context.Context, id int) error
+	ListCategories(ctx context.Context, includeInactive bool, params pagination.Params) (pagination.Page[*entities.Category], error)
+}
+
+type categoryUseCase struct {
+	categoryRepo repositories.CategoryRepository
+}
+
+func NewCategoryUseCase(categoryRepo repositories.CategoryRepository) CategoryUseCase {
+	return &categoryUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (u *categoryUseCase) CreateCategory(ctx context.Context, category *entities.Category) error {
+	slug, err := uniqueSlug(category.Name, func(slug string) (bool, error) {
+		return u.categoryRepo.SlugExists(ctx, slug, 0)
+	})
+	if err != nil {
+		return err
+	}
+	category.Slug = slug
+
+	return u.categoryRepo.Create(ctx, category)
+}
+
+func (u *categoryUseCase) GetCategoryByID(ctx context.Context, id int) (*entities.Category, error) {
+	return u.categoryRepo.GetByID(ctx, id)
+}
+
+// GetCategoryBySlug returns an active category; inactive ones are reported as
+// not found.
+func (u *categoryUseCase) GetCategoryBySlug(ctx context.Context, slug string) (*entities.Category, error) {
+	category, err := u.categoryRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !category.IsActive {
+		return nil, apperrors.NewNotFoundError("Category")
+	}
+	return category, nil
+}
+
+func (u *categoryUseCase) UpdateCategory(ctx context.Context, category *entities.Category) error {
+	existing, err := u.categoryRepo.GetByID(ctx, category.ID)
+	if err != nil {
+		return err
+	}
+
+	category.Slug = existing.Slug
+	if category.Name != existing.Name {
+		slug, err := uniqueSlug(category.Name, func(slug string) (bool, error) {
+			return u.categoryRepo.SlugExists(ctx, slug, category.ID)
+		})
+		if err != nil {
+			return err
+		}
+		category.Slug = slug
+	}
+
+	return u.categoryRepo.Update(ctx, category)
+}
+
+func (u *categoryUseCase) DeleteCategory(ctx context.Context, id int) error {
+	return u.categoryRepo.Delete(ctx, id)
+}
+
+func (u *categoryUseCase) ListCategories(ctx context.Context, includeInactive bool, params pagination.Params) (pagination.Page[*entities.Category], error) {
+	return u.categoryRepo.List(ctx, !includeInactive, params)
+}
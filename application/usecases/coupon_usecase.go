@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+var (
+	ErrInvalidCoupon = apperrors.NewAppError(422, "invalid_coupon", "Coupon is invalid", nil)
+	ErrCouponInUse   = apperrors.NewAppError(409, "coupon_in_use", "Coupons that were used can only be deactivated", nil)
+)
+
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]+$`)
+
+type CouponUseCase interface {
+	CreateCoupon(ctx context.Context, coupon *entities.Coupon) error
+	GetCoupon(ctx context.Context, id int64) (*entities.Coupon, error)
+	UpdateCoupon(ctx context.Context, coupon *entities.Coupon) error
+	DeleteCoupon(ctx context.Context, id int64) error
+	ListCoupons(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Coupon], error)
+}
+
+type couponUseCase struct {
+	couponRepo repositories.CouponRepository
+}
+
+func NewCouponUseCase(couponRepo repositories.CouponRepository) CouponUseCase {
+	return &couponUseCase{
+		couponRepo: couponRepo,
+	}
+}
+
+// NormalizeCouponCode returns code the way coupon codes are stored, so
+// customers may type them in any case.
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (u *couponUseCase) CreateCoupon(ctx context.Context, coupon *entities.Coupon) error {
+	coupon.Code = NormalizeCouponCode(coupon.Code)
+	if err := validateCoupon(coupon); err != nil {
+		return err
+	}
+	coupon.ID = 0
+	coupon.UsedCount = 0
+	return u.couponRepo.Create(ctx, coupon)
+}
+
+func (u *couponUseCase) GetCoupon(ctx context.Context, id int64) (*entities.Coupon, error) {
+	return u.couponRepo.GetByID(ctx, id)
+}
+
+// UpdateCoupon replaces the coupon. Orders placed with it keep their
+// discounts.
+func (u *couponUseCase) UpdateCoupon(ctx context.Context, coupon *entities.Coupon) error {
+	existing, err := u.couponRepo.GetByID(ctx, coupon.ID)
+	if err != nil {
+		return err
+	}
+
+	coupon.Code = NormalizeCouponCode(coupon.Code)
+	if err := validateCoupon(coupon); err != nil {
+		return err
+	}
+	coupon.UsedCount = existing.UsedCount
+	coupon.CreatedAt = existing.CreatedAt
+	return u.couponRepo.Update(ctx, coupon)
+}
+
+// DeleteCoupon removes a coupon no order has used yet.
+func (u *couponUseCase) DeleteCoupon(ctx context.Context, id int64) error {
+	coupon, err := u.couponRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if coupon.UsedCount > 0 {
+		return ErrCouponInUse
+	}
+	return u.couponRepo.Delete(ctx, id)
+}
+
+func (u *couponUseCase) ListCoupons(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Coupon], error) {
+	return u.couponRepo.List(ctx, params)
+}
+
+// validateCoupon checks what the request validation cannot: the code format,
+// the value for the coupon type and the validity window.
+func validateCoupon(coupon *entities.Coupon) error {
+	details := map[string]string{}
+	if !couponCodePattern.MatchString(coupon.Code) {
+		details["code"] = "code may only contain letters, digits, - and _"
+	}
+	switch coupon.Type {
+	case entities.CouponTypePercentage:
+		if coupon.Value <= 0 || coupon.Value > 100 {
+			details["value"] = "value must be a percentage between 0 and 100"
+		}
+	case entities.CouponTypeFixed:
+		if coupon.Value <= 0 {
+			details["value"] = "value must be greater than 0"
+		}
+	case entities.CouponTypeFreeShipping:
+		coupon.Value = 0
+	default:
+		details["type"] = "type must be one of: percentage, fixed, free_shipping"
+	}
+	if coupon.StartsAt != nil && coupon.EndsAt != nil && !coupon.EndsAt.After(*coupon.StartsAt) {
+		details["ends_at"] = "ends_at must be after starts_at"
+	}
+	if len(details) > 0 {
+		return ErrInvalidCoupon.WithDetails(details)
+	}
+	return nil
+}
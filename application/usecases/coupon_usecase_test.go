@@ -0,0 +1,144 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+// MockCouponRepository keeps coupons and their redemptions in memory and,
+// like the real order transaction, checks coupons again when they are
+// redeemed.
+type MockCouponRepository struct {
+	coupons     []*entities.Coupon
+	redemptions []*entities.CouponRedemption
+}
+
+func (m *MockCouponRepository) Create(ctx context.Context, coupon *entities.Coupon) error {
+	if _, err := m.GetByCode(ctx, coupon.Code); err == nil {
+		return apperrors.NewConflictError("Coupon code already exists")
+	}
+	coupon.ID = int64(len(m.coupons) + 1)
+	stored := *coupon
+	m.coupons = append(m.coupons, &stored)
+	return nil
+}
+
+func (m *MockCouponRepository) GetByID(ctx context.Context, id int64) (*entities.Coupon, error) {
+	if id < 1 || id > int64(len(m.coupons)) || m.coupons[id-1] == nil {
+		return nil, apperrors.NewNotFoundError("Coupon")
+	}
+	clone := *m.coupons[id-1]
+	return &clone, nil
+}
+
+func (m *MockCouponRepository) GetByCode(ctx context.Context, code string) (*entities.Coupon, error) {
+	for _, coupon := range m.coupons {
+		if coupon != nil && coupon.Code == code {
+			clone := *coupon
+			return &clone, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Coupon")
+}
+
+func (m *MockCouponRepository) Update(ctx context.Context, coupon *entities.Coupon) error {
+	stored := *coupon
+	m.coupons[coupon.ID-1] = &stored
+	return nil
+}
+
+func (m *MockCouponRepository) Delete(ctx context.Context, id int64) error {
+	if _, err := m.GetByID(ctx, id); err != nil {
+		return err
+	}
+	m.coupons[id-1] = nil
+	return nil
+}
+
+func (m *MockCouponRepository) List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Coupon], error) {
+	var coupons []*entities.Coupon
+	for _, coupon := range m.coupons {
+		if coupon != nil {
+			clone := *coupon
+			coupons = append(coupons, &clone)
+		}
+	}
+	return pagination.Page[*entities.Coupon]{Items: coupons}, nil
+}
+
+func (m *MockCouponRepository) CountRedemptions(ctx context.Context, couponID, userID int64) (int, error) {
+	count := 0
+	for _, redemption := range m.redemptions {
+		if redemption.CouponID == couponID && redemption.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockCouponRepository) redeem(order *entities.Order) error {
+	coupon := m.coupons[*order.CouponID-1]
+	used, _ := m.CountRedemptions(ctx, coupon.ID, order.UserID)
+	if err := coupon.CheckUsable(time.Now(), used); err != nil {
+		return err
+	}
+	m.redemptions = append(m.redemptions, &entities.CouponRedemption{
+		CouponID: coupon.ID,
+		UserID:   order.UserID,
+		OrderID:  order.ID,
+		Discount: order.CouponDiscount + order.ShippingDiscount,
+	})
+	coupon.UsedCount++
+	return nil
+}
+
+func (m *MockCouponRepository) release(orderID int64) {
+	for i, redemption := range m.redemptions {
+		if redemption.OrderID == orderID {
+			m.coupons[redemption.CouponID-1].UsedCount--
+			m.redemptions = append(m.redemptions[:i], m.redemptions[i+1:]...)
+			return
+		}
+	}
+}
+
+func TestCouponUseCase_ValidatesCoupons(t *testing.T) {
+	couponRepo := &MockCouponRepository{}
+	coupons := usecases.NewCouponUseCase(couponRepo)
+
+	coupon := &entities.Coupon{Code: " hemat10 ", Type: entities.CouponTypePercentage, Value: 10, IsActive: true}
+	assert.NoError(t, coupons.CreateCoupon(ctx, coupon))
+	assert.Equal(t, "HEMAT10", coupon.Code)
+
+	duplicate := &entities.Coupon{Code: "Hemat10", Type: entities.CouponTypeFixed, Value: 5000}
+	assert.True(t, errors.Is(coupons.CreateCoupon(ctx, duplicate), apperrors.ErrConflict))
+
+	startsAt := time.Now()
+	endsAt := startsAt.Add(-time.Hour)
+	for _, invalid := range []*entities.Coupon{
+		{Code: "HEMAT 10", Type: entities.CouponTypeFixed, Value: 5000},
+		{Code: "HEMAT150", Type: entities.CouponTypePercentage, Value: 150},
+		{Code: "GRATIS", Type: entities.CouponTypeFixed},
+		{Code: "KILAT", Type: entities.CouponTypeFixed, Value: 5000, StartsAt: &startsAt, EndsAt: &endsAt},
+	} {
+		assert.True(t, errors.Is(coupons.CreateCoupon(ctx, invalid), usecases.ErrInvalidCoupon), invalid.Code)
+	}
+
+	// Used coupons can only be deactivated.
+	couponRepo.coupons[0].UsedCount = 1
+	assert.True(t, errors.Is(coupons.DeleteCoupon(ctx, coupon.ID), usecases.ErrCouponInUse))
+	coupon.IsActive = false
+	assert.NoError(t, coupons.UpdateCoupon(ctx, coupon))
+	assert.Equal(t, 1, coupon.UsedCount)
+
+	couponRepo.coupons[0].UsedCount = 0
+	assert.NoError(t, coupons.DeleteCoupon(ctx, coupon.ID))
+}
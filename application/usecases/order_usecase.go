@@ -0,0 +1,385 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
+)
+
+var (
+	ErrEmptyCart               = apperrors.NewAppError(422, "empty_cart", "Cart is empty", nil)
+	ErrCartHasIssues           = apperrors.NewAppError(409, "cart_has_issues", "Some cart items are unavailable or exceed the stock", nil)
+	ErrOrderStatusNotPermitted = apperrors.NewForbiddenError("You are not allowed to move this order to the requested status")
+	ErrRefundRequired          = apperrors.NewAppError(409, "refund_required", "Paid orders are cancelled by refunding them", nil)
+	ErrAddressRequired         = apperrors.NewAppError(422, "shipping_address_required", "Add a shipping address before checking out", nil)
+	ErrShippingUnavailable     = apperrors.NewAppError(422, "shipping_service_unavailable", "The courier service cannot ship this order", nil)
+	ErrShippingProviderFailed  = apperrors.NewAppError(502, "shipping_provider_error", "Shipping rate request failed", nil)
+)
+
+const overdueBatchSize = 100
+
+// Statuses the parties of an order may move it to, on top of the state
+// machine rules. Sellers only change orders made up of their own products.
+// Users with orders:manage may perform any valid transition.
+var (
+	customerOrderStatuses = []string{entities.OrderStatusCancelled, entities.OrderStatusDelivered}
+	sellerOrderStatuses   = []string{entities.OrderStatusProcessing, entities.OrderStatusShipped, entities.OrderStatusDelivered, entities.OrderStatusCancelled}
+)
+
+type OrderUseCase interface {
+	QuoteShipping(ctx context.Context, actor Actor, addressID int64) ([]*entities.ShippingQuote, error)
+	Checkout(ctx context.Context, actor Actor, addressID int64, courier, service, notes string) (*entities.Order, error)
+	GetOrder(ctx context.Context, actor Actor, id int64) (*entities.Order, error)
+	ListOwnOrders(ctx context.Context, actor Actor, status string, params pagination.Params) (pagination.Page[*entities.Order], error)
+	ListSales(ctx context.Context, actor Actor, status string, params pagination.Params) (pagination.Page[*entities.Order], error)
+	ListAllOrders(ctx context.Context, status string, params pagination.Params) (pagination.Page[*entities.Order], error)
+	UpdateStatus(ctx context.Context, actor Actor, id int64, status, note string) (*entities.Order, error)
+	CancelOverdueOrders(ctx context.Context) (int, error)
+}
+
+type orderUseCase struct {
+	orderRepo      repositories.OrderRepository
+	cartRepo       repositories.CartRepository
+	addressRepo    repositories.AddressRepository
+	couponRepo     repositories.CouponRepository
+	permissionRepo repositories.PermissionRepository
+	shipping       ports.ShippingRateProvider
+	cfg            *config.Config
+}
+
+func NewOrderUseCase(
+	orderRepo repositories.OrderRepository,
+	cartRepo repositories.CartRepository,
+	addressRepo repositories.AddressRepository,
+	couponRepo repositories.CouponRepository,
+	permissionRepo repositories.PermissionRepository,
+	shipping ports.ShippingRateProvider,
+	cfg *config.Config,
+) OrderUseCase {
+	return &orderUseCase{
+		orderRepo:      orderRepo,
+		cartRepo:       cartRepo,
+		addressRepo:    addressRepo,
+		couponRepo:     couponRepo,
+		permissionRepo: permissionRepo,
+		shipping:       shipping,
+		cfg:            cfg,
+	}
+}
+
+// QuoteShipping lists the courier services able to ship the actor's cart to
+// the address with addressID or, when it is 0, to the default address,
+// cheapest first.
+func (u *orderUseCase) QuoteShipping(ctx context.Context, actor Actor, addressID int64) ([]*entities.ShippingQuote, error) {
+	cart, err := u.checkoutCart(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+	address, err := u.shippingAddress(ctx, actor, addressID)
+	if err != nil {
+		return nil, err
+	}
+	return u.quote(ctx, cart, address)
+}
+
+// Checkout turns the actor's cart into an order awaiting payment, shipped to
+// the address with addressID or, when it is 0, to the actor's default
+// address, by the chosen courier service. Prices, the address, the shipping
+// rate and the discounts of the cart's coupon are taken at this moment and
+// stored on the order, and the stock stays reserved until the payment is due.
+func (u *orderUseCase) Checkout(ctx context.Context, actor Actor, addressID int64, courier, service, notes string) (*entities.Order, error) {
+	cart, err := u.checkoutCart(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := u.shippingAddress(ctx, actor, addressID)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, err := u.quote(ctx, cart, address)
+	if err != nil {
+		return nil, err
+	}
+	var chosen *entities.ShippingQuote
+	for _, quote := range quotes {
+		if quote.Courier == courier && quote.Service == service {
+			chosen = quote
+			break
+		}
+	}
+	if chosen == nil {
+		return nil, ErrShippingUnavailable.WithDetails(map[string]interface{}{"available": quotes})
+	}
+
+	now := time.Now()
+	orderNumber, err := newOrderNumber(now)
+	if err != nil {
+		return nil, apperrors.NewInternalError(err)
+	}
+	paymentDueAt := now.Add(u.reservationTTL())
+
+	order := &entities.Order{
+		OrderNumber:     orderNumber,
+		UserID:          actor.UserID,
+		Status:          entities.OrderStatusPendingPayment,
+		Notes:           notes,
+		ShippingAddress: address.Snapshot(),
+		ShippingCourier: chosen.Courier,
+		ShippingService: chosen.Service,
+		ShippingWeight:  cart.Weight,
+		ShippingCost:    chosen.Price,
+		PaymentDueAt:    &paymentDueAt,
+		History: []entities.OrderStatusHistory{
+			{ToStatus: entities.OrderStatusPendingPayment, ChangedBy: &actor.UserID},
+		},
+	}
+	for _, item := range cart.Items {
+		order.Items = append(order.Items, entities.NewOrderItem(item.Product, item.Quantity))
+	}
+	if cart.Coupon != nil {
+		if err := u.applyCoupon(ctx, actor, cart, order, now); err != nil {
+			return nil, err
+		}
+	}
+	order.CalculateTotals()
+
+	if err := u.orderRepo.Place(ctx, order, cart.ID); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// checkoutCart returns the actor's cart, recalculated, as long as it can be
+// checked out.
+func (u *orderUseCase) checkoutCart(ctx context.Context, actor Actor) (*entities.Cart, error) {
+	cart, err := u.cartRepo.GetByUserID(ctx, actor.UserID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, ErrEmptyCart
+	} else if err != nil {
+		return nil, err
+	}
+
+	cart.Recalculate()
+	if len(cart.Items) == 0 {
+		return nil, ErrEmptyCart
+	}
+	if cart.HasIssues {
+		var issues []map[string]interface{}
+		for _, item := range cart.Items {
+			if item.Issue != "" {
+				issues = append(issues, map[string]interface{}{"product_id": item.ProductID, "issue": item.Issue})
+			}
+		}
+		return nil, ErrCartHasIssues.WithDetails(issues)
+	}
+	return cart, nil
+}
+
+// applyCoupon puts the discounts of the cart's coupon on order. The
+// repository checks the coupon again while placing the order, so concurrent
+// checkouts cannot exceed its limits.
+func (u *orderUseCase) applyCoupon(ctx context.Context, actor Actor, cart *entities.Cart, order *entities.Order, now time.Time) error {
+	coupon := cart.Coupon
+	redemptions, err := u.couponRepo.CountRedemptions(ctx, coupon.ID, actor.UserID)
+	if err != nil {
+		return err
+	}
+	if err := coupon.CheckUsable(now, redemptions); err != nil {
+		return err
+	}
+	discount, err := coupon.ItemDiscount(cart.Items, now)
+	if err != nil {
+		return err
+	}
+
+	order.CouponID = &coupon.ID
+	order.CouponCode = coupon.Code
+	order.CouponDiscount = discount
+	order.ShippingDiscount = coupon.ShippingDiscount(order.ShippingCost)
+	return nil
+}
+
+// quote asks the shipping provider for the services able to ship cart from
+// the store's origin city to address.
+func (u *orderUseCase) quote(ctx context.Context, cart *entities.Cart, address *entities.Address) ([]*entities.ShippingQuote, error) {
+	quotes, err := u.shipping.Quote(ctx, &ports.ShippingQuoteRequest{
+		OriginCityCode:          u.cfg.ShippingOriginCity,
+		DestinationCityCode:     address.CityCode,
+		DestinationDistrictCode: address.DistrictCode,
+		Weight:                  cart.Weight,
+	})
+	if err != nil {
+		return nil, ErrShippingProviderFailed
+	}
+	return quotes, nil
+}
+
+// shippingAddress returns the address of the actor an order is shipped to.
+func (u *orderUseCase) shippingAddress(ctx context.Context, actor Actor, addressID int64) (*entities.Address, error) {
+	if addressID == 0 {
+		address, err := u.addressRepo.GetDefault(ctx, actor.UserID)
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, ErrAddressRequired
+		}
+		return address, err
+	}
+
+	address, err := u.addressRepo.GetByID(ctx, addressID)
+	if err != nil {
+		return nil, err
+	}
+	if address.UserID != actor.UserID {
+		return nil, apperrors.NewNotFoundError("Address")
+	}
+	return address, nil
+}
+
+// GetOrder returns an order to its customer, to sellers of products in it and
+// to users with orders:manage. Everyone else gets a 404.
+func (u *orderUseCase) GetOrder(ctx context.Context, actor Actor, id int64) (*entities.Order, error) {
+	order, err := u.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID == actor.UserID || order.HasSeller(actor.UserID) {
+		return order, nil
+	}
+	manager, err := u.isManager(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !manager {
+		return nil, apperrors.NewNotFoundError("Order")
+	}
+	return order, nil
+}
+
+func (u *orderUseCase) ListOwnOrders(ctx context.Context, actor Actor, status string, params pagination.Params) (pagination.Page[*entities.Order], error) {
+	return u.orderRepo.List(ctx, repositories.OrderFilter{UserID: actor.UserID, Status: status}, params)
+}
+
+// ListSales lists the orders containing products of the actor.
+func (u *orderUseCase) ListSales(ctx context.Context, actor Actor, status string, params pagination.Params) (pagination.Page[*entities.Order], error) {
+	return u.orderRepo.List(ctx, repositories.OrderFilter{SellerID: actor.UserID, Status: status}, params)
+}
+
+func (u *orderUseCase) ListAllOrders(ctx context.Context, status string, params pagination.Params) (pagination.Page[*entities.Order], error) {
+	return u.orderRepo.List(ctx, repositories.OrderFilter{Status: status}, params)
+}
+
+// UpdateStatus moves the order through the state machine. Customers may
+// cancel unpaid orders and confirm delivery, the seller of all its products
+// fulfils and cancels it, and users with orders:manage may perform any valid
+// transition. Paying an order commits its stock reservations; cancelling it
+// releases them. Paid orders hold money, so they are cancelled through
+// PaymentUseCase.Refund instead.
+func (u *orderUseCase) UpdateStatus(ctx context.Context, actor Actor, id int64, status, note string) (*entities.Order, error) {
+	order, err := u.GetOrder(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.authorizeTransition(ctx, actor, order, status); err != nil {
+		return nil, err
+	}
+	if status == entities.OrderStatusCancelled && order.Status != entities.OrderStatusPendingPayment {
+		return nil, ErrRefundRequired
+	}
+
+	entry, err := order.TransitionTo(status, &actor.UserID, note)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.orderRepo.UpdateStatus(ctx, order, entry); err != nil {
+		return nil, err
+	}
+	return u.orderRepo.GetByID(ctx, id)
+}
+
+// CancelOverdueOrders cancels orders whose payment deadline has passed,
+// releasing their reserved stock. Orders paid in the meantime are skipped.
+// It returns the number of cancelled orders.
+func (u *orderUseCase) CancelOverdueOrders(ctx context.Context) (int, error) {
+	orders, err := u.orderRepo.ListPaymentOverdue(ctx, time.Now(), overdueBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	cancelled := 0
+	for _, order := range orders {
+		entry, err := order.TransitionTo(entities.OrderStatusCancelled, nil, "Payment window expired")
+		if err != nil {
+			continue
+		}
+		err = u.orderRepo.UpdateStatus(ctx, order, entry)
+		if errors.Is(err, apperrors.ErrConflict) {
+			continue
+		} else if err != nil {
+			return cancelled, err
+		}
+		cancelled++
+	}
+	return cancelled, nil
+}
+
+func (u *orderUseCase) authorizeTransition(ctx context.Context, actor Actor, order *entities.Order, status string) error {
+	manager, err := u.isManager(ctx, actor)
+	if err != nil || manager {
+		return err
+	}
+
+	if order.UserID == actor.UserID && containsStatus(customerOrderStatuses, status) {
+		// Once paid, cancellations go through the seller or support.
+		if status != entities.OrderStatusCancelled || order.Status == entities.OrderStatusPendingPayment {
+			return nil
+		}
+	}
+	if order.HasOnlySeller(actor.UserID) && containsStatus(sellerOrderStatuses, status) {
+		return nil
+	}
+	return ErrOrderStatusNotPermitted
+}
+
+func (u *orderUseCase) isManager(ctx context.Context, actor Actor) (bool, error) {
+	return u.permissionRepo.RoleHasPermission(ctx, actor.RoleID, entities.PermissionOrdersManage)
+}
+
+func (u *orderUseCase) reservationTTL() time.Duration {
+	ttl, err := time.ParseDuration(u.cfg.StockReservationTTL)
+	if err != nil {
+		return 30 * time.Minute
+	}
+	return ttl
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// newOrderNumber returns a human friendly order number such as
+// ORD-20240131-9F3A61C2.
+func newOrderNumber(now time.Time) (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ORD-%s-%X", now.Format("20060102"), b), nil
+}
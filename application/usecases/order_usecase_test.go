@@ -0,0 +1,486 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/shipping"
+)
+
+// MockOrderRepository stores orders in memory and adjusts the stock of the
+// products in the product repository and redeems coupons like the real
+// transaction does.
+type MockOrderRepository struct {
+	products *MockProductRepository
+	carts    *MockCartRepository
+	coupons  *MockCouponRepository
+	orders   []*entities.Order
+}
+
+func (m *MockOrderRepository) product(id int64) *entities.Product {
+	for _, product := range m.products.products {
+		if product.ID == id {
+			return product
+		}
+	}
+	return nil
+}
+
+func (m *MockOrderRepository) Place(ctx context.Context, order *entities.Order, cartID int64) error {
+	for _, item := range order.Items {
+		if product := m.product(*item.ProductID); product == nil || product.StockQuantity < item.Quantity {
+			return entities.ErrInsufficientStock
+		}
+	}
+	order.ID = int64(len(m.orders) + 1)
+	if order.CouponID != nil {
+		if err := m.coupons.redeem(order); err != nil {
+			return err
+		}
+	}
+	for _, item := range order.Items {
+		product := m.product(*item.ProductID)
+		product.StockQuantity -= item.Quantity
+		product.SyncStockStatus()
+	}
+
+	m.orders = append(m.orders, order)
+	if err := m.carts.ClearItems(ctx, cartID); err != nil {
+		return err
+	}
+	return m.carts.SetCoupon(ctx, cartID, nil)
+}
+
+func (m *MockOrderRepository) GetByID(ctx context.Context, id int64) (*entities.Order, error) {
+	for _, order := range m.orders {
+		if order.ID == id {
+			clone := *order
+			return &clone, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Order")
+}
+
+func (m *MockOrderRepository) List(ctx context.Context, filter repositories.OrderFilter, params pagination.Params) (pagination.Page[*entities.Order], error) {
+	var orders []*entities.Order
+	for _, order := range m.orders {
+		if (filter.UserID == 0 || order.UserID == filter.UserID) &&
+			(filter.SellerID == 0 || order.HasSeller(filter.SellerID)) &&
+			(filter.Status == "" || order.Status == filter.Status) {
+			orders = append(orders, order)
+		}
+	}
+	return pagination.Page[*entities.Order]{Items: orders}, nil
+}
+
+func (m *MockOrderRepository) ListPaymentOverdue(ctx context.Context, now time.Time, limit int) ([]*entities.Order, error) {
+	var orders []*entities.Order
+	for _, order := range m.orders {
+		if order.Status == entities.OrderStatusPendingPayment && !order.PaymentDueAt.After(now) {
+			clone := *order
+			orders = append(orders, &clone)
+		}
+	}
+	return orders, nil
+}
+
+func (m *MockOrderRepository) UpdateStatus(ctx context.Context, order *entities.Order, entry *entities.OrderStatusHistory) error {
+	stored := m.orders[order.ID-1]
+	if stored.Status != *entry.FromStatus {
+		return apperrors.NewConflictError("Order status was changed by another request")
+	}
+
+	wasPaid := stored.Status != entities.OrderStatusPendingPayment
+	// Cancelled orders and orders refunded before shipping return their stock.
+	releases := entry.ToStatus == entities.OrderStatusCancelled ||
+		entry.ToStatus == entities.OrderStatusRefunded &&
+			(stored.Status == entities.OrderStatusPaid || stored.Status == entities.OrderStatusProcessing)
+	stored.Status = entry.ToStatus
+	stored.History = append(stored.History, *entry)
+	for _, item := range stored.Items {
+		product := m.product(*item.ProductID)
+		switch {
+		case entry.ToStatus == entities.OrderStatusPaid:
+			product.SoldCount += item.Quantity
+		case releases:
+			product.StockQuantity += item.Quantity
+			if wasPaid {
+				product.SoldCount -= item.Quantity
+			}
+			product.SyncStockStatus()
+		}
+	}
+	if releases {
+		m.coupons.release(order.ID)
+	}
+	return nil
+}
+
+var (
+	shopper = usecases.Actor{UserID: 20, RoleID: entities.RoleCustomer}
+	other   = usecases.Actor{UserID: 21, RoleID: entities.RoleCustomer}
+)
+
+func newTestOrderUseCase() (usecases.OrderUseCase, usecases.CartUseCase, *MockProductRepository) {
+	orderUseCase, cartUseCase, orderRepo := newTestOrderUseCaseWithRepository()
+	return orderUseCase, cartUseCase, orderRepo.products
+}
+
+func newTestOrderUseCaseWithRepository() (usecases.OrderUseCase, usecases.CartUseCase, *MockOrderRepository) {
+	discount, weight := 40000.0, 0.3
+	productRepo := &MockProductRepository{products: []*entities.Product{
+		{ID: 1, UserID: tokoA.UserID, Name: "Kaos", SKU: "K-1", Price: 50000, DiscountPrice: &discount, Weight: &weight, StockQuantity: 5, IsActive: true, Status: entities.ProductStatusPublished},
+		{ID: 2, UserID: tokoA.UserID, Name: "Topi", SKU: "T-1", Price: 25000, StockQuantity: 10, IsActive: true, Status: entities.ProductStatusPublished},
+	}}
+	couponRepo := &MockCouponRepository{}
+	cartRepo := &MockCartRepository{products: productRepo, coupons: couponRepo}
+	orderRepo := &MockOrderRepository{products: productRepo, carts: cartRepo, coupons: couponRepo}
+	permissionRepo := &MockPermissionRepository{granted: map[int][]string{
+		entities.RoleAdmin: {entities.PermissionOrdersManage},
+	}}
+
+	addressRepo := &MockAddressRepository{}
+	if err := usecases.NewAddressUseCase(addressRepo, newMockRegionRepository()).CreateAddress(ctx, shopper, newTestAddress("Rumah")); err != nil {
+		panic(err)
+	}
+
+	cfg := &config.Config{StockReservationTTL: "30m", ShippingOriginCity: "31.71"}
+	cartUseCase := usecases.NewCartUseCase(cartRepo, productRepo, couponRepo, cfg)
+	provider := shipping.NewTableProvider(newMockShippingRateRepository())
+	return usecases.NewOrderUseCase(orderRepo, cartRepo, addressRepo, couponRepo, permissionRepo, provider, cfg), cartUseCase, orderRepo
+}
+
+func placeTestOrder(t *testing.T, orders usecases.OrderUseCase, carts usecases.CartUseCase) *entities.Order {
+	owner := usecases.CartOwner{UserID: shopper.UserID}
+	_, err := carts.AddItem(ctx, owner, 1, 2)
+	assert.NoError(t, err)
+	_, err = carts.AddItem(ctx, owner, 2, 1)
+	assert.NoError(t, err)
+
+	order, err := orders.Checkout(ctx, shopper, 0, "jne", "REG", "Tolong dibungkus rapi")
+	assert.NoError(t, err)
+	return order
+}
+
+func TestOrderUseCase_CheckoutSnapshotsCart(t *testing.T) {
+	orders, carts, products := newTestOrderUseCase()
+
+	order := placeTestOrder(t, orders, carts)
+
+	assert.Equal(t, entities.OrderStatusPendingPayment, order.Status)
+	assert.Regexp(t, `^ORD-\d{8}-[0-9A-F]{8}$`, order.OrderNumber)
+	assert.Equal(t, 3, order.ItemCount)
+	assert.Equal(t, 125000.0, order.Subtotal)
+	assert.Equal(t, 20000.0, order.DiscountTotal)
+	assert.Equal(t, 18000.0, order.ShippingCost)
+	assert.Equal(t, 123000.0, order.Total)
+	assert.Equal(t, "Kaos", order.Items[0].ProductName)
+	assert.Equal(t, 40000.0, order.Items[0].UnitPrice)
+	assert.Equal(t, tokoA.UserID, order.Items[0].SellerID)
+	assert.Len(t, order.History, 1)
+	assert.Equal(t, "Budi Santoso", order.ShippingAddress.RecipientName)
+	assert.Equal(t, "Tebet", order.ShippingAddress.District)
+	assert.Equal(t, "Kota Administrasi Jakarta Selatan", order.ShippingAddress.City)
+	assert.Equal(t, "jne", order.ShippingCourier)
+	assert.Equal(t, "REG", order.ShippingService)
+	assert.Equal(t, 1.6, order.ShippingWeight)
+
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), *order.PaymentDueAt, time.Minute)
+	assert.Equal(t, 3, products.products[0].StockQuantity)
+	assert.Equal(t, 0, products.products[0].SoldCount)
+
+	// The cart is emptied, and later price changes leave the order alone.
+	cart, err := carts.GetCart(ctx, usecases.CartOwner{UserID: shopper.UserID})
+	assert.NoError(t, err)
+	assert.Empty(t, cart.Items)
+
+	products.products[0].Price = 99000
+	stored, err := orders.GetOrder(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 50000.0, stored.Items[0].OriginalPrice)
+}
+
+func TestOrderUseCase_CheckoutRejectsEmptyOrInvalidCarts(t *testing.T) {
+	orders, carts, products := newTestOrderUseCase()
+
+	_, err := orders.Checkout(ctx, shopper, 0, "jne", "REG", "")
+	assert.True(t, errors.Is(err, usecases.ErrEmptyCart))
+
+	_, err = carts.AddItem(ctx, usecases.CartOwner{UserID: shopper.UserID}, 1, 2)
+	assert.NoError(t, err)
+	products.products[0].Status = entities.ProductStatusArchived
+
+	_, err = orders.Checkout(ctx, shopper, 0, "jne", "REG", "")
+	assert.True(t, errors.Is(err, usecases.ErrCartHasIssues))
+}
+
+func TestOrderUseCase_CheckoutRequiresAddressOfTheActor(t *testing.T) {
+	orders, carts, _ := newTestOrderUseCase()
+	_, err := carts.AddItem(ctx, usecases.CartOwner{UserID: other.UserID}, 1, 1)
+	assert.NoError(t, err)
+
+	_, err = orders.Checkout(ctx, other, 0, "jne", "REG", "")
+	assert.True(t, errors.Is(err, usecases.ErrAddressRequired))
+
+	// Address 1 belongs to the shopper.
+	_, err = orders.Checkout(ctx, other, 1, "jne", "REG", "")
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+}
+
+func TestOrderUseCase_QuoteShippingForTheCart(t *testing.T) {
+	orders, carts, _ := newTestOrderUseCase()
+	owner := usecases.CartOwner{UserID: shopper.UserID}
+	_, err := carts.AddItem(ctx, owner, 1, 2)
+	assert.NoError(t, err)
+	_, err = carts.AddItem(ctx, owner, 2, 1)
+	assert.NoError(t, err)
+
+	// 1.6 kg is charged as 2 kg. The Jakarta rate of JNE REG beats its
+	// nationwide rate, and the cheapest service comes first.
+	quotes, err := orders.QuoteShipping(ctx, shopper, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, quotes, 2) {
+		assert.Equal(t, "sicepat", quotes[0].Courier)
+		assert.Equal(t, 15000.0, quotes[0].Price)
+		assert.Equal(t, "jne", quotes[1].Courier)
+		assert.Equal(t, 18000.0, quotes[1].Price)
+		assert.Equal(t, 2.0, quotes[1].Weight)
+	}
+
+	_, err = orders.Checkout(ctx, shopper, 0, "jne", "YES", "")
+	assert.True(t, errors.Is(err, usecases.ErrShippingUnavailable))
+}
+
+func TestOrderUseCase_OrderVisibility(t *testing.T) {
+	orders, carts, _ := newTestOrderUseCase()
+	order := placeTestOrder(t, orders, carts)
+
+	for _, actor := range []usecases.Actor{shopper, tokoA, admin} {
+		_, err := orders.GetOrder(ctx, actor, order.ID)
+		assert.NoError(t, err)
+	}
+	for _, actor := range []usecases.Actor{other, tokoB} {
+		_, err := orders.GetOrder(ctx, actor, order.ID)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+	}
+
+	sales, err := orders.ListSales(ctx, tokoA, "", pagination.Params{Limit: 20})
+	assert.NoError(t, err)
+	assert.Len(t, sales.Items, 1)
+
+	sales, err = orders.ListSales(ctx, tokoB, "", pagination.Params{Limit: 20})
+	assert.NoError(t, err)
+	assert.Empty(t, sales.Items)
+}
+
+func TestOrderUseCase_StatusFlow(t *testing.T) {
+	orders, carts, products := newTestOrderUseCase()
+	order := placeTestOrder(t, orders, carts)
+
+	_, err := orders.UpdateStatus(ctx, tokoA, order.ID, entities.OrderStatusPaid, "")
+	assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+
+	steps := []struct {
+		actor  usecases.Actor
+		status string
+	}{
+		{admin, entities.OrderStatusPaid},
+		{tokoA, entities.OrderStatusProcessing},
+		{tokoA, entities.OrderStatusShipped},
+		{shopper, entities.OrderStatusDelivered},
+	}
+	for _, step := range steps {
+		updated, err := orders.UpdateStatus(ctx, step.actor, order.ID, step.status, "")
+		assert.NoError(t, err)
+		assert.Equal(t, step.status, updated.Status)
+	}
+
+	_, err = orders.UpdateStatus(ctx, admin, order.ID, entities.OrderStatusShipped, "")
+	assert.True(t, errors.Is(err, entities.ErrInvalidOrderTransition))
+
+	stored, err := orders.GetOrder(ctx, admin, order.ID)
+	assert.NoError(t, err)
+	assert.Len(t, stored.History, 5)
+	assert.Equal(t, 2, products.products[0].SoldCount)
+}
+
+func TestOrderUseCase_CancelRestocks(t *testing.T) {
+	orders, carts, products := newTestOrderUseCase()
+	order := placeTestOrder(t, orders, carts)
+
+	_, err := orders.UpdateStatus(ctx, other, order.ID, entities.OrderStatusCancelled, "")
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+	cancelled, err := orders.UpdateStatus(ctx, shopper, order.ID, entities.OrderStatusCancelled, "Salah pilih ukuran")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusCancelled, cancelled.Status)
+	assert.Equal(t, 5, products.products[0].StockQuantity)
+	assert.Equal(t, 10, products.products[1].StockQuantity)
+
+	second := placeTestOrder(t, orders, carts)
+	_, err = orders.UpdateStatus(ctx, admin, second.ID, entities.OrderStatusPaid, "")
+	assert.NoError(t, err)
+
+	// Paid orders can no longer be cancelled by the customer.
+	_, err = orders.UpdateStatus(ctx, shopper, second.ID, entities.OrderStatusCancelled, "")
+	assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+}
+
+func TestOrderUseCase_CheckoutRedeemsCoupons(t *testing.T) {
+	orders, carts, orderRepo := newTestOrderUseCaseWithRepository()
+	owner := usecases.CartOwner{UserID: shopper.UserID}
+	maxDiscount, shippingCap, perUser := 8000.0, 10000.0, 1
+	couponRepo := orderRepo.coupons
+	couponRepo.coupons = []*entities.Coupon{
+		{ID: 1, Code: "HEMAT10", Type: entities.CouponTypePercentage, Value: 10, MaxDiscount: &maxDiscount, PerUserLimit: &perUser, SellerIDs: []int64{tokoA.UserID}, IsActive: true},
+		{ID: 2, Code: "ONGKIR", Type: entities.CouponTypeFreeShipping, MaxDiscount: &shippingCap, IsActive: true},
+	}
+
+	_, err := carts.AddItem(ctx, owner, 1, 2)
+	assert.NoError(t, err)
+	_, err = carts.AddItem(ctx, owner, 2, 1)
+	assert.NoError(t, err)
+	_, err = carts.ApplyCoupon(ctx, owner, "hemat10")
+	assert.NoError(t, err)
+
+	order, err := orders.Checkout(ctx, shopper, 0, "jne", "REG", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "HEMAT10", order.CouponCode)
+	assert.Equal(t, 8000.0, order.CouponDiscount)
+	assert.Equal(t, 28000.0, order.DiscountTotal)
+	assert.Equal(t, 115000.0, order.Total)
+	assert.Equal(t, 1, couponRepo.coupons[0].UsedCount)
+	assert.Len(t, couponRepo.redemptions, 1)
+
+	cart, err := carts.GetCart(ctx, owner)
+	assert.NoError(t, err)
+	assert.Nil(t, cart.Coupon)
+
+	// The per-user limit is reached until the order is cancelled.
+	_, err = carts.AddItem(ctx, owner, 2, 1)
+	assert.NoError(t, err)
+	_, err = carts.ApplyCoupon(ctx, owner, "HEMAT10")
+	assert.True(t, errors.Is(err, entities.ErrCouponUserLimit))
+
+	_, err = orders.UpdateStatus(ctx, shopper, order.ID, entities.OrderStatusCancelled, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, couponRepo.coupons[0].UsedCount)
+	assert.Empty(t, couponRepo.redemptions)
+
+	_, err = carts.ApplyCoupon(ctx, owner, "ONGKIR")
+	assert.NoError(t, err)
+	order, err = orders.Checkout(ctx, shopper, 0, "jne", "REG", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, order.CouponDiscount)
+	assert.Equal(t, 9000.0, order.ShippingDiscount)
+	assert.Equal(t, 25000.0, order.Total)
+}
+
+func TestOrderUseCase_CheckoutRechecksCouponLimits(t *testing.T) {
+	orders, carts, orderRepo := newTestOrderUseCaseWithRepository()
+	owner := usecases.CartOwner{UserID: shopper.UserID}
+	usageLimit := 1
+	orderRepo.coupons.coupons = []*entities.Coupon{
+		{ID: 1, Code: "KILAT", Type: entities.CouponTypeFixed, Value: 20000, UsageLimit: &usageLimit, IsActive: true},
+	}
+
+	_, err := carts.AddItem(ctx, owner, 2, 1)
+	assert.NoError(t, err)
+	_, err = carts.ApplyCoupon(ctx, owner, "KILAT")
+	assert.NoError(t, err)
+
+	// Another customer redeems the last use after the coupon was applied.
+	orderRepo.coupons.coupons[0].UsedCount = 1
+	_, err = orders.Checkout(ctx, shopper, 0, "jne", "REG", "")
+	assert.True(t, errors.Is(err, entities.ErrCouponUsedUp))
+	assert.Empty(t, orderRepo.orders)
+	assert.Equal(t, 10, orderRepo.products.products[1].StockQuantity)
+}
+
+func TestOrderUseCase_SoldOutProductsFollowStock(t *testing.T) {
+	orders, carts, products := newTestOrderUseCase()
+
+	_, err := carts.AddItem(ctx, usecases.CartOwner{UserID: shopper.UserID}, 1, 5)
+	assert.NoError(t, err)
+	order, err := orders.Checkout(ctx, shopper, 0, "jne", "REG", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, products.products[0].StockQuantity)
+	assert.Equal(t, entities.ProductStatusOutOfStock, products.products[0].Status)
+
+	_, err = carts.AddItem(ctx, usecases.CartOwner{UserID: other.UserID}, 1, 1)
+	assert.True(t, errors.Is(err, entities.ErrInsufficientStock))
+
+	_, err = orders.UpdateStatus(ctx, shopper, order.ID, entities.OrderStatusCancelled, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, products.products[0].StockQuantity)
+	assert.Equal(t, entities.ProductStatusPublished, products.products[0].Status)
+}
+
+func TestOrderUseCase_CancelOverdueOrders(t *testing.T) {
+	orders, carts, products := newTestOrderUseCase()
+	overdue := placeTestOrder(t, orders, carts)
+	pending := placeTestOrder(t, orders, carts)
+	assert.Equal(t, 1, products.products[0].StockQuantity)
+
+	past := time.Now().Add(-time.Minute)
+	overdue.PaymentDueAt = &past
+
+	cancelled, err := orders.CancelOverdueOrders(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cancelled)
+	assert.Equal(t, 3, products.products[0].StockQuantity)
+
+	stored, err := orders.GetOrder(ctx, admin, overdue.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusCancelled, stored.Status)
+	assert.Nil(t, stored.History[1].ChangedBy)
+
+	stored, err = orders.GetOrder(ctx, admin, pending.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusPendingPayment, stored.Status)
+}
+
+func TestOrderUseCase_SellersOnlyMoveTheirOwnOrders(t *testing.T) {
+	orders, carts, products := newTestOrderUseCase()
+	products.products = append(products.products, &entities.Product{
+		ID: 3, UserID: tokoB.UserID, Name: "Tas", SKU: "B-1", Price: 75000, StockQuantity: 4, IsActive: true, Status: entities.ProductStatusPublished,
+	})
+	_, err := carts.AddItem(ctx, usecases.CartOwner{UserID: shopper.UserID}, 3, 1)
+	assert.NoError(t, err)
+	shared := placeTestOrder(t, orders, carts)
+
+	_, err = orders.UpdateStatus(ctx, admin, shared.ID, entities.OrderStatusPaid, "")
+	assert.NoError(t, err)
+	for _, seller := range []usecases.Actor{tokoA, tokoB} {
+		_, err = orders.UpdateStatus(ctx, seller, shared.ID, entities.OrderStatusProcessing, "")
+		assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+	}
+	updated, err := orders.UpdateStatus(ctx, admin, shared.ID, entities.OrderStatusProcessing, "")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusProcessing, updated.Status)
+}
+
+func TestOrderUseCase_PaidOrdersAreCancelledByRefunds(t *testing.T) {
+	orders, carts, _ := newTestOrderUseCase()
+	order := placeTestOrder(t, orders, carts)
+
+	_, err := orders.UpdateStatus(ctx, admin, order.ID, entities.OrderStatusPaid, "")
+	assert.NoError(t, err)
+	for _, actor := range []usecases.Actor{tokoA, admin} {
+		_, err = orders.UpdateStatus(ctx, actor, order.ID, entities.OrderStatusCancelled, "")
+		assert.True(t, errors.Is(err, usecases.ErrRefundRequired), "actor %d", actor.UserID)
+	}
+
+	stored, err := orders.GetOrder(ctx, admin, order.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusPaid, stored.Status)
+}
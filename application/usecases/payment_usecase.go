@@ -0,0 +1,345 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+var (
+	ErrOrderNotPayable            = apperrors.NewAppError(409, "order_not_payable", "Only orders awaiting payment can be paid", nil)
+	ErrNoPaidPayment              = apperrors.NewAppError(409, "no_paid_payment", "Order has no paid payment to refund", nil)
+	ErrPaymentAmountMismatch      = apperrors.NewAppError(409, "payment_amount_mismatch", "Notified amount does not match the payment", nil)
+	ErrInvalidPaymentSignature    = apperrors.NewAppError(401, "invalid_signature", "Invalid payment notification signature", nil)
+	ErrInvalidPaymentNotification = apperrors.NewAppError(400, "invalid_notification", "Invalid payment notification", nil)
+	ErrPaymentGatewayUnavailable  = apperrors.NewAppError(502, "payment_gateway_error", "Payment gateway request failed", nil)
+)
+
+const paymentCurrency = "IDR"
+
+type PaymentUseCase interface {
+	CreatePayment(ctx context.Context, actor Actor, orderID int64) (*entities.Payment, error)
+	ListPayments(ctx context.Context, actor Actor, orderID int64) ([]*entities.Payment, error)
+	HandleNotification(ctx context.Context, payload []byte, headers http.Header) (*entities.Payment, error)
+	Refund(ctx context.Context, actor Actor, orderID int64, reason string) (*entities.Order, error)
+	ExpireOverduePayments(ctx context.Context) (int, error)
+}
+
+type paymentUseCase struct {
+	paymentRepo  repositories.PaymentRepository
+	orderRepo    repositories.OrderRepository
+	orderUseCase OrderUseCase
+	gateway      ports.PaymentGateway
+}
+
+func NewPaymentUseCase(
+	paymentRepo repositories.PaymentRepository,
+	orderRepo repositories.OrderRepository,
+	orderUseCase OrderUseCase,
+	gateway ports.PaymentGateway,
+) PaymentUseCase {
+	return &paymentUseCase{
+		paymentRepo:  paymentRepo,
+		orderRepo:    orderRepo,
+		orderUseCase: orderUseCase,
+		gateway:      gateway,
+	}
+}
+
+// CreatePayment opens a charge at the gateway for an order of the actor that
+// awaits payment. The charge expires with the order's payment deadline. An
+// order has one pending charge at a time, which is returned when it exists.
+func (u *paymentUseCase) CreatePayment(ctx context.Context, actor Actor, orderID int64) (*entities.Payment, error) {
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != actor.UserID {
+		return nil, apperrors.NewNotFoundError("Order")
+	}
+	if order.Status != entities.OrderStatusPendingPayment || order.PaymentDueAt == nil {
+		return nil, ErrOrderNotPayable
+	}
+
+	if pending, err := u.paymentRepo.GetPendingByOrderID(ctx, order.ID); err == nil {
+		return pending, nil
+	} else if !errors.Is(err, apperrors.ErrNotFound) {
+		return nil, err
+	}
+
+	charge, err := u.gateway.CreateCharge(ctx, &ports.ChargeRequest{
+		OrderNumber: order.OrderNumber,
+		Amount:      order.Total,
+		Currency:    paymentCurrency,
+		ExpiresAt:   *order.PaymentDueAt,
+	})
+	if err != nil {
+		return nil, ErrPaymentGatewayUnavailable
+	}
+
+	payment := &entities.Payment{
+		OrderID:    order.ID,
+		Gateway:    u.gateway.Name(),
+		Reference:  charge.Reference,
+		Status:     entities.PaymentStatusPending,
+		Amount:     order.Total,
+		Currency:   paymentCurrency,
+		PaymentURL: charge.PaymentURL,
+		ExpiresAt:  *order.PaymentDueAt,
+	}
+	if err := u.paymentRepo.Create(ctx, payment); err != nil {
+		// A concurrent request opened a charge first.
+		if errors.Is(err, apperrors.ErrConflict) {
+			return u.paymentRepo.GetPendingByOrderID(ctx, order.ID)
+		}
+		return nil, err
+	}
+	return payment, nil
+}
+
+// ListPayments lists the payments of an order visible to the actor.
+func (u *paymentUseCase) ListPayments(ctx context.Context, actor Actor, orderID int64) ([]*entities.Payment, error) {
+	if _, err := u.orderUseCase.GetOrder(ctx, actor, orderID); err != nil {
+		return nil, err
+	}
+	return u.paymentRepo.ListByOrderID(ctx, orderID)
+}
+
+// HandleNotification applies a webhook call of the gateway. Gateways resend
+// notifications until they are acknowledged, so a notification that was
+// already processed changes nothing. The order follows its payment: a paid
+// payment pays the order, an expired one cancels it, releasing the stock,
+// and money received for an order that can no longer be paid is refunded.
+func (u *paymentUseCase) HandleNotification(ctx context.Context, payload []byte, headers http.Header) (*entities.Payment, error) {
+	notification, err := u.gateway.ParseNotification(ctx, payload, headers)
+	if errors.Is(err, ports.ErrInvalidSignature) {
+		return nil, ErrInvalidPaymentSignature
+	} else if err != nil {
+		return nil, ErrInvalidPaymentNotification.WithDetails(err.Error())
+	}
+
+	payment, err := u.paymentRepo.GetByReference(ctx, u.gateway.Name(), notification.Reference)
+	if err != nil {
+		return nil, err
+	}
+
+	seen, err := u.paymentRepo.NotificationExists(ctx, u.gateway.Name(), notification.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if !seen {
+		if notification.Status == entities.PaymentStatusPaid && !sameAmount(notification.Amount, payment.Amount) {
+			return nil, ErrPaymentAmountMismatch.WithDetails(map[string]interface{}{
+				"expected": payment.Amount,
+				"notified": notification.Amount,
+			})
+		}
+
+		err = u.applyStatus(ctx, payment, notification.Status, &entities.PaymentNotification{
+			Gateway:   u.gateway.Name(),
+			EventID:   notification.EventID,
+			PaymentID: &payment.ID,
+			Status:    notification.Status,
+			Payload:   string(notification.Payload),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Duplicates sync the order too, finishing work an earlier attempt may
+	// have left undone.
+	if err := u.syncOrder(ctx, payment); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// Refund returns the money of an order's paid payment. Paid, processing and
+// delivered orders move to refunded; cancelled orders stay cancelled. A
+// refund that failed half way is finished by retrying it.
+func (u *paymentUseCase) Refund(ctx context.Context, actor Actor, orderID int64, reason string) (*entities.Order, error) {
+	order, err := u.orderUseCase.GetOrder(ctx, actor, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != entities.OrderStatusCancelled && !order.CanTransitionTo(entities.OrderStatusRefunded) {
+		return nil, entities.ErrInvalidOrderTransition.WithDetails(map[string]interface{}{
+			"from":    order.Status,
+			"to":      entities.OrderStatusRefunded,
+			"allowed": order.NextStatuses(),
+		})
+	}
+
+	payments, err := u.paymentRepo.ListByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	var paid *entities.Payment
+	for _, payment := range payments {
+		if payment.Status == entities.PaymentStatusPaid || payment.Status == entities.PaymentStatusRefunding {
+			paid = payment
+		}
+	}
+	if paid == nil {
+		return nil, ErrNoPaidPayment
+	}
+
+	if err := u.refund(ctx, paid); err != nil {
+		return nil, err
+	}
+	if order.Status != entities.OrderStatusCancelled {
+		if err := u.moveOrder(ctx, order, entities.OrderStatusRefunded, &actor.UserID, reason); err != nil {
+			return nil, err
+		}
+	}
+	return u.orderRepo.GetByID(ctx, orderID)
+}
+
+// ExpireOverduePayments settles pending payments past their expiry. The
+// gateway is asked first, so payments made just before the deadline whose
+// notification has not arrived yet still pay the order; the others expire and
+// cancel their order. It returns the number of settled payments.
+func (u *paymentUseCase) ExpireOverduePayments(ctx context.Context) (int, error) {
+	payments, err := u.paymentRepo.ListExpired(ctx, time.Now(), overdueBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, payment := range payments {
+		status := entities.PaymentStatusExpired
+		charge, err := u.gateway.GetCharge(ctx, payment.Reference)
+		if err == nil && charge.Status == entities.PaymentStatusPaid {
+			status = entities.PaymentStatusPaid
+		} else if err != nil && !errors.Is(err, ports.ErrChargeNotFound) {
+			// Try again once the gateway answers; the order deadline still
+			// applies.
+			continue
+		}
+
+		if err := u.applyStatus(ctx, payment, status, nil); err != nil {
+			return settled, err
+		}
+		if err := u.syncOrder(ctx, payment); err != nil {
+			return settled, err
+		}
+		settled++
+	}
+	return settled, nil
+}
+
+// applyStatus moves the payment to status and records the notification that
+// reported it. Statuses the payment cannot move to, such as a late pending
+// notification, only record the notification. When another request changed
+// the payment first, payment is reloaded.
+func (u *paymentUseCase) applyStatus(ctx context.Context, payment *entities.Payment, status string, notification *entities.PaymentNotification) error {
+	from, ok := payment.TransitionTo(status, time.Now())
+	if !ok {
+		if notification == nil {
+			return nil
+		}
+		return u.paymentRepo.RecordNotification(ctx, notification)
+	}
+
+	err := u.paymentRepo.UpdateStatus(ctx, payment, from, notification)
+	if errors.Is(err, apperrors.ErrConflict) {
+		latest, err := u.paymentRepo.GetByReference(ctx, payment.Gateway, payment.Reference)
+		if err != nil {
+			return err
+		}
+		*payment = *latest
+		return nil
+	}
+	return err
+}
+
+// syncOrder moves the order of payment to the status its payment calls for.
+// It is safe to call repeatedly. When another request moves the order at the
+// same time, the order is reloaded and synced again, so that e.g. a payment
+// for an order cancelled in the meantime is refunded.
+func (u *paymentUseCase) syncOrder(ctx context.Context, payment *entities.Payment) error {
+	err := u.syncOrderOnce(ctx, payment)
+	if errors.Is(err, apperrors.ErrConflict) {
+		err = u.syncOrderOnce(ctx, payment)
+	}
+	return err
+}
+
+func (u *paymentUseCase) syncOrderOnce(ctx context.Context, payment *entities.Payment) error {
+	order, err := u.orderRepo.GetByID(ctx, payment.OrderID)
+	if err != nil {
+		return err
+	}
+
+	switch payment.Status {
+	case entities.PaymentStatusPaid:
+		if order.Status == entities.OrderStatusPendingPayment {
+			return u.moveOrder(ctx, order, entities.OrderStatusPaid, nil, "Paid via "+payment.Gateway)
+		}
+		if order.Status == entities.OrderStatusCancelled {
+			return u.refund(ctx, payment)
+		}
+	case entities.PaymentStatusRefunding:
+		if order.Status == entities.OrderStatusCancelled {
+			return u.refund(ctx, payment)
+		}
+	case entities.PaymentStatusExpired:
+		if order.Status == entities.OrderStatusPendingPayment {
+			return u.moveOrder(ctx, order, entities.OrderStatusCancelled, nil, "Payment expired")
+		}
+	case entities.PaymentStatusRefunded:
+		if order.CanTransitionTo(entities.OrderStatusRefunded) {
+			return u.moveOrder(ctx, order, entities.OrderStatusRefunded, nil, "Refunded via "+payment.Gateway)
+		}
+	}
+	return nil
+}
+
+func (u *paymentUseCase) moveOrder(ctx context.Context, order *entities.Order, status string, changedBy *int64, note string) error {
+	entry, err := order.TransitionTo(status, changedBy, note)
+	if err != nil {
+		return err
+	}
+	return u.orderRepo.UpdateStatus(ctx, order, entry)
+}
+
+// refund returns the money of a paid payment. The refund and its reference
+// are saved as refunding before the gateway is called, and the gateway
+// refunds a reference once, so retrying a refund that failed at any step
+// never pays out twice.
+func (u *paymentUseCase) refund(ctx context.Context, payment *entities.Payment) error {
+	if payment.Status == entities.PaymentStatusPaid {
+		reference := "refund-" + payment.Reference
+		payment.RefundReference = &reference
+		if err := u.applyStatus(ctx, payment, entities.PaymentStatusRefunding, nil); err != nil {
+			return err
+		}
+	}
+	// Another request may have finished the refund in the meantime.
+	if payment.Status != entities.PaymentStatusRefunding || payment.RefundReference == nil {
+		return nil
+	}
+
+	err := u.gateway.Refund(ctx, &ports.RefundRequest{
+		Reference:       payment.Reference,
+		RefundReference: *payment.RefundReference,
+		Amount:          payment.Amount,
+	})
+	if err != nil {
+		return ErrPaymentGatewayUnavailable
+	}
+	return u.applyStatus(ctx, payment, entities.PaymentStatusRefunded, nil)
+}
+
+// sameAmount compares money amounts to the cent.
+func sameAmount(a, b float64) bool {
+	return math.Abs(a-b) < 0.005
+}
@@ -0,0 +1,320 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/payment"
+)
+
+type MockPaymentRepository struct {
+	payments      []*entities.Payment
+	notifications []*entities.PaymentNotification
+}
+
+func (m *MockPaymentRepository) Create(ctx context.Context, payment *entities.Payment) error {
+	if _, err := m.GetPendingByOrderID(ctx, payment.OrderID); err == nil {
+		return apperrors.NewConflictError("Order already has a pending payment")
+	}
+	payment.ID = int64(len(m.payments) + 1)
+	stored := *payment
+	m.payments = append(m.payments, &stored)
+	return nil
+}
+
+func (m *MockPaymentRepository) GetByReference(ctx context.Context, gateway, reference string) (*entities.Payment, error) {
+	for _, payment := range m.payments {
+		if payment.Gateway == gateway && payment.Reference == reference {
+			clone := *payment
+			return &clone, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Payment")
+}
+
+func (m *MockPaymentRepository) GetPendingByOrderID(ctx context.Context, orderID int64) (*entities.Payment, error) {
+	for _, payment := range m.payments {
+		if payment.OrderID == orderID && payment.Status == entities.PaymentStatusPending {
+			clone := *payment
+			return &clone, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Payment")
+}
+
+func (m *MockPaymentRepository) ListByOrderID(ctx context.Context, orderID int64) ([]*entities.Payment, error) {
+	var payments []*entities.Payment
+	for _, payment := range m.payments {
+		if payment.OrderID == orderID {
+			clone := *payment
+			payments = append(payments, &clone)
+		}
+	}
+	return payments, nil
+}
+
+func (m *MockPaymentRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entities.Payment, error) {
+	var payments []*entities.Payment
+	for _, payment := range m.payments {
+		if payment.Status == entities.PaymentStatusPending && !payment.ExpiresAt.After(now) {
+			clone := *payment
+			payments = append(payments, &clone)
+		}
+	}
+	return payments, nil
+}
+
+func (m *MockPaymentRepository) NotificationExists(ctx context.Context, gateway, eventID string) (bool, error) {
+	for _, notification := range m.notifications {
+		if notification.Gateway == gateway && notification.EventID == eventID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockPaymentRepository) RecordNotification(ctx context.Context, notification *entities.PaymentNotification) error {
+	if exists, _ := m.NotificationExists(ctx, notification.Gateway, notification.EventID); !exists {
+		m.notifications = append(m.notifications, notification)
+	}
+	return nil
+}
+
+func (m *MockPaymentRepository) UpdateStatus(ctx context.Context, payment *entities.Payment, from string, notification *entities.PaymentNotification) error {
+	stored := m.payments[payment.ID-1]
+	if stored.Status != from {
+		return apperrors.NewConflictError("Payment status was changed by another request")
+	}
+	if notification != nil {
+		if exists, _ := m.NotificationExists(ctx, notification.Gateway, notification.EventID); exists {
+			return apperrors.NewConflictError("Payment notification was already processed")
+		}
+		m.notifications = append(m.notifications, notification)
+	}
+	*stored = *payment
+	return nil
+}
+
+type paymentFixture struct {
+	payments  usecases.PaymentUseCase
+	orders    usecases.OrderUseCase
+	carts     usecases.CartUseCase
+	orderRepo *MockOrderRepository
+	repo      *MockPaymentRepository
+	gateway   *payment.FakeGateway
+}
+
+func newTestPaymentUseCase() *paymentFixture {
+	orderUseCase, cartUseCase, orderRepo := newTestOrderUseCaseWithRepository()
+	paymentRepo := &MockPaymentRepository{}
+	gateway := payment.NewFakeGateway("webhook-secret")
+	return &paymentFixture{
+		payments:  usecases.NewPaymentUseCase(paymentRepo, orderRepo, orderUseCase, gateway),
+		orders:    orderUseCase,
+		carts:     cartUseCase,
+		orderRepo: orderRepo,
+		repo:      paymentRepo,
+		gateway:   gateway,
+	}
+}
+
+// settle has the fake gateway report the payment with status and delivers
+// the webhook call.
+func (f *paymentFixture) settle(t *testing.T, p *entities.Payment, status string) (*entities.Payment, error) {
+	payload, headers, err := f.gateway.Notify(p.Reference, status)
+	assert.NoError(t, err)
+	return f.payments.HandleNotification(ctx, payload, headers)
+}
+
+func TestPaymentUseCase_WebhookPaysOrderOnce(t *testing.T) {
+	f := newTestPaymentUseCase()
+	order := placeTestOrder(t, f.orders, f.carts)
+
+	p, err := f.payments.CreatePayment(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PaymentStatusPending, p.Status)
+	assert.Equal(t, order.Total, p.Amount)
+	assert.Equal(t, *order.PaymentDueAt, p.ExpiresAt)
+
+	again, err := f.payments.CreatePayment(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, p.ID, again.ID, "an order has one pending payment")
+
+	_, err = f.payments.CreatePayment(ctx, other, order.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+	payload, headers, err := f.gateway.Notify(p.Reference, entities.PaymentStatusPaid)
+	assert.NoError(t, err)
+	paid, err := f.payments.HandleNotification(ctx, payload, headers)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PaymentStatusPaid, paid.Status)
+	assert.NotNil(t, paid.PaidAt)
+
+	// The gateway resends the notification; nothing happens twice.
+	_, err = f.payments.HandleNotification(ctx, payload, headers)
+	assert.NoError(t, err)
+	assert.Len(t, f.repo.notifications, 1)
+
+	stored, err := f.orders.GetOrder(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusPaid, stored.Status)
+	assert.Len(t, stored.History, 2)
+	assert.Equal(t, 2, f.orderRepo.products.products[0].SoldCount)
+
+	_, err = f.payments.CreatePayment(ctx, shopper, order.ID)
+	assert.True(t, errors.Is(err, usecases.ErrOrderNotPayable))
+}
+
+func TestPaymentUseCase_WebhookRejectsForgedNotifications(t *testing.T) {
+	f := newTestPaymentUseCase()
+	order := placeTestOrder(t, f.orders, f.carts)
+	p, err := f.payments.CreatePayment(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+
+	payload, headers, err := f.gateway.Notify(p.Reference, entities.PaymentStatusPaid)
+	assert.NoError(t, err)
+	headers.Set(payment.FakeSignatureHeader, "00ff")
+	_, err = f.payments.HandleNotification(ctx, payload, headers)
+	assert.True(t, errors.Is(err, usecases.ErrInvalidPaymentSignature))
+
+	stored, err := f.orders.GetOrder(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusPendingPayment, stored.Status)
+}
+
+func TestPaymentUseCase_ExpiredPaymentsCancelOrders(t *testing.T) {
+	f := newTestPaymentUseCase()
+	products := f.orderRepo.products.products
+
+	expiring := placeTestOrder(t, f.orders, f.carts)
+	first, err := f.payments.CreatePayment(ctx, shopper, expiring.ID)
+	assert.NoError(t, err)
+
+	// Paid at the gateway, but the notification has not arrived yet.
+	late := placeTestOrder(t, f.orders, f.carts)
+	second, err := f.payments.CreatePayment(ctx, shopper, late.ID)
+	assert.NoError(t, err)
+	_, _, err = f.gateway.Notify(second.Reference, entities.PaymentStatusPaid)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, products[0].StockQuantity)
+	past := time.Now().Add(-time.Minute)
+	f.repo.payments[0].ExpiresAt = past
+	f.repo.payments[1].ExpiresAt = past
+
+	settled, err := f.payments.ExpireOverduePayments(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, settled)
+
+	assert.Equal(t, entities.PaymentStatusExpired, f.repo.payments[first.ID-1].Status)
+	stored, _ := f.orders.GetOrder(ctx, shopper, expiring.ID)
+	assert.Equal(t, entities.OrderStatusCancelled, stored.Status)
+	assert.Equal(t, 3, products[0].StockQuantity, "the expired order releases its stock")
+
+	assert.Equal(t, entities.PaymentStatusPaid, f.repo.payments[second.ID-1].Status)
+	stored, _ = f.orders.GetOrder(ctx, shopper, late.ID)
+	assert.Equal(t, entities.OrderStatusPaid, stored.Status)
+}
+
+func TestPaymentUseCase_RefundsPaymentsForCancelledOrders(t *testing.T) {
+	f := newTestPaymentUseCase()
+	order := placeTestOrder(t, f.orders, f.carts)
+	p, err := f.payments.CreatePayment(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+
+	_, err = f.orders.UpdateStatus(ctx, shopper, order.ID, entities.OrderStatusCancelled, "")
+	assert.NoError(t, err)
+
+	settled, err := f.settle(t, p, entities.PaymentStatusPaid)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PaymentStatusRefunded, settled.Status)
+
+	charge, err := f.gateway.GetCharge(ctx, p.Reference)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PaymentStatusRefunded, charge.Status)
+
+	stored, _ := f.orders.GetOrder(ctx, shopper, order.ID)
+	assert.Equal(t, entities.OrderStatusCancelled, stored.Status)
+}
+
+func TestPaymentUseCase_Refund(t *testing.T) {
+	f := newTestPaymentUseCase()
+	order := placeTestOrder(t, f.orders, f.carts)
+
+	_, err := f.payments.Refund(ctx, admin, order.ID, "")
+	assert.True(t, errors.Is(err, entities.ErrInvalidOrderTransition))
+
+	p, err := f.payments.CreatePayment(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+	_, err = f.settle(t, p, entities.PaymentStatusPaid)
+	assert.NoError(t, err)
+
+	refunded, err := f.payments.Refund(ctx, admin, order.ID, "Item damaged")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusRefunded, refunded.Status)
+	assert.Equal(t, entities.PaymentStatusRefunded, f.repo.payments[p.ID-1].Status)
+	assert.NotNil(t, f.repo.payments[p.ID-1].RefundedAt)
+
+	// The gateway confirms the refund later; the order stays refunded.
+	_, err = f.settle(t, p, entities.PaymentStatusRefunded)
+	assert.NoError(t, err)
+
+	payments, err := f.payments.ListPayments(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+	assert.Len(t, payments, 1)
+	_, err = f.payments.ListPayments(ctx, other, order.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+}
+
+func TestPaymentUseCase_RefundReturnsStock(t *testing.T) {
+	f := newTestPaymentUseCase()
+	product := f.orderRepo.product(1)
+	stock, sold := product.StockQuantity, product.SoldCount
+
+	order := placeTestOrder(t, f.orders, f.carts)
+	p, err := f.payments.CreatePayment(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+	_, err = f.settle(t, p, entities.PaymentStatusPaid)
+	assert.NoError(t, err)
+	assert.Equal(t, stock-2, product.StockQuantity)
+	assert.Equal(t, sold+2, product.SoldCount)
+
+	_, err = f.payments.Refund(ctx, admin, order.ID, "Item damaged")
+	assert.NoError(t, err)
+	assert.Equal(t, stock, product.StockQuantity)
+	assert.Equal(t, sold, product.SoldCount)
+}
+
+func TestPaymentUseCase_RetriedRefundsPayOutOnce(t *testing.T) {
+	f := newTestPaymentUseCase()
+	order := placeTestOrder(t, f.orders, f.carts)
+	p, err := f.payments.CreatePayment(ctx, shopper, order.ID)
+	assert.NoError(t, err)
+	_, err = f.settle(t, p, entities.PaymentStatusPaid)
+	assert.NoError(t, err)
+
+	// An earlier attempt saved the refund and reached the gateway, but failed
+	// before the payment was marked refunded.
+	stored := f.repo.payments[p.ID-1]
+	reference := "refund-" + p.Reference
+	stored.Status = entities.PaymentStatusRefunding
+	stored.RefundReference = &reference
+	assert.NoError(t, f.gateway.Refund(ctx, &ports.RefundRequest{Reference: p.Reference, RefundReference: reference, Amount: p.Amount}))
+
+	refunded, err := f.payments.Refund(ctx, admin, order.ID, "Item damaged")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusRefunded, refunded.Status)
+	assert.Equal(t, entities.PaymentStatusRefunded, stored.Status)
+	assert.Equal(t, reference, *stored.RefundReference)
+
+	// A different refund of the same charge is refused by the gateway.
+	err = f.gateway.Refund(ctx, &ports.RefundRequest{Reference: p.Reference, RefundReference: "refund-other", Amount: p.Amount})
+	assert.Error(t, err)
+}
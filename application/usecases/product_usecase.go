@@ -0,0 +1,417 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+var (
+	ErrInvalidDiscountPrice = apperrors.NewAppError(400, "invalid_discount_price", "Discount price must be lower than the price", nil)
+	ErrNotProductOwner      = apperrors.NewForbiddenError("You can only manage your own products")
+	ErrFeatureNotPermitted  = apperrors.NewForbiddenError("Only product managers can feature products")
+	ErrUnknownCategory      = apperrors.NewAppError(400, "unknown_category", "Unknown category", nil)
+	ErrUnknownTag           = apperrors.NewAppError(400, "unknown_tag", "Unknown tag", nil)
+	ErrEmptySearchQuery     = apperrors.NewAppError(400, "empty_search_query", "Search query must not be empty", nil)
+	ErrInvalidStockMovement = apperrors.NewAppError(400, "invalid_stock_movement", "Restocks and returns must add stock and only returns may reference an order", nil)
+)
+
+const maxSuggestions = 10
+
+// Actor identifies the authenticated user performing an operation.
+type Actor struct {
+	UserID int64
+	RoleID int
+}
+
+type ProductUseCase interface {
+	CreateProduct(ctx context.Context, actor Actor, product *entities.Product) error
+	GetProduct(ctx context.Context, id int64) (*entities.Product, error)
+	GetProductBySlug(ctx context.Context, slug string) (*entities.Product, error)
+	GetManagedProduct(ctx context.Context, actor Actor, id int64) (*entities.Product, error)
+	UpdateProduct(ctx context.Context, actor Actor, product *entities.Product) error
+	DeleteProduct(ctx context.Context, actor Actor, id int64) error
+	ListProducts(ctx context.Context, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error)
+	ListOwnProducts(ctx context.Context, actor Actor, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error)
+	ListProductsByCategory(ctx context.Context, slug string, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error)
+	ListProductsByTag(ctx context.Context, slug string, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error)
+	SearchProducts(ctx context.Context, text string, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error)
+	SuggestProducts(ctx context.Context, text string, limit int) ([]*entities.SearchSuggestion, error)
+	AttachCategories(ctx context.Context, actor Actor, productID int64, categoryIDs []int) (*entities.Product, error)
+	DetachCategory(ctx context.Context, actor Actor, productID int64, categoryID int) (*entities.Product, error)
+	AttachTags(ctx context.Context, actor Actor, productID int64, tagIDs []int) (*entities.Product, error)
+	DetachTag(ctx context.Context, actor Actor, productID int64, tagID int) (*entities.Product, error)
+	AdjustStock(ctx context.Context, actor Actor, movement *entities.StockMovement) error
+	ListStockHistory(ctx context.Context, actor Actor, productID int64, params pagination.Params) (pagination.Page[*entities.StockMovement], error)
+}
+
+type productUseCase struct {
+	productRepo       repositories.ProductRepository
+	categoryRepo      repositories.CategoryRepository
+	tagRepo           repositories.TagRepository
+	permissionRepo    repositories.PermissionRepository
+	stockMovementRepo repositories.StockMovementRepository
+}
+
+func NewProductUseCase(
+	productRepo repositories.ProductRepository,
+	categoryRepo repositories.CategoryRepository,
+	tagRepo repositories.TagRepository,
+	permissionRepo repositories.PermissionRepository,
+	stockMovementRepo repositories.StockMovementRepository,
+) ProductUseCase {
+	return &productUseCase{
+		productRepo:       productRepo,
+		categoryRepo:      categoryRepo,
+		tagRepo:           tagRepo,
+		permissionRepo:    permissionRepo,
+		stockMovementRepo: stockMovementRepo,
+	}
+}
+
+func (u *productUseCase) CreateProduct(ctx context.Context, actor Actor, product *entities.Product) error {
+	if err := validateProduct(product); err != nil {
+		return err
+	}
+	if product.IsFeatured {
+		if err := u.authorizeFeaturing(ctx, actor); err != nil {
+			return err
+		}
+	}
+
+	product.UserID = actor.UserID
+	if product.Status == "" {
+		product.Status = entities.ProductStatusDraft
+	}
+	product.SyncStockStatus()
+
+	slug, err := uniqueSlug(product.Name, func(slug string) (bool, error) {
+		return u.productRepo.SlugExists(ctx, slug, 0)
+	})
+	if err != nil {
+		return err
+	}
+	product.Slug = slug
+
+	return u.productRepo.Create(ctx, product)
+}
+
+// GetProduct returns a product as seen by shoppers: hidden products are
+// reported as not found.
+func (u *productUseCase) GetProduct(ctx context.Context, id int64) (*entities.Product, error) {
+	product, err := u.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsVisible() {
+		return nil, apperrors.NewNotFoundError("Product")
+	}
+	return product, nil
+}
+
+func (u *productUseCase) GetProductBySlug(ctx context.Context, slug string) (*entities.Product, error) {
+	product, err := u.productRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsVisible() {
+		return nil, apperrors.NewNotFoundError("Product")
+	}
+	return product, nil
+}
+
+// GetManagedProduct returns any product, including drafts, as long as the
+// actor owns it or may manage every product.
+func (u *productUseCase) GetManagedProduct(ctx context.Context, actor Actor, id int64) (*entities.Product, error) {
+	product, err := u.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.authorize(ctx, actor, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func (u *productUseCase) UpdateProduct(ctx context.Context, actor Actor, product *entities.Product) error {
+	existing, err := u.GetManagedProduct(ctx, actor, product.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := validateProduct(product); err != nil {
+		return err
+	}
+	if product.IsFeatured != existing.IsFeatured {
+		if err := u.authorizeFeaturing(ctx, actor); err != nil {
+			return err
+		}
+	}
+
+	// Ownership cannot be changed through an update.
+	product.UserID = existing.UserID
+
+	if product.Name != existing.Name {
+		slug, err := uniqueSlug(product.Name, func(slug string) (bool, error) {
+			return u.productRepo.SlugExists(ctx, slug, product.ID)
+		})
+		if err != nil {
+			return err
+		}
+		product.Slug = slug
+	} else {
+		product.Slug = existing.Slug
+	}
+
+	// A new stock quantity is applied as a manual adjustment after saving the
+	// rest, so the ledger explains it and sales made in the meantime are not
+	// overwritten.
+	change := product.StockQuantity - existing.StockQuantity
+	product.StockQuantity = existing.StockQuantity
+	product.SyncStockStatus()
+	if err := u.productRepo.Update(ctx, product); err != nil {
+		return err
+	}
+	if change == 0 {
+		return nil
+	}
+
+	movement := &entities.StockMovement{
+		ProductID:   product.ID,
+		Type:        entities.StockMovementAdjustment,
+		Quantity:    abs(change),
+		StockChange: change,
+		ActorID:     &actor.UserID,
+		Reason:      "Stock quantity updated",
+	}
+	if err := u.productRepo.AdjustStock(ctx, movement); err != nil {
+		return err
+	}
+	product.StockQuantity = movement.BalanceAfter
+	product.SyncStockStatus()
+	return nil
+}
+
+func (u *productUseCase) DeleteProduct(ctx context.Context, actor Actor, id int64) error {
+	if _, err := u.GetManagedProduct(ctx, actor, id); err != nil {
+		return err
+	}
+	return u.productRepo.Delete(ctx, id)
+}
+
+// ListProducts lists the products visible to shoppers.
+func (u *productUseCase) ListProducts(ctx context.Context, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	filter.OnlyVisible = true
+	filter.Status = ""
+	return u.productRepo.List(ctx, filter, params)
+}
+
+// ListOwnProducts lists the products of the actor in any status.
+func (u *productUseCase) ListOwnProducts(ctx context.Context, actor Actor, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	filter.UserID = actor.UserID
+	filter.OnlyVisible = false
+	return u.productRepo.List(ctx, filter, params)
+}
+
+// ListProductsByCategory lists the visible products of an active category.
+func (u *productUseCase) ListProductsByCategory(ctx context.Context, slug string, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	category, err := u.categoryRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return pagination.Page[*entities.Product]{}, err
+	}
+	if !category.IsActive {
+		return pagination.Page[*entities.Product]{}, apperrors.NewNotFoundError("Category")
+	}
+	filter.CategoryID = category.ID
+	return u.ListProducts(ctx, filter, params)
+}
+
+func (u *productUseCase) ListProductsByTag(ctx context.Context, slug string, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	tag, err := u.tagRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return pagination.Page[*entities.Product]{}, err
+	}
+	filter.TagID = tag.ID
+	return u.ListProducts(ctx, filter, params)
+}
+
+// SearchProducts runs a full-text search over the products visible to
+// shoppers.
+func (u *productUseCase) SearchProducts(ctx context.Context, text string, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return pagination.Page[*entities.Product]{}, ErrEmptySearchQuery
+	}
+
+	filter.OnlyVisible = true
+	filter.Status = ""
+	return u.productRepo.Search(ctx, text, filter, params)
+}
+
+func (u *productUseCase) SuggestProducts(ctx context.Context, text string, limit int) ([]*entities.SearchSuggestion, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return []*entities.SearchSuggestion{}, nil
+	}
+	if limit <= 0 || limit > maxSuggestions {
+		limit = maxSuggestions
+	}
+	return u.productRepo.Suggest(ctx, text, limit)
+}
+
+func (u *productUseCase) AttachCategories(ctx context.Context, actor Actor, productID int64, categoryIDs []int) (*entities.Product, error) {
+	if _, err := u.GetManagedProduct(ctx, actor, productID); err != nil {
+		return nil, err
+	}
+
+	categories, err := u.categoryRepo.GetByIDs(ctx, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int]bool, len(categories))
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+	if unknown := missingIDs(categoryIDs, known); len(unknown) > 0 {
+		return nil, ErrUnknownCategory.WithDetails(unknown)
+	}
+
+	if err := u.productRepo.AttachCategories(ctx, productID, categoryIDs); err != nil {
+		return nil, err
+	}
+	return u.productRepo.GetByID(ctx, productID)
+}
+
+func (u *productUseCase) DetachCategory(ctx context.Context, actor Actor, productID int64, categoryID int) (*entities.Product, error) {
+	if _, err := u.GetManagedProduct(ctx, actor, productID); err != nil {
+		return nil, err
+	}
+	if err := u.productRepo.DetachCategory(ctx, productID, categoryID); err != nil {
+		return nil, err
+	}
+	return u.productRepo.GetByID(ctx, productID)
+}
+
+func (u *productUseCase) AttachTags(ctx context.Context, actor Actor, productID int64, tagIDs []int) (*entities.Product, error) {
+	if _, err := u.GetManagedProduct(ctx, actor, productID); err != nil {
+		return nil, err
+	}
+
+	tags, err := u.tagRepo.GetByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int]bool, len(tags))
+	for _, tag := range tags {
+		known[tag.ID] = true
+	}
+	if unknown := missingIDs(tagIDs, known); len(unknown) > 0 {
+		return nil, ErrUnknownTag.WithDetails(unknown)
+	}
+
+	if err := u.productRepo.AttachTags(ctx, productID, tagIDs); err != nil {
+		return nil, err
+	}
+	return u.productRepo.GetByID(ctx, productID)
+}
+
+func (u *productUseCase) DetachTag(ctx context.Context, actor Actor, productID int64, tagID int) (*entities.Product, error) {
+	if _, err := u.GetManagedProduct(ctx, actor, productID); err != nil {
+		return nil, err
+	}
+	if err := u.productRepo.DetachTag(ctx, productID, tagID); err != nil {
+		return nil, err
+	}
+	return u.productRepo.GetByID(ctx, productID)
+}
+
+// AdjustStock records a restock, return or manual adjustment of the stock of
+// a product the actor manages. StockChange is signed; restocks and returns
+// must add stock.
+func (u *productUseCase) AdjustStock(ctx context.Context, actor Actor, movement *entities.StockMovement) error {
+	if _, err := u.GetManagedProduct(ctx, actor, movement.ProductID); err != nil {
+		return err
+	}
+
+	if !entities.IsManualStockMovement(movement.Type) || movement.StockChange == 0 {
+		return ErrInvalidStockMovement
+	}
+	if movement.Type != entities.StockMovementAdjustment && movement.StockChange < 0 {
+		return ErrInvalidStockMovement
+	}
+	if movement.Type != entities.StockMovementReturn && movement.OrderID != nil {
+		return ErrInvalidStockMovement
+	}
+
+	movement.Quantity = abs(movement.StockChange)
+	movement.ActorID = &actor.UserID
+	return u.productRepo.AdjustStock(ctx, movement)
+}
+
+// ListStockHistory lists the stock movements of a product the actor manages,
+// newest first.
+func (u *productUseCase) ListStockHistory(ctx context.Context, actor Actor, productID int64, params pagination.Params) (pagination.Page[*entities.StockMovement], error) {
+	if _, err := u.GetManagedProduct(ctx, actor, productID); err != nil {
+		return pagination.Page[*entities.StockMovement]{}, err
+	}
+	return u.stockMovementRepo.ListByProduct(ctx, productID, params)
+}
+
+func (u *productUseCase) authorize(ctx context.Context, actor Actor, product *entities.Product) error {
+	if product.UserID == actor.UserID {
+		return nil
+	}
+
+	allowed, err := u.permissionRepo.RoleHasPermission(ctx, actor.RoleID, entities.PermissionProductsManage)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrNotProductOwner
+	}
+	return nil
+}
+
+// authorizeFeaturing allows only users with products:manage to feature
+// products on the storefront, sellers included.
+func (u *productUseCase) authorizeFeaturing(ctx context.Context, actor Actor) error {
+	allowed, err := u.permissionRepo.RoleHasPermission(ctx, actor.RoleID, entities.PermissionProductsManage)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrFeatureNotPermitted
+	}
+	return nil
+}
+
+func validateProduct(product *entities.Product) error {
+	if product.DiscountPrice != nil && *product.DiscountPrice >= product.Price {
+		return ErrInvalidDiscountPrice
+	}
+	if product.Status != "" && !entities.IsValidProductStatus(product.Status) {
+		return apperrors.NewBadRequestError("Invalid product status")
+	}
+	return nil
+}
+
+func missingIDs(ids []int, known map[int]bool) []int {
+	var missing []int
+	for _, id := range ids {
+		if !known[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
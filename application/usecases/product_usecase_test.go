@@ -0,0 +1,612 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+type MockProductRepository struct {
+	products  []*entities.Product
+	movements []*entities.StockMovement
+}
+
+func (m *MockProductRepository) Create(ctx context.Context, product *entities.Product) error {
+	for _, existing := range m.products {
+		if existing.SKU == product.SKU {
+			return apperrors.NewConflictError("SKU is already used by another product")
+		}
+	}
+	product.ID = int64(len(m.products) + 1)
+	m.products = append(m.products, product)
+	m.record(&entities.StockMovement{
+		ProductID:   product.ID,
+		Type:        entities.StockMovementInitial,
+		Quantity:    product.StockQuantity,
+		StockChange: product.StockQuantity,
+	}, product.StockQuantity)
+	return nil
+}
+
+func (m *MockProductRepository) GetByID(ctx context.Context, id int64) (*entities.Product, error) {
+	for _, product := range m.products {
+		if product.ID == id && !product.DeletedAt.Valid {
+			clone := *product
+			return &clone, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Product")
+}
+
+func (m *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*entities.Product, error) {
+	for _, product := range m.products {
+		if product.Slug == slug && !product.DeletedAt.Valid {
+			clone := *product
+			return &clone, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Product")
+}
+
+func (m *MockProductRepository) SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	for _, product := range m.products {
+		if product.Slug == slug && product.ID != excludeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, product *entities.Product) error {
+	for i, existing := range m.products {
+		if existing.ID == product.ID {
+			stored := *product
+			stored.StockQuantity = existing.StockQuantity
+			m.products[i] = &stored
+			return nil
+		}
+	}
+	return apperrors.NewNotFoundError("Product")
+}
+
+func (m *MockProductRepository) AdjustStock(ctx context.Context, movement *entities.StockMovement) error {
+	for _, product := range m.products {
+		if product.ID == movement.ProductID {
+			if product.StockQuantity+movement.StockChange < 0 {
+				return entities.ErrInsufficientStock
+			}
+			product.StockQuantity += movement.StockChange
+			product.SyncStockStatus()
+			m.record(movement, product.StockQuantity)
+			return nil
+		}
+	}
+	return apperrors.NewNotFoundError("Product")
+}
+
+func (m *MockProductRepository) record(movement *entities.StockMovement, balance int) {
+	movement.ID = int64(len(m.movements) + 1)
+	movement.BalanceAfter = balance
+	m.movements = append(m.movements, movement)
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id int64) error {
+	for _, product := range m.products {
+		if product.ID == id && !product.DeletedAt.Valid {
+			product.DeletedAt.Time, product.DeletedAt.Valid = time.Now(), true
+			return nil
+		}
+	}
+	return apperrors.NewNotFoundError("Product")
+}
+
+func (m *MockProductRepository) GetDeletedByID(ctx context.Context, id int64) (*entities.Product, error) {
+	for _, product := range m.products {
+		if product.ID == id && product.DeletedAt.Valid {
+			clone := *product
+			return &clone, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Product")
+}
+
+func (m *MockProductRepository) ListDeleted(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	var products []*entities.Product
+	for _, product := range m.products {
+		if product.DeletedAt.Valid {
+			products = append(products, product)
+		}
+	}
+	return pagination.Page[*entities.Product]{Items: products}, nil
+}
+
+func (m *MockProductRepository) Restore(ctx context.Context, id int64) error {
+	product, err := m.GetDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, stored := range m.products {
+		if stored.ID == product.ID {
+			stored.DeletedAt.Valid = false
+		}
+	}
+	return nil
+}
+
+func (m *MockProductRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var kept []*entities.Product
+	for _, product := range m.products {
+		if !product.DeletedAt.Valid || !product.DeletedAt.Time.Before(before) {
+			kept = append(kept, product)
+		}
+	}
+	purged := int64(len(m.products) - len(kept))
+	m.products = kept
+	return purged, nil
+}
+
+func (m *MockProductRepository) AttachCategories(ctx context.Context, productID int64, categoryIDs []int) error {
+	for _, product := range m.products {
+		if product.ID == productID {
+			for _, id := range categoryIDs {
+				product.Categories = append(product.Categories, entities.Category{ID: id})
+			}
+			return nil
+		}
+	}
+	return apperrors.NewNotFoundError("Product")
+}
+
+func (m *MockProductRepository) DetachCategory(ctx context.Context, productID int64, categoryID int) error {
+	return nil
+}
+
+func (m *MockProductRepository) AttachTags(ctx context.Context, productID int64, tagIDs []int) error {
+	return nil
+}
+
+func (m *MockProductRepository) DetachTag(ctx context.Context, productID int64, tagID int) error {
+	return nil
+}
+
+func (m *MockProductRepository) List(ctx context.Context, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	var products []*entities.Product
+	for _, product := range m.products {
+		if filter.UserID != 0 && product.UserID != filter.UserID {
+			continue
+		}
+		if filter.OnlyVisible && !product.IsVisible() {
+			continue
+		}
+		products = append(products, product)
+	}
+	return pagination.Page[*entities.Product]{Items: products}, nil
+}
+
+type MockCategoryRepository struct {
+	categories []*entities.Category
+}
+
+func (m *MockCategoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	category.ID = len(m.categories) + 1
+	m.categories = append(m.categories, category)
+	return nil
+}
+
+func (m *MockCategoryRepository) GetByID(ctx context.Context, id int) (*entities.Category, error) {
+	for _, category := range m.categories {
+		if category.ID == id && !category.DeletedAt.Valid {
+			return category, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Category")
+}
+
+func (m *MockCategoryRepository) GetBySlug(ctx context.Context, slug string) (*entities.Category, error) {
+	for _, category := range m.categories {
+		if category.Slug == slug && !category.DeletedAt.Valid {
+			return category, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Category")
+}
+
+func (m *MockCategoryRepository) GetByIDs(ctx context.Context, ids []int) ([]*entities.Category, error) {
+	var categories []*entities.Category
+	for _, id := range ids {
+		if category, err := m.GetByID(ctx, id); err == nil {
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
+
+func (m *MockCategoryRepository) SlugExists(ctx context.Context, slug string, excludeID int) (bool, error) {
+	for _, category := range m.categories {
+		if category.Slug == slug && category.ID != excludeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockCategoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	return nil
+}
+
+func (m *MockCategoryRepository) Delete(ctx context.Context, id int) error {
+	category, err := m.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	category.DeletedAt.Time, category.DeletedAt.Valid = time.Now(), true
+	return nil
+}
+
+func (m *MockCategoryRepository) List(ctx context.Context, onlyActive bool, params pagination.Params) (pagination.Page[*entities.Category], error) {
+	return pagination.Page[*entities.Category]{Items: m.categories}, nil
+}
+
+func (m *MockCategoryRepository) ListDeleted(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Category], error) {
+	var categories []*entities.Category
+	for _, category := range m.categories {
+		if category.DeletedAt.Valid {
+			categories = append(categories, category)
+		}
+	}
+	return pagination.Page[*entities.Category]{Items: categories}, nil
+}
+
+func (m *MockCategoryRepository) Restore(ctx context.Context, id int) error {
+	for _, category := range m.categories {
+		if category.ID == id && category.DeletedAt.Valid {
+			category.DeletedAt.Valid = false
+			return nil
+		}
+	}
+	return apperrors.NewNotFoundError("Category")
+}
+
+func (m *MockCategoryRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var kept []*entities.Category
+	for _, category := range m.categories {
+		if !category.DeletedAt.Valid || !category.DeletedAt.Time.Before(before) {
+			kept = append(kept, category)
+		}
+	}
+	purged := int64(len(m.categories) - len(kept))
+	m.categories = kept
+	return purged, nil
+}
+
+type MockTagRepository struct{}
+
+func (m *MockTagRepository) Create(ctx context.Context, tag *entities.Tag) error { return nil }
+
+func (m *MockTagRepository) GetByID(ctx context.Context, id int) (*entities.Tag, error) {
+	return nil, apperrors.NewNotFoundError("Tag")
+}
+
+func (m *MockTagRepository) GetBySlug(ctx context.Context, slug string) (*entities.Tag, error) {
+	return nil, apperrors.NewNotFoundError("Tag")
+}
+
+func (m *MockTagRepository) GetByIDs(ctx context.Context, ids []int) ([]*entities.Tag, error) {
+	return nil, nil
+}
+
+func (m *MockTagRepository) SlugExists(ctx context.Context, slug string, excludeID int) (bool, error) {
+	return false, nil
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, tag *entities.Tag) error { return nil }
+
+func (m *MockTagRepository) Delete(ctx context.Context, id int) error { return nil }
+
+func (m *MockTagRepository) List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Tag], error) {
+	return pagination.Page[*entities.Tag]{}, nil
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, text string, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	var products []*entities.Product
+	for _, product := range m.products {
+		if strings.Contains(strings.ToLower(product.Name), strings.ToLower(text)) && (!filter.OnlyVisible || product.IsVisible()) {
+			products = append(products, product)
+		}
+	}
+	return pagination.Page[*entities.Product]{Items: products}, nil
+}
+
+func (m *MockProductRepository) Suggest(ctx context.Context, text string, limit int) ([]*entities.SearchSuggestion, error) {
+	return []*entities.SearchSuggestion{}, nil
+}
+
+// MockPermissionRepository grants the permissions listed per role.
+type MockPermissionRepository struct {
+	granted map[int][]string
+}
+
+func (m *MockPermissionRepository) List(ctx context.Context) ([]*entities.Permission, error) {
+	return nil, nil
+}
+
+func (m *MockPermissionRepository) GetByNames(ctx context.Context, names []string) ([]*entities.Permission, error) {
+	return nil, nil
+}
+
+func (m *MockPermissionRepository) ListByRoleID(ctx context.Context, roleID int) ([]*entities.Permission, error) {
+	return nil, nil
+}
+
+func (m *MockPermissionRepository) RoleHasPermission(ctx context.Context, roleID int, name string) (bool, error) {
+	for _, granted := range m.granted[roleID] {
+		if granted == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockPermissionRepository) ReplaceRolePermissions(ctx context.Context, roleID int, permissions []*entities.Permission) error {
+	return nil
+}
+
+var (
+	tokoA = usecases.Actor{UserID: 10, RoleID: entities.RoleToko}
+	tokoB = usecases.Actor{UserID: 11, RoleID: entities.RoleToko}
+	admin = usecases.Actor{UserID: 1, RoleID: entities.RoleAdmin}
+)
+
+type MockStockMovementRepository struct {
+	productRepo *MockProductRepository
+}
+
+func (m *MockStockMovementRepository) ListByProduct(ctx context.Context, productID int64, params pagination.Params) (pagination.Page[*entities.StockMovement], error) {
+	var movements []*entities.StockMovement
+	for i := len(m.productRepo.movements) - 1; i >= 0; i-- {
+		if movement := m.productRepo.movements[i]; movement.ProductID == productID {
+			movements = append(movements, movement)
+		}
+	}
+	return pagination.Page[*entities.StockMovement]{Items: movements}, nil
+}
+
+func (m *MockStockMovementRepository) ListDrift(ctx context.Context) ([]*entities.StockDrift, error) {
+	return nil, nil
+}
+
+func (m *MockStockMovementRepository) Reconcile(ctx context.Context, productID int64) error {
+	return nil
+}
+
+func newTestProductUseCase() (usecases.ProductUseCase, *MockCategoryRepository) {
+	productRepo := &MockProductRepository{}
+	categoryRepo := &MockCategoryRepository{}
+	permissionRepo := &MockPermissionRepository{granted: map[int][]string{
+		entities.RoleToko:  {entities.PermissionProductsWrite},
+		entities.RoleAdmin: {entities.PermissionProductsWrite, entities.PermissionProductsManage},
+	}}
+	stockMovementRepo := &MockStockMovementRepository{productRepo: productRepo}
+	return usecases.NewProductUseCase(productRepo, categoryRepo, &MockTagRepository{}, permissionRepo, stockMovementRepo), categoryRepo
+}
+
+func TestProductUseCase_CreateGeneratesUniqueSlug(t *testing.T) {
+	useCase, _ := newTestProductUseCase()
+
+	first := &entities.Product{Name: "Kaos Polos", SKU: "KP-1", Price: 50000}
+	second := &entities.Product{Name: "Kaos  Polos!", SKU: "KP-2", Price: 50000}
+	third := &entities.Product{Name: "kaos polos", SKU: "KP-3", Price: 50000}
+
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, first))
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, second))
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoB, third))
+
+	assert.Equal(t, "kaos-polos", first.Slug)
+	assert.Equal(t, "kaos-polos-2", second.Slug)
+	assert.Equal(t, "kaos-polos-3", third.Slug)
+	assert.Equal(t, entities.ProductStatusDraft, first.Status)
+	assert.Equal(t, tokoB.UserID, third.UserID)
+}
+
+func TestProductUseCase_CreateRejectsInvalidProducts(t *testing.T) {
+	useCase, _ := newTestProductUseCase()
+
+	discount := 60000.0
+	err := useCase.CreateProduct(ctx, tokoA, &entities.Product{Name: "Topi", SKU: "T-1", Price: 50000, DiscountPrice: &discount})
+	assert.True(t, errors.Is(err, usecases.ErrInvalidDiscountPrice))
+
+	err = useCase.CreateProduct(ctx, tokoA, &entities.Product{Name: "???", SKU: "T-2", Price: 50000})
+	assert.True(t, errors.Is(err, usecases.ErrInvalidName))
+
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, &entities.Product{Name: "Topi", SKU: "T-3", Price: 50000}))
+	err = useCase.CreateProduct(ctx, tokoA, &entities.Product{Name: "Topi Lain", SKU: "T-3", Price: 50000})
+	assert.True(t, errors.Is(err, apperrors.ErrConflict))
+}
+
+func TestProductUseCase_OnlyOwnerOrManagerCanModify(t *testing.T) {
+	useCase, _ := newTestProductUseCase()
+
+	product := &entities.Product{Name: "Sepatu Lari", SKU: "SL-1", Price: 300000}
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, product))
+
+	_, err := useCase.GetManagedProduct(ctx, tokoB, product.ID)
+	assert.True(t, errors.Is(err, usecases.ErrNotProductOwner))
+	assert.True(t, errors.Is(useCase.DeleteProduct(ctx, tokoB, product.ID), usecases.ErrNotProductOwner))
+
+	managed, err := useCase.GetManagedProduct(ctx, admin, product.ID)
+	assert.NoError(t, err)
+	managed.Name = "Sepatu Lari Pro"
+	assert.NoError(t, useCase.UpdateProduct(ctx, admin, managed))
+	assert.Equal(t, "sepatu-lari-pro", managed.Slug)
+	assert.Equal(t, tokoA.UserID, managed.UserID, "updates must not transfer ownership")
+
+	assert.NoError(t, useCase.DeleteProduct(ctx, tokoA, product.ID))
+}
+
+func TestProductUseCase_ShoppersOnlySeePublishedProducts(t *testing.T) {
+	useCase, _ := newTestProductUseCase()
+
+	draft := &entities.Product{Name: "Draft", SKU: "D-1", Price: 1000, IsActive: true}
+	published := &entities.Product{Name: "Published", SKU: "P-1", Price: 1000, StockQuantity: 3, IsActive: true, Status: entities.ProductStatusPublished}
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, draft))
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, published))
+
+	_, err := useCase.GetProduct(ctx, draft.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+	found, err := useCase.GetProductBySlug(ctx, "published")
+	assert.NoError(t, err)
+	assert.Equal(t, published.ID, found.ID)
+
+	listed, err := useCase.ListProducts(ctx, repositories.ProductFilter{}, pagination.Params{Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, listed.Items, 1)
+
+	own, err := useCase.ListOwnProducts(ctx, tokoA, repositories.ProductFilter{}, pagination.Params{Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, own.Items, 2)
+}
+
+func TestProductUseCase_AttachCategories(t *testing.T) {
+	useCase, categoryRepo := newTestProductUseCase()
+	categoryUseCase := usecases.NewCategoryUseCase(categoryRepo)
+
+	shoes := &entities.Category{Name: "Sports & Outdoor", IsActive: true}
+	old := &entities.Category{Name: "Old", IsActive: true}
+	assert.NoError(t, categoryUseCase.CreateCategory(ctx, shoes))
+	assert.NoError(t, categoryUseCase.CreateCategory(ctx, old))
+	assert.Equal(t, "sports-outdoor", shoes.Slug)
+	assert.NoError(t, categoryUseCase.DeleteCategory(ctx, old.ID))
+
+	product := &entities.Product{Name: "Sepatu", SKU: "S-1", Price: 1000}
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, product))
+
+	_, err := useCase.AttachCategories(ctx, tokoB, product.ID, []int{shoes.ID})
+	assert.True(t, errors.Is(err, usecases.ErrNotProductOwner))
+
+	_, err = useCase.AttachCategories(ctx, tokoA, product.ID, []int{shoes.ID, old.ID, 99})
+	var appErr *apperrors.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "unknown_category", appErr.Type)
+	assert.Equal(t, []int{old.ID, 99}, appErr.Details)
+
+	updated, err := useCase.AttachCategories(ctx, tokoA, product.ID, []int{shoes.ID})
+	assert.NoError(t, err)
+	assert.Len(t, updated.Categories, 1)
+}
+
+func TestProductUseCase_SearchOnlyReturnsVisibleProducts(t *testing.T) {
+	useCase, _ := newTestProductUseCase()
+
+	draft := &entities.Product{Name: "Sepatu Draft", SKU: "SD-1", Price: 1000, IsActive: true}
+	published := &entities.Product{Name: "Sepatu Lari", SKU: "SL-1", Price: 1000, StockQuantity: 3, IsActive: true, Status: entities.ProductStatusPublished}
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, draft))
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, published))
+
+	page, err := useCase.SearchProducts(ctx, "  sepatu ", repositories.ProductFilter{}, pagination.Params{Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, published.ID, page.Items[0].ID)
+
+	_, err = useCase.SearchProducts(ctx, "   ", repositories.ProductFilter{}, pagination.Params{Limit: 10})
+	assert.True(t, errors.Is(err, usecases.ErrEmptySearchQuery))
+}
+
+func TestProductUseCase_StockChangesAreRecorded(t *testing.T) {
+	useCase, _ := newTestProductUseCase()
+
+	product := &entities.Product{Name: "Topi Rimba", SKU: "TR-1", Price: 75000, StockQuantity: 5, Status: entities.ProductStatusPublished}
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, product))
+
+	// Setting the stock through an update records the difference.
+	managed, err := useCase.GetManagedProduct(ctx, tokoA, product.ID)
+	assert.NoError(t, err)
+	managed.StockQuantity = 0
+	assert.NoError(t, useCase.UpdateProduct(ctx, tokoA, managed))
+	assert.Equal(t, entities.ProductStatusOutOfStock, managed.Status)
+
+	restock := &entities.StockMovement{ProductID: product.ID, Type: entities.StockMovementRestock, StockChange: 8, Reason: "Supplier delivery"}
+	assert.NoError(t, useCase.AdjustStock(ctx, tokoA, restock))
+	assert.Equal(t, 8, restock.BalanceAfter)
+	assert.Equal(t, tokoA.UserID, *restock.ActorID)
+
+	stored, err := useCase.GetManagedProduct(ctx, tokoA, product.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, stored.StockQuantity)
+	assert.Equal(t, entities.ProductStatusPublished, stored.Status)
+
+	page, err := useCase.ListStockHistory(ctx, tokoA, product.ID, pagination.Params{})
+	assert.NoError(t, err)
+	var types []string
+	balance := 0
+	for _, movement := range page.Items {
+		types = append(types, movement.Type)
+		balance += movement.StockChange
+	}
+	assert.Equal(t, []string{entities.StockMovementRestock, entities.StockMovementAdjustment, entities.StockMovementInitial}, types)
+	assert.Equal(t, stored.StockQuantity, balance, "the ledger must add up to the stock")
+
+	_, err = useCase.ListStockHistory(ctx, tokoB, product.ID, pagination.Params{})
+	assert.True(t, errors.Is(err, usecases.ErrNotProductOwner))
+}
+
+func TestProductUseCase_AdjustStockValidatesMovements(t *testing.T) {
+	useCase, _ := newTestProductUseCase()
+
+	product := &entities.Product{Name: "Tas Kanvas", SKU: "TK-1", Price: 120000, StockQuantity: 2}
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, product))
+	orderID := int64(7)
+
+	invalid := []*entities.StockMovement{
+		{ProductID: product.ID, Type: entities.StockMovementRestock, StockChange: -1},
+		{ProductID: product.ID, Type: entities.StockMovementAdjustment, StockChange: 0},
+		{ProductID: product.ID, Type: entities.StockMovementSale, StockChange: 1},
+		{ProductID: product.ID, Type: entities.StockMovementRestock, StockChange: 1, OrderID: &orderID},
+	}
+	for _, movement := range invalid {
+		assert.True(t, errors.Is(useCase.AdjustStock(ctx, tokoA, movement), usecases.ErrInvalidStockMovement), movement.Type)
+	}
+
+	err := useCase.AdjustStock(ctx, tokoA, &entities.StockMovement{ProductID: product.ID, Type: entities.StockMovementAdjustment, StockChange: -3})
+	assert.True(t, errors.Is(err, entities.ErrInsufficientStock))
+
+	err = useCase.AdjustStock(ctx, tokoB, &entities.StockMovement{ProductID: product.ID, Type: entities.StockMovementRestock, StockChange: 1})
+	assert.True(t, errors.Is(err, usecases.ErrNotProductOwner))
+
+	assert.NoError(t, useCase.AdjustStock(ctx, tokoA, &entities.StockMovement{ProductID: product.ID, Type: entities.StockMovementReturn, StockChange: 1, OrderID: &orderID}))
+}
+
+func TestProductUseCase_OnlyManagersFeatureProducts(t *testing.T) {
+	useCase, _ := newTestProductUseCase()
+
+	featured := &entities.Product{Name: "Jaket", SKU: "J-1", Price: 200000, IsFeatured: true}
+	assert.True(t, errors.Is(useCase.CreateProduct(ctx, tokoA, featured), usecases.ErrFeatureNotPermitted))
+
+	product := &entities.Product{Name: "Jaket", SKU: "J-1", Price: 200000}
+	assert.NoError(t, useCase.CreateProduct(ctx, tokoA, product))
+
+	own, err := useCase.GetManagedProduct(ctx, tokoA, product.ID)
+	assert.NoError(t, err)
+	own.IsFeatured = true
+	assert.True(t, errors.Is(useCase.UpdateProduct(ctx, tokoA, own), usecases.ErrFeatureNotPermitted))
+
+	managed, err := useCase.GetManagedProduct(ctx, admin, product.ID)
+	assert.NoError(t, err)
+	managed.IsFeatured = true
+	assert.NoError(t, useCase.UpdateProduct(ctx, admin, managed))
+
+	// Sellers can still edit featured products without touching the flag.
+	own, err = useCase.GetManagedProduct(ctx, tokoA, product.ID)
+	assert.NoError(t, err)
+	own.Name = "Jaket Gunung"
+	assert.NoError(t, useCase.UpdateProduct(ctx, tokoA, own))
+}
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+var (
+	ErrBuiltInRole       = apperrors.NewAppError(409, "built_in_role", "Built-in roles cannot be deleted", nil)
+	ErrUnknownPermission = apperrors.NewAppError(400, "unknown_permission", "Unknown permission", nil)
+)
+
+type RoleUseCase interface {
+	CreateRole(ctx context.Context, role *entities.Role) error
+	GetRoleByID(ctx context.Context, id int) (*entities.Role, error)
+	UpdateRole(ctx context.Context, role *entities.Role) error
+	DeleteRole(ctx context.Context, id int) error
+	ListRoles(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Role], error)
+	ListPermissions(ctx context.Context) ([]*entities.Permission, error)
+	SetRolePermissions(ctx context.Context, roleID int, names []string) (*entities.Role, error)
+	HasPermission(ctx context.Context, roleID int, permission string) (bool, error)
+}
+
+type roleUseCase struct {
+	roleRepo       repositories.RoleRepository
+	permissionRepo repositories.PermissionRepository
+}
+
+func NewRoleUseCase(roleRepo repositories.RoleRepository, permissionRepo repositories.PermissionRepository) RoleUseCase {
+	return &roleUseCase{
+		roleRepo:       roleRepo,
+		permissionRepo: permissionRepo,
+	}
+}
+
+func (u *roleUseCase) CreateRole(ctx context.Context, role *entities.Role) error {
+	return u.roleRepo.Create(ctx, role)
+}
+
+func (u *roleUseCase) GetRoleByID(ctx context.Context, id int) (*entities.Role, error) {
+	role, err := u.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := u.permissionRepo.ListByRoleID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Permissions = make([]entities.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		role.Permissions = append(role.Permissions, *permission)
+	}
+
+	return role, nil
+}
+
+func (u *roleUseCase) UpdateRole(ctx context.Context, role *entities.Role) error {
+	return u.roleRepo.Update(ctx, role)
+}
+
+func (u *roleUseCase) DeleteRole(ctx context.Context, id int) error {
+	role, err := u.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if role.IsBuiltIn() {
+		return ErrBuiltInRole
+	}
+
+	return u.roleRepo.Delete(ctx, id)
+}
+
+func (u *roleUseCase) ListRoles(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Role], error) {
+	return u.roleRepo.List(ctx, params)
+}
+
+func (u *roleUseCase) ListPermissions(ctx context.Context) ([]*entities.Permission, error) {
+	return u.permissionRepo.List(ctx)
+}
+
+func (u *roleUseCase) SetRolePermissions(ctx context.Context, roleID int, names []string) (*entities.Role, error) {
+	if _, err := u.roleRepo.GetByID(ctx, roleID); err != nil {
+		return nil, err
+	}
+
+	permissions := []*entities.Permission{}
+	if len(names) > 0 {
+		var err error
+		permissions, err = u.permissionRepo.GetByNames(ctx, names)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	known := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		known[permission.Name] = true
+	}
+	var unknown []string
+	for _, name := range names {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, ErrUnknownPermission.WithDetails(unknown)
+	}
+
+	if err := u.permissionRepo.ReplaceRolePermissions(ctx, roleID, permissions); err != nil {
+		return nil, err
+	}
+
+	return u.GetRoleByID(ctx, roleID)
+}
+
+func (u *roleUseCase) HasPermission(ctx context.Context, roleID int, permission string) (bool, error) {
+	return u.permissionRepo.RoleHasPermission(ctx, roleID, permission)
+}
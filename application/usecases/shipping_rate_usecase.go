@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"context"
+	"regexp"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+var ErrInvalidShippingRate = apperrors.NewAppError(422, "invalid_shipping_rate", "Shipping rate is invalid", nil)
+
+// shippingRegionCode matches a province code such as '31', a city code such
+// as '31.74' or the any region wildcard.
+var shippingRegionCode = regexp.MustCompile(`^(\*|[0-9]{2}(\.[0-9]{2})?)$`)
+
+type ShippingRateUseCase interface {
+	CreateRate(ctx context.Context, rate *entities.ShippingRate) error
+	GetRate(ctx context.Context, id int64) (*entities.ShippingRate, error)
+	UpdateRate(ctx context.Context, rate *entities.ShippingRate) error
+	DeleteRate(ctx context.Context, id int64) error
+	ListRates(ctx context.Context, params pagination.Params) (pagination.Page[*entities.ShippingRate], error)
+}
+
+type shippingRateUseCase struct {
+	rateRepo repositories.ShippingRateRepository
+}
+
+func NewShippingRateUseCase(rateRepo repositories.ShippingRateRepository) ShippingRateUseCase {
+	return &shippingRateUseCase{
+		rateRepo: rateRepo,
+	}
+}
+
+func (u *shippingRateUseCase) CreateRate(ctx context.Context, rate *entities.ShippingRate) error {
+	if err := validateShippingRate(rate); err != nil {
+		return err
+	}
+	rate.ID = 0
+	return u.rateRepo.Create(ctx, rate)
+}
+
+func (u *shippingRateUseCase) GetRate(ctx context.Context, id int64) (*entities.ShippingRate, error) {
+	return u.rateRepo.GetByID(ctx, id)
+}
+
+func (u *shippingRateUseCase) UpdateRate(ctx context.Context, rate *entities.ShippingRate) error {
+	existing, err := u.rateRepo.GetByID(ctx, rate.ID)
+	if err != nil {
+		return err
+	}
+	if err := validateShippingRate(rate); err != nil {
+		return err
+	}
+	rate.CreatedAt = existing.CreatedAt
+	return u.rateRepo.Update(ctx, rate)
+}
+
+func (u *shippingRateUseCase) DeleteRate(ctx context.Context, id int64) error {
+	return u.rateRepo.Delete(ctx, id)
+}
+
+func (u *shippingRateUseCase) ListRates(ctx context.Context, params pagination.Params) (pagination.Page[*entities.ShippingRate], error) {
+	return u.rateRepo.List(ctx, params)
+}
+
+// validateShippingRate checks what the request validation cannot: the
+// region codes and that the weight bracket and delivery estimate are ranges.
+func validateShippingRate(rate *entities.ShippingRate) error {
+	details := map[string]string{}
+	if !shippingRegionCode.MatchString(rate.OriginCode) {
+		details["origin_code"] = "origin_code must be a province or city code, or *"
+	}
+	if !shippingRegionCode.MatchString(rate.DestinationCode) {
+		details["destination_code"] = "destination_code must be a province or city code, or *"
+	}
+	if rate.MaxWeight <= rate.MinWeight {
+		details["max_weight"] = "max_weight must be greater than min_weight"
+	}
+	if rate.EtdMaxDays < rate.EtdMinDays {
+		details["etd_max_days"] = "etd_max_days must be greater than or equal to etd_min_days"
+	}
+	if len(details) > 0 {
+		return ErrInvalidShippingRate.WithDetails(details)
+	}
+	return nil
+}
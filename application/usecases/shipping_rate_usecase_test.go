@@ -0,0 +1,130 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+type MockShippingRateRepository struct {
+	rates []*entities.ShippingRate
+}
+
+// newMockShippingRateRepository holds Jakarta rates of JNE REG and SiCepat
+// REG, plus a nationwide JNE REG rate.
+func newMockShippingRateRepository() *MockShippingRateRepository {
+	repo := &MockShippingRateRepository{}
+	for _, rate := range []*entities.ShippingRate{
+		{Courier: "jne", Service: "REG", OriginCode: "31", DestinationCode: "31", MinWeight: 0, MaxWeight: 1, Price: 9000, EtdMinDays: 1, EtdMaxDays: 2},
+		{Courier: "jne", Service: "REG", OriginCode: "31", DestinationCode: "31", MinWeight: 1, MaxWeight: 2, Price: 18000, EtdMinDays: 1, EtdMaxDays: 2},
+		{Courier: "jne", Service: "REG", OriginCode: "*", DestinationCode: "*", MinWeight: 0, MaxWeight: 30, Price: 50000, EtdMinDays: 2, EtdMaxDays: 4},
+		{Courier: "sicepat", Service: "REG", OriginCode: "31", DestinationCode: "31", MinWeight: 1, MaxWeight: 5, Price: 15000, EtdMinDays: 1, EtdMaxDays: 3},
+		{Courier: "jne", Service: "YES", OriginCode: "*", DestinationCode: "*", MinWeight: 0, MaxWeight: 30, Price: 40000, EtdMinDays: 1, EtdMaxDays: 1},
+	} {
+		rate.IsActive = rate.Service != "YES"
+		_ = repo.Create(ctx, rate)
+	}
+	return repo
+}
+
+func (m *MockShippingRateRepository) Create(ctx context.Context, rate *entities.ShippingRate) error {
+	for _, existing := range m.rates {
+		if existing != nil && existing.Courier == rate.Courier && existing.Service == rate.Service &&
+			existing.OriginCode == rate.OriginCode && existing.DestinationCode == rate.DestinationCode && existing.MinWeight == rate.MinWeight {
+			return apperrors.NewConflictError("Shipping rate already exists for this weight bracket")
+		}
+	}
+	rate.ID = int64(len(m.rates) + 1)
+	stored := *rate
+	m.rates = append(m.rates, &stored)
+	return nil
+}
+
+func (m *MockShippingRateRepository) GetByID(ctx context.Context, id int64) (*entities.ShippingRate, error) {
+	if id < 1 || id > int64(len(m.rates)) || m.rates[id-1] == nil {
+		return nil, apperrors.NewNotFoundError("Shipping rate")
+	}
+	clone := *m.rates[id-1]
+	return &clone, nil
+}
+
+func (m *MockShippingRateRepository) Update(ctx context.Context, rate *entities.ShippingRate) error {
+	stored := *rate
+	m.rates[rate.ID-1] = &stored
+	return nil
+}
+
+func (m *MockShippingRateRepository) Delete(ctx context.Context, id int64) error {
+	if _, err := m.GetByID(ctx, id); err != nil {
+		return err
+	}
+	m.rates[id-1] = nil
+	return nil
+}
+
+func (m *MockShippingRateRepository) List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.ShippingRate], error) {
+	var rates []*entities.ShippingRate
+	for _, rate := range m.rates {
+		if rate != nil {
+			clone := *rate
+			rates = append(rates, &clone)
+		}
+	}
+	return pagination.Page[*entities.ShippingRate]{Items: rates}, nil
+}
+
+func (m *MockShippingRateRepository) ListMatching(ctx context.Context, originCodes, destinationCodes []string, weight float64) ([]*entities.ShippingRate, error) {
+	var rates []*entities.ShippingRate
+	for _, rate := range m.rates {
+		if rate != nil && rate.IsActive && contains(originCodes, rate.OriginCode) && contains(destinationCodes, rate.DestinationCode) &&
+			rate.MinWeight < weight && rate.MaxWeight >= weight {
+			clone := *rate
+			rates = append(rates, &clone)
+		}
+	}
+	return rates, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestShippingRateUseCase_ValidatesRates(t *testing.T) {
+	rates := usecases.NewShippingRateUseCase(newMockShippingRateRepository())
+
+	rate := &entities.ShippingRate{Courier: "anteraja", Service: "REG", OriginCode: "31", DestinationCode: "34.71", MinWeight: 0, MaxWeight: 1, Price: 12000, EtdMinDays: 2, EtdMaxDays: 3, IsActive: true}
+	assert.NoError(t, rates.CreateRate(ctx, rate))
+	assert.NotZero(t, rate.ID)
+
+	duplicate := *rate
+	assert.True(t, errors.Is(rates.CreateRate(ctx, &duplicate), apperrors.ErrConflict))
+
+	for _, invalid := range []entities.ShippingRate{
+		{Courier: "anteraja", Service: "REG", OriginCode: "Jakarta", DestinationCode: "*", MaxWeight: 1},
+		{Courier: "anteraja", Service: "REG", OriginCode: "31", DestinationCode: "31.7", MaxWeight: 1},
+		{Courier: "anteraja", Service: "REG", OriginCode: "31", DestinationCode: "*", MinWeight: 2, MaxWeight: 1},
+		{Courier: "anteraja", Service: "REG", OriginCode: "31", DestinationCode: "*", MaxWeight: 1, EtdMinDays: 3, EtdMaxDays: 2},
+	} {
+		assert.True(t, errors.Is(rates.CreateRate(ctx, &invalid), usecases.ErrInvalidShippingRate), "%+v", invalid)
+	}
+
+	rate.Price = 13000
+	assert.NoError(t, rates.UpdateRate(ctx, rate))
+	stored, err := rates.GetRate(ctx, rate.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 13000.0, stored.Price)
+
+	assert.NoError(t, rates.DeleteRate(ctx, rate.ID))
+	assert.True(t, errors.Is(rates.DeleteRate(ctx, rate.ID), apperrors.ErrNotFound))
+}
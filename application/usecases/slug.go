@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"fmt"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
+)
+
+var ErrInvalidName = apperrors.NewAppError(400, "invalid_name", "Name must contain letters or digits", nil)
+
+// uniqueSlug derives a slug from name and appends -2, -3, ... until exists
+// reports that it is free.
+func uniqueSlug(name string, exists func(slug string) (bool, error)) (string, error) {
+	base := utils.Slugify(name)
+	if base == "" {
+		return "", ErrInvalidName
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := exists(slug)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+type TagUseCase interface {
+	CreateTag(ctx context.Context, tag *entities.Tag) error
+	GetTagByID(ctx context.Context, id int) (*entities.Tag, error)
+	UpdateTag(ctx context.Context, tag *entities.Tag) error
+	DeleteTag(ctx context.Context, id int) error
+	ListTags(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Tag], error)
+}
+
+type tagUseCase struct {
+	tagRepo repositories.TagRepository
+}
+
+func NewTagUseCase(tagRepo repositories.TagRepository) TagUseCase {
+	return &tagUseCase{
+		tagRepo: tagRepo,
+	}
+}
+
+func (u *tagUseCase) CreateTag(ctx context.Context, tag *entities.Tag) error {
+	slug, err := uniqueSlug(tag.Name, func(slug string) (bool, error) {
+		return u.tagRepo.SlugExists(ctx, slug, 0)
+	})
+	if err != nil {
+		return err
+	}
+	tag.Slug = slug
+
+	return u.tagRepo.Create(ctx, tag)
+}
+
+func (u *tagUseCase) GetTagByID(ctx context.Context, id int) (*entities.Tag, error) {
+	return u.tagRepo.GetByID(ctx, id)
+}
+
+func (u *tagUseCase) UpdateTag(ctx context.Context, tag *entities.Tag) error {
+	existing, err := u.tagRepo.GetByID(ctx, tag.ID)
+	if err != nil {
+		return err
+	}
+
+	tag.Slug = existing.Slug
+	if tag.Name != existing.Name {
+		slug, err := uniqueSlug(tag.Name, func(slug string) (bool, error) {
+			return u.tagRepo.SlugExists(ctx, slug, tag.ID)
+		})
+		if err != nil {
+			return err
+		}
+		tag.Slug = slug
+	}
+
+	return u.tagRepo.Update(ctx, tag)
+}
+
+func (u *tagUseCase) DeleteTag(ctx context.Context, id int) error {
+	return u.tagRepo.Delete(ctx, id)
+}
+
+func (u *tagUseCase) ListTags(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Tag], error) {
+	return u.tagRepo.List(ctx, params)
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
+)
+
+var ErrOwnerDeleted = apperrors.NewAppError(409, "owner_deleted", "The owner of this product is deleted; restore the user instead", nil)
+
+// TrashUseCase manages deleted users, products and categories. They can be
+// restored until they are purged, TRASH_RETENTION after being deleted.
+type TrashUseCase interface {
+	ListDeletedUsers(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error)
+	RestoreUser(ctx context.Context, id int64) error
+	ListDeletedProducts(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Product], error)
+	RestoreProduct(ctx context.Context, id int64) error
+	ListDeletedCategories(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Category], error)
+	RestoreCategory(ctx context.Context, id int) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type trashUseCase struct {
+	userRepo     repositories.UserRepository
+	productRepo  repositories.ProductRepository
+	categoryRepo repositories.CategoryRepository
+	cfg          *config.Config
+}
+
+func NewTrashUseCase(
+	userRepo repositories.UserRepository,
+	productRepo repositories.ProductRepository,
+	categoryRepo repositories.CategoryRepository,
+	cfg *config.Config,
+) TrashUseCase {
+	return &trashUseCase{
+		userRepo:     userRepo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		cfg:          cfg,
+	}
+}
+
+func (u *trashUseCase) ListDeletedUsers(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error) {
+	return u.userRepo.ListDeleted(ctx, params)
+}
+
+// RestoreUser brings back a deleted user together with the products that
+// were deleted with them. Their sessions stay revoked.
+func (u *trashUseCase) RestoreUser(ctx context.Context, id int64) error {
+	return u.userRepo.Restore(ctx, id)
+}
+
+func (u *trashUseCase) ListDeletedProducts(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	return u.productRepo.ListDeleted(ctx, params)
+}
+
+// RestoreProduct brings back a deleted product. Products of deleted users
+// come back with their owner only.
+func (u *trashUseCase) RestoreProduct(ctx context.Context, id int64) error {
+	product, err := u.productRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.userRepo.GetByID(ctx, product.UserID); errors.Is(err, apperrors.ErrNotFound) {
+		return ErrOwnerDeleted
+	} else if err != nil {
+		return err
+	}
+	return u.productRepo.Restore(ctx, id)
+}
+
+func (u *trashUseCase) ListDeletedCategories(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Category], error) {
+	return u.categoryRepo.ListDeleted(ctx, params)
+}
+
+func (u *trashUseCase) RestoreCategory(ctx context.Context, id int) error {
+	return u.categoryRepo.Restore(ctx, id)
+}
+
+// PurgeExpired permanently removes everything that has been deleted for
+// longer than the retention period and returns how many records went.
+func (u *trashUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+	before := time.Now().Add(-u.retention())
+
+	users, err := u.userRepo.PurgeDeleted(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	products, err := u.productRepo.PurgeDeleted(ctx, before)
+	if err != nil {
+		return users, err
+	}
+	categories, err := u.categoryRepo.PurgeDeleted(ctx, before)
+	return users + products + categories, err
+}
+
+func (u *trashUseCase) retention() time.Duration {
+	retention, err := time.ParseDuration(u.cfg.TrashRetention)
+	if err != nil {
+		return 30 * 24 * time.Hour
+	}
+	return retention
+}
@@ -0,0 +1,72 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
+)
+
+func newTestTrashUseCase() (usecases.TrashUseCase, *MockUserRepository, *MockProductRepository, *MockCategoryRepository) {
+	userRepo := NewMockUserRepository()
+	productRepo := &MockProductRepository{}
+	categoryRepo := &MockCategoryRepository{}
+	cfg := &config.Config{TrashRetention: "720h"}
+	return usecases.NewTrashUseCase(userRepo, productRepo, categoryRepo, cfg), userRepo, productRepo, categoryRepo
+}
+
+func TestTrashUseCase_RestoresProductsWithTheirOwner(t *testing.T) {
+	trash, userRepo, productRepo, _ := newTestTrashUseCase()
+
+	seller := &entities.User{Email: "toko@example.com", FullName: "Toko"}
+	assert.NoError(t, userRepo.Create(ctx, seller))
+	product := &entities.Product{UserID: seller.ID, Name: "Kopi", SKU: "KOPI-1"}
+	assert.NoError(t, productRepo.Create(ctx, product))
+	assert.NoError(t, productRepo.Delete(ctx, product.ID))
+	assert.NoError(t, userRepo.Delete(ctx, seller.ID))
+
+	deleted, err := trash.ListDeletedProducts(ctx, pagination.Params{Limit: 20})
+	assert.NoError(t, err)
+	assert.Len(t, deleted.Items, 1)
+
+	err = trash.RestoreProduct(ctx, product.ID)
+	assert.True(t, errors.Is(err, usecases.ErrOwnerDeleted), "products of deleted users wait for their owner")
+
+	assert.NoError(t, trash.RestoreUser(ctx, seller.ID))
+	assert.NoError(t, trash.RestoreProduct(ctx, product.ID))
+	_, err = productRepo.GetByID(ctx, product.ID)
+	assert.NoError(t, err)
+
+	err = trash.RestoreProduct(ctx, product.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound), "only deleted products can be restored")
+}
+
+func TestTrashUseCase_PurgesAfterRetention(t *testing.T) {
+	trash, _, _, categoryRepo := newTestTrashUseCase()
+
+	expired := &entities.Category{Name: "Lama", Slug: "lama"}
+	recent := &entities.Category{Name: "Baru", Slug: "baru"}
+	assert.NoError(t, categoryRepo.Create(ctx, expired))
+	assert.NoError(t, categoryRepo.Create(ctx, recent))
+	assert.NoError(t, categoryRepo.Delete(ctx, expired.ID))
+	assert.NoError(t, categoryRepo.Delete(ctx, recent.ID))
+	expired.DeletedAt.Time = time.Now().Add(-31 * 24 * time.Hour)
+
+	purged, err := trash.PurgeExpired(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	deleted, err := trash.ListDeletedCategories(ctx, pagination.Params{Limit: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.Category{recent}, deleted.Items)
+
+	assert.NoError(t, trash.RestoreCategory(ctx, recent.ID))
+	_, err = categoryRepo.GetBySlug(ctx, "baru")
+	assert.NoError(t, err)
+}
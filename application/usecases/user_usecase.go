@@ -1,78 +1,533 @@
 package usecases
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
 	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
 	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
 	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
 	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
 )
 
+var (
+	ErrInvalidCredentials       = apperrors.NewAppError(401, "invalid_credentials", "Invalid email or password", nil)
+	ErrAccountInactive          = apperrors.NewAppError(403, "account_inactive", "User account is inactive", nil)
+	ErrInvalidRefreshToken      = apperrors.NewAppError(401, "invalid_refresh_token", "Invalid or expired refresh token", nil)
+	ErrRefreshTokenReused       = apperrors.NewAppError(401, "refresh_token_reused", "Refresh token has already been used", nil)
+	ErrInvalidVerificationToken = apperrors.NewAppError(400, "invalid_verification_token", "Invalid or expired verification token", nil)
+	ErrInvalidResetToken        = apperrors.NewAppError(400, "invalid_reset_token", "Invalid or expired password reset token", nil)
+)
+
+// TokenPair is the set of credentials handed to a client after login or
+// refresh.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+}
+
 type UserUseCase interface {
-	Register(user *entities.User) error
-	Login(email, password string) (string, *entities.User, error)
-	GetUserByID(id int64) (*entities.User, error)
-	UpdateUser(user *entities.User) error
-	DeleteUser(id int64) error
-	ListUsers(offset, limit int) ([]*entities.User, error)
+	Register(ctx context.Context, user *entities.User, address *entities.Address) error
+	Login(ctx context.Context, email, password string) (*TokenPair, *entities.User, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error)
+	Logout(ctx context.Context, userID int64, accessTokenID, refreshToken string) error
+	LogoutAll(ctx context.Context, userID int64) error
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, email string) error
+	IsUserVerified(ctx context.Context, userID int64) (bool, error)
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	GetUserByID(ctx context.Context, id int64) (*entities.User, error)
+	UpdateUser(ctx context.Context, user *entities.User) error
+	DeleteUser(ctx context.Context, id int64) error
+	ListUsers(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error)
 }
 
 type userUseCase struct {
-	userRepo repositories.UserRepository
-	cfg      *config.Config
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	revokedTokenRepo repositories.RevokedTokenRepository
+	txManager        repositories.TxManager
+	mailer           ports.Mailer
+	cfg              *config.Config
 }
 
-func NewUserUseCase(userRepo repositories.UserRepository, cfg *config.Config) UserUseCase {
+func NewUserUseCase(
+	userRepo repositories.UserRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	revokedTokenRepo repositories.RevokedTokenRepository,
+	txManager repositories.TxManager,
+	mailer ports.Mailer,
+	cfg *config.Config,
+) UserUseCase {
 	return &userUseCase{
-		userRepo: userRepo,
-		cfg:      cfg,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revokedTokenRepo: revokedTokenRepo,
+		txManager:        txManager,
+		mailer:           mailer,
+		cfg:              cfg,
 	}
 }
 
-func (u *userUseCase) Register(user *entities.User) error {
+// Register creates the user and, when address is not nil, saves it as their
+// default address. Either both are created or neither is.
+func (u *userUseCase) Register(ctx context.Context, user *entities.User, address *entities.Address) error {
 	hashedPassword, err := utils.HashPassword(user.PasswordHash)
 	if err != nil {
 		return err
 	}
 	user.PasswordHash = hashedPassword
-	return u.userRepo.Create(user)
+
+	token, err := u.assignVerificationToken(user)
+	if err != nil {
+		return err
+	}
+
+	err = u.txManager.Do(ctx, func(ctx context.Context, repos repositories.Repositories) error {
+		// IDs assigned by an attempt that was rolled back must not be reused
+		// when the unit of work is retried.
+		user.ID = 0
+		if err := repos.Users().Create(ctx, user); err != nil {
+			return err
+		}
+		if address == nil {
+			return nil
+		}
+
+		if err := resolveRegions(ctx, repos.Regions(), address); err != nil {
+			return err
+		}
+		address.ID = 0
+		address.UserID = user.ID
+		address.IsDefault = true
+		return repos.Addresses().Create(ctx, address)
+	})
+	if err != nil {
+		return err
+	}
+
+	// The account exists at this point; a failed email can be retried through
+	// ResendVerification, so it must not fail the registration.
+	if err := u.sendVerificationEmail(user, token); err != nil {
+		slog.ErrorContext(ctx, "Failed to send verification email", "user_id", user.ID, "error", err)
+	}
+
+	return nil
 }
 
-func (u *userUseCase) Login(email, password string) (string, *entities.User, error) {
-	user, err := u.userRepo.GetByEmail(email)
+func (u *userUseCase) Login(ctx context.Context, email, password string) (*TokenPair, *entities.User, error) {
+	user, err := u.userRepo.GetByEmail(ctx, email)
 	if err != nil {
-		return "", nil, errors.New("invalid email or password")
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, nil, ErrInvalidCredentials
+		}
+		return nil, nil, err
 	}
 
 	if !utils.CheckPassword(password, user.PasswordHash) {
-		return "", nil, errors.New("invalid email or password")
+		return nil, nil, ErrInvalidCredentials
 	}
 
 	if !user.IsActive {
-		return "", nil, errors.New("user account is inactive")
+		return nil, nil, ErrAccountInactive
 	}
 
-	token, err := utils.GenerateToken(user.ID, user.Email, user.RoleID, u.cfg.JWTSecret, u.cfg.JWTExpiry)
+	familyID, err := utils.GenerateRandomToken(16)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 
-	return token, user, nil
+	tokens, err := u.issueTokens(ctx, user, familyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, user, nil
 }
 
-func (u *userUseCase) GetUserByID(id int64) (*entities.User, error) {
-	return u.userRepo.GetByID(id)
+// RefreshToken exchanges a refresh token for a new token pair. Every refresh
+// token can only be used once; presenting a token that was already rotated is
+// treated as theft and revokes every token in its family.
+func (u *userUseCase) RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	stored, err := u.refreshTokenRepo.GetByTokenHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if stored.RevokedAt != nil {
+		if err := u.revokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	if stored.IsExpired(time.Now()) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	revoked, err := u.refreshTokenRepo.MarkRevoked(ctx, stored.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		// Another request rotated this token between our read and update.
+		if err := u.revokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	user, err := u.userRepo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrAccountInactive
+	}
+
+	return u.issueTokens(ctx, user, stored.FamilyID)
 }
 
-func (u *userUseCase) UpdateUser(user *entities.User) error {
-	return u.userRepo.Update(user)
+// Logout revokes the current access token and, when given, the family of the
+// refresh token issued alongside it.
+func (u *userUseCase) Logout(ctx context.Context, userID int64, accessTokenID, refreshToken string) error {
+	if accessTokenID != "" {
+		if err := u.revokeAccessToken(ctx, accessTokenID); err != nil {
+			return err
+		}
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := u.refreshTokenRepo.GetByTokenHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		return err
+	}
+	if stored.UserID != userID {
+		return ErrInvalidRefreshToken
+	}
+
+	return u.revokeFamily(ctx, stored.FamilyID)
 }
 
-func (u *userUseCase) DeleteUser(id int64) error {
-	return u.userRepo.Delete(id)
+// LogoutAll revokes every refresh token of the user together with any access
+// token issued alongside them that may not have expired yet.
+func (u *userUseCase) LogoutAll(ctx context.Context, userID int64) error {
+	recent, err := u.refreshTokenRepo.ListByUserIDSince(ctx, userID, time.Now().Add(-u.accessTokenTTL()))
+	if err != nil {
+		return err
+	}
+
+	for _, token := range recent {
+		if err := u.revokeAccessToken(ctx, token.AccessTokenID); err != nil {
+			return err
+		}
+	}
+
+	return u.refreshTokenRepo.RevokeAllByUserID(ctx, userID)
+}
+
+func (u *userUseCase) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	return u.revokedTokenRepo.Exists(ctx, tokenID)
+}
+
+func (u *userUseCase) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrInvalidVerificationToken
+	}
+
+	user, err := u.userRepo.GetByVerificationToken(ctx, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return ErrInvalidVerificationToken
+		}
+		return err
+	}
+
+	if user.VerificationExpires == nil || time.Now().After(*user.VerificationExpires) {
+		return ErrInvalidVerificationToken
+	}
+
+	user.IsVerified = true
+	user.VerificationToken = ""
+	user.VerificationExpires = nil
+	return u.userRepo.Update(ctx, user)
 }
 
-func (u *userUseCase) ListUsers(offset, limit int) ([]*entities.User, error) {
-	return u.userRepo.List(offset, limit)
+// ResendVerification sends a new verification email. It silently does
+// nothing for unknown, already verified or recently emailed addresses so the
+// response cannot be used to discover registered emails.
+func (u *userUseCase) ResendVerification(ctx context.Context, email string) error {
+	user, err := u.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.IsVerified {
+		return nil
+	}
+
+	if user.VerificationSentAt != nil && time.Since(*user.VerificationSentAt) < u.verificationResendInterval() {
+		return nil
+	}
+
+	token, err := u.assignVerificationToken(user)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	return u.sendVerificationEmail(user, token)
+}
+
+func (u *userUseCase) IsUserVerified(ctx context.Context, userID int64) (bool, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.IsVerified, nil
+}
+
+// ForgotPassword emails a password reset link. Unknown and inactive accounts
+// are ignored without an error so the response does not reveal which emails
+// are registered.
+func (u *userUseCase) ForgotPassword(ctx context.Context, email string) error {
+	user, err := u.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	expires := time.Now().Add(u.passwordResetTokenTTL())
+	user.ResetPasswordToken = utils.HashToken(token)
+	user.ResetPasswordExpires = &expires
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s?token=%s", u.cfg.PasswordResetURL, url.QueryEscape(token))
+
+	// Unknown emails succeed silently, so a failed email must not fail the
+	// request either; the user can ask for another link.
+	err = u.mailer.Send(&ports.MailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\nThe link expires in %s. If you did not request a reset, you can ignore this email.\n",
+			user.FullName, link, u.passwordResetTokenTTL(),
+		),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send password reset email", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token. The token can only
+// be used once and every existing session of the user is revoked.
+func (u *userUseCase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if token == "" {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	userID, err := u.userRepo.ResetPassword(ctx, utils.HashToken(token), hashedPassword, time.Now())
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	return u.LogoutAll(ctx, userID)
+}
+
+func (u *userUseCase) GetUserByID(ctx context.Context, id int64) (*entities.User, error) {
+	return u.userRepo.GetByID(ctx, id)
+}
+
+func (u *userUseCase) UpdateUser(ctx context.Context, user *entities.User) error {
+	return u.userRepo.Update(ctx, user)
+}
+
+// DeleteUser soft deletes the user, who can be restored through the trash,
+// and signs them out everywhere.
+func (u *userUseCase) DeleteUser(ctx context.Context, id int64) error {
+	if err := u.userRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return u.LogoutAll(ctx, id)
+}
+
+func (u *userUseCase) ListUsers(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error) {
+	return u.userRepo.List(ctx, params)
+}
+
+func (u *userUseCase) issueTokens(ctx context.Context, user *entities.User, familyID string) (*TokenPair, error) {
+	accessTokenID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := utils.GenerateToken(user.ID, user.Email, user.RoleID, accessTokenID, u.cfg.JWTSecret, u.cfg.JWTExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.refreshTokenRepo.Create(ctx, &entities.RefreshToken{
+		UserID:        user.ID,
+		TokenHash:     utils.HashToken(refreshToken),
+		FamilyID:      familyID,
+		AccessTokenID: accessTokenID,
+		ExpiresAt:     time.Now().Add(u.refreshTokenTTL()),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(u.accessTokenTTL().Seconds()),
+	}, nil
+}
+
+// assignVerificationToken stores the hash of a fresh verification token on
+// the user and returns the plain token to be emailed.
+func (u *userUseCase) assignVerificationToken(user *entities.User) (string, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	expires := now.Add(u.verificationTokenTTL())
+	user.VerificationToken = utils.HashToken(token)
+	user.VerificationExpires = &expires
+	user.VerificationSentAt = &now
+
+	return token, nil
+}
+
+func (u *userUseCase) sendVerificationEmail(user *entities.User, token string) error {
+	link := fmt.Sprintf("%s/api/v1/users/verify?token=%s", strings.TrimRight(u.cfg.AppBaseURL, "/"), url.QueryEscape(token))
+
+	return u.mailer.Send(&ports.MailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease verify your email address by opening the link below:\n\n%s\n\nThe link expires in %s.\n",
+			user.FullName, link, u.verificationTokenTTL(),
+		),
+	})
+}
+
+// revokeFamily revokes every refresh token in the family and the access
+// tokens issued with them that may still be valid.
+func (u *userUseCase) revokeFamily(ctx context.Context, familyID string) error {
+	recent, err := u.refreshTokenRepo.ListByFamilyIDSince(ctx, familyID, time.Now().Add(-u.accessTokenTTL()))
+	if err != nil {
+		return err
+	}
+
+	for _, token := range recent {
+		if err := u.revokeAccessToken(ctx, token.AccessTokenID); err != nil {
+			return err
+		}
+	}
+
+	return u.refreshTokenRepo.RevokeFamily(ctx, familyID)
+}
+
+func (u *userUseCase) revokeAccessToken(ctx context.Context, tokenID string) error {
+	return u.revokedTokenRepo.Create(ctx, &entities.RevokedToken{
+		TokenID:   tokenID,
+		ExpiresAt: time.Now().Add(u.accessTokenTTL()),
+	})
+}
+
+func (u *userUseCase) accessTokenTTL() time.Duration {
+	ttl, err := time.ParseDuration(u.cfg.JWTExpiry)
+	if err != nil {
+		return 15 * time.Minute
+	}
+	return ttl
+}
+
+func (u *userUseCase) verificationTokenTTL() time.Duration {
+	ttl, err := time.ParseDuration(u.cfg.VerificationTokenExpiry)
+	if err != nil {
+		return 24 * time.Hour
+	}
+	return ttl
+}
+
+func (u *userUseCase) verificationResendInterval() time.Duration {
+	interval, err := time.ParseDuration(u.cfg.VerificationResendInterval)
+	if err != nil {
+		return time.Minute
+	}
+	return interval
+}
+
+func (u *userUseCase) passwordResetTokenTTL() time.Duration {
+	ttl, err := time.ParseDuration(u.cfg.PasswordResetTokenExpiry)
+	if err != nil {
+		return time.Hour
+	}
+	return ttl
+}
+
+func (u *userUseCase) refreshTokenTTL() time.Duration {
+	ttl, err := time.ParseDuration(u.cfg.RefreshTokenExpiry)
+	if err != nil {
+		return 30 * 24 * time.Hour
+	}
+	return ttl
 }
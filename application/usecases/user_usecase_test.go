@@ -1,13 +1,26 @@
 package usecases_test
 
 import (
+	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
 	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
 	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/mailer"
 )
 
+// ctx is the context the use cases and repositories are called with.
+var ctx = context.Background()
+
 type MockUserRepository struct {
 	users     []*entities.User
 	createErr error
@@ -22,7 +35,7 @@ func NewMockUserRepository() *MockUserRepository {
 	}
 }
 
-func (m *MockUserRepository) Create(user *entities.User) error {
+func (m *MockUserRepository) Create(ctx context.Context, user *entities.User) error {
 	if m.createErr != nil {
 		return m.createErr
 	}
@@ -31,49 +44,125 @@ func (m *MockUserRepository) Create(user *entities.User) error {
 	return nil
 }
 
-func (m *MockUserRepository) GetByID(id int64) (*entities.User, error) {
+func (m *MockUserRepository) GetByID(ctx context.Context, id int64) (*entities.User, error) {
 	if m.getErr != nil {
 		return nil, m.getErr
 	}
 	for _, user := range m.users {
-		if user.ID == id {
+		if user.ID == id && !user.DeletedAt.Valid {
 			return user, nil
 		}
 	}
-	return nil, errors.New("user not found")
+	return nil, apperrors.NewNotFoundError("User")
 }
 
-func (m *MockUserRepository) GetByEmail(email string) (*entities.User, error) {
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	if m.getErr != nil {
 		return nil, m.getErr
 	}
 	for _, user := range m.users {
-		if user.Email == email {
+		if user.Email == email && !user.DeletedAt.Valid {
 			return user, nil
 		}
 	}
-	return nil, errors.New("user not found")
+	return nil, apperrors.NewNotFoundError("User")
+}
+
+func (m *MockUserRepository) GetByVerificationToken(ctx context.Context, tokenHash string) (*entities.User, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	for _, user := range m.users {
+		if user.VerificationToken == tokenHash {
+			return user, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("User")
+}
+
+func (m *MockUserRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) (int64, error) {
+	if m.updateErr != nil {
+		return 0, m.updateErr
+	}
+	for _, user := range m.users {
+		if user.ResetPasswordToken == tokenHash && user.ResetPasswordExpires != nil &&
+			user.ResetPasswordExpires.After(now) && !user.DeletedAt.Valid {
+			user.PasswordHash = passwordHash
+			user.ResetPasswordToken = ""
+			user.ResetPasswordExpires = nil
+			return user.ID, nil
+		}
+	}
+	return 0, apperrors.NewNotFoundError("User")
 }
 
-func (m *MockUserRepository) Update(user *entities.User) error {
+func (m *MockUserRepository) Update(ctx context.Context, user *entities.User) error {
 	if m.updateErr != nil {
 		return m.updateErr
 	}
 	return nil
 }
 
-func (m *MockUserRepository) Delete(id int64) error {
+func (m *MockUserRepository) Delete(ctx context.Context, id int64) error {
 	if m.deleteErr != nil {
 		return m.deleteErr
 	}
+	user, err := m.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	user.DeletedAt.Time, user.DeletedAt.Valid = time.Now(), true
 	return nil
 }
 
-func (m *MockUserRepository) List(offset, limit int) ([]*entities.User, error) {
+func (m *MockUserRepository) ListDeleted(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error) {
+	var users []*entities.User
+	for _, user := range m.users {
+		if user.DeletedAt.Valid {
+			users = append(users, user)
+		}
+	}
+	return pagination.Page[*entities.User]{Items: users}, nil
+}
+
+func (m *MockUserRepository) Restore(ctx context.Context, id int64) error {
+	for _, user := range m.users {
+		if user.ID == id && user.DeletedAt.Valid {
+			user.DeletedAt.Valid = false
+			return nil
+		}
+	}
+	return apperrors.NewNotFoundError("User")
+}
+
+func (m *MockUserRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var kept []*entities.User
+	for _, user := range m.users {
+		if !user.DeletedAt.Valid || !user.DeletedAt.Time.Before(before) {
+			kept = append(kept, user)
+		}
+	}
+	purged := int64(len(m.users) - len(kept))
+	m.users = kept
+	return purged, nil
+}
+
+func (m *MockUserRepository) List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error) {
 	if m.getErr != nil {
-		return nil, m.getErr
+		return pagination.Page[*entities.User]{}, m.getErr
+	}
+	var users []*entities.User
+	for _, user := range m.users {
+		if params.Cursor == nil || user.ID > params.Cursor.ID {
+			users = append(users, user)
+		}
+	}
+	if len(users) > params.Limit+1 {
+		users = users[:params.Limit+1]
 	}
-	return m.users, nil
+	return pagination.NewPage(users, params, func(user *entities.User) pagination.Cursor {
+		return pagination.Cursor{ID: user.ID}
+	}), nil
 }
 
 type MockUserUseCase struct {
@@ -86,28 +175,28 @@ func NewMockUserUseCase(userRepo repositories.UserRepository) *MockUserUseCase {
 	}
 }
 
-func (u *MockUserUseCase) Register(user *entities.User) error {
-	return u.userRepo.Create(user)
+func (u *MockUserUseCase) Register(ctx context.Context, user *entities.User) error {
+	return u.userRepo.Create(ctx, user)
 }
 
-func (u *MockUserUseCase) Login(email, password string) (*entities.User, error) {
-	return u.userRepo.GetByEmail(email)
+func (u *MockUserUseCase) Login(ctx context.Context, email, password string) (*entities.User, error) {
+	return u.userRepo.GetByEmail(ctx, email)
 }
 
-func (u *MockUserUseCase) GetUserByID(id int64) (*entities.User, error) {
-	return u.userRepo.GetByID(id)
+func (u *MockUserUseCase) GetUserByID(ctx context.Context, id int64) (*entities.User, error) {
+	return u.userRepo.GetByID(ctx, id)
 }
 
-func (u *MockUserUseCase) UpdateUser(user *entities.User) error {
-	return u.userRepo.Update(user)
+func (u *MockUserUseCase) UpdateUser(ctx context.Context, user *entities.User) error {
+	return u.userRepo.Update(ctx, user)
 }
 
-func (u *MockUserUseCase) DeleteUser(id int64) error {
-	return u.userRepo.Delete(id)
+func (u *MockUserUseCase) DeleteUser(ctx context.Context, id int64) error {
+	return u.userRepo.Delete(ctx, id)
 }
 
-func (u *MockUserUseCase) ListUsers(offset, limit int) ([]*entities.User, error) {
-	return u.userRepo.List(offset, limit)
+func (u *MockUserUseCase) ListUsers(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error) {
+	return u.userRepo.List(ctx, params)
 }
 
 func TestRegisterUser(t *testing.T) {
@@ -120,7 +209,7 @@ func TestRegisterUser(t *testing.T) {
 		RoleID:   1,
 	}
 
-	err := useCase.Register(user)
+	err := useCase.Register(ctx, user)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -141,7 +230,7 @@ func TestRegisterUserWithError(t *testing.T) {
 		FullName: "Test User",
 	}
 
-	err := useCase.Register(user)
+	err := useCase.Register(ctx, user)
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -160,7 +249,7 @@ func TestLoginSuccess(t *testing.T) {
 	}
 	mockRepo.users = append(mockRepo.users, user)
 
-	result, err := useCase.Login("test@example.com", "password123")
+	result, err := useCase.Login(ctx, "test@example.com", "password123")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -179,7 +268,7 @@ func TestLoginUserNotFound(t *testing.T) {
 	mockRepo := NewMockUserRepository()
 	useCase := NewMockUserUseCase(mockRepo)
 
-	_, err := useCase.Login("nonexistent@example.com", "password123")
+	_, err := useCase.Login(ctx, "nonexistent@example.com", "password123")
 
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -197,7 +286,7 @@ func TestGetUserByID(t *testing.T) {
 	}
 	mockRepo.users = append(mockRepo.users, user)
 
-	result, err := useCase.GetUserByID(1)
+	result, err := useCase.GetUserByID(ctx, 1)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -220,7 +309,7 @@ func TestUpdateUser(t *testing.T) {
 	mockRepo.users = append(mockRepo.users, user)
 
 	user.FullName = "Updated User"
-	err := useCase.UpdateUser(user)
+	err := useCase.UpdateUser(ctx, user)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -238,7 +327,7 @@ func TestDeleteUser(t *testing.T) {
 	}
 	mockRepo.users = append(mockRepo.users, user)
 
-	err := useCase.DeleteUser(1)
+	err := useCase.DeleteUser(ctx, 1)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -258,13 +347,416 @@ func TestListUsers(t *testing.T) {
 		mockRepo.users = append(mockRepo.users, user)
 	}
 
-	users, err := useCase.ListUsers(0, 10)
+	page, err := useCase.ListUsers(ctx, pagination.Params{Limit: 10})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(users) != 5 {
-		t.Errorf("Expected 5 users, got %d", len(users))
+	if len(page.Items) != 5 {
+		t.Errorf("Expected 5 users, got %d", len(page.Items))
+	}
+
+	if page.HasMore {
+		t.Errorf("Expected no further page")
+	}
+}
+
+type MockRefreshTokenRepository struct {
+	tokens []*entities.RefreshToken
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *entities.RefreshToken) error {
+	token.ID = int64(len(m.tokens) + 1)
+	token.CreatedAt = time.Now()
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError("Refresh token")
+}
+
+func (m *MockRefreshTokenRepository) MarkRevoked(ctx context.Context, id int64) (bool, error) {
+	for _, token := range m.tokens {
+		if token.ID == id && token.RevokedAt == nil {
+			now := time.Now()
+			token.RevokedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockRefreshTokenRepository) ListByFamilyIDSince(ctx context.Context, familyID string, since time.Time) ([]*entities.RefreshToken, error) {
+	var result []*entities.RefreshToken
+	for _, token := range m.tokens {
+		if token.FamilyID == familyID && !token.CreatedAt.Before(since) {
+			result = append(result, token)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockRefreshTokenRepository) ListByUserIDSince(ctx context.Context, userID int64, since time.Time) ([]*entities.RefreshToken, error) {
+	var result []*entities.RefreshToken
+	for _, token := range m.tokens {
+		if token.UserID == userID && !token.CreatedAt.Before(since) {
+			result = append(result, token)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllByUserID(ctx context.Context, userID int64) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type MockRevokedTokenRepository struct {
+	revoked map[string]bool
+}
+
+func NewMockRevokedTokenRepository() *MockRevokedTokenRepository {
+	return &MockRevokedTokenRepository{
+		revoked: make(map[string]bool),
+	}
+}
+
+func (m *MockRevokedTokenRepository) Create(ctx context.Context, token *entities.RevokedToken) error {
+	m.revoked[token.TokenID] = true
+	return nil
+}
+
+func (m *MockRevokedTokenRepository) Exists(ctx context.Context, tokenID string) (bool, error) {
+	return m.revoked[tokenID], nil
+}
+
+func (m *MockRevokedTokenRepository) DeleteExpired(ctx context.Context) error {
+	return nil
+}
+
+// MockTxManager runs units of work against mock repositories. Like a rolled
+// back transaction, a failed unit of work leaves the users and addresses as
+// they were. Repositories it does not hold are nil.
+type MockTxManager struct {
+	repositories.Repositories
+	users     *MockUserRepository
+	addresses *MockAddressRepository
+	regions   *MockRegionRepository
+}
+
+func NewMockTxManager(users *MockUserRepository) *MockTxManager {
+	return &MockTxManager{
+		users:     users,
+		addresses: &MockAddressRepository{},
+		regions:   newMockRegionRepository(),
+	}
+}
+
+func (m *MockTxManager) Do(ctx context.Context, fn func(ctx context.Context, repos repositories.Repositories) error) error {
+	users := append([]*entities.User(nil), m.users.users...)
+	addresses := append([]*entities.Address(nil), m.addresses.addresses...)
+	if err := fn(ctx, m); err != nil {
+		m.users.users = users
+		m.addresses.addresses = addresses
+		return err
+	}
+	return nil
+}
+
+func (m *MockTxManager) Users() repositories.UserRepository        { return m.users }
+func (m *MockTxManager) Addresses() repositories.AddressRepository { return m.addresses }
+func (m *MockTxManager) Regions() repositories.RegionRepository    { return m.regions }
+
+func newTestUserConfig() *config.Config {
+	return &config.Config{
+		JWTSecret:                  "test-secret",
+		JWTExpiry:                  "15m",
+		RefreshTokenExpiry:         "720h",
+		AppBaseURL:                 "http://localhost:8080",
+		VerificationTokenExpiry:    "24h",
+		VerificationResendInterval: "1m",
+		PasswordResetURL:           "http://localhost:3000/reset-password",
+		PasswordResetTokenExpiry:   "1h",
+	}
+}
+
+func newTestUserUseCase() (usecases.UserUseCase, *MockRefreshTokenRepository, *mailer.MemoryMailer) {
+	refreshRepo := &MockRefreshTokenRepository{}
+	revokedRepo := NewMockRevokedTokenRepository()
+	mail := mailer.NewMemoryMailer()
+	cfg := newTestUserConfig()
+
+	userRepo := NewMockUserRepository()
+	useCase := usecases.NewUserUseCase(userRepo, refreshRepo, revokedRepo, NewMockTxManager(userRepo), mail, cfg)
+	useCase.Register(ctx, &entities.User{
+		Email:        "test@example.com",
+		PasswordHash: "password123",
+		FullName:     "Test User",
+		IsActive:     true,
+	}, nil)
+
+	return useCase, refreshRepo, mail
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	useCase, _, _ := newTestUserUseCase()
+
+	tokens, _, err := useCase.Login(ctx, "test@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	rotated, err := useCase.RefreshToken(ctx, tokens.RefreshToken)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rotated.RefreshToken == tokens.RefreshToken {
+		t.Error("Expected refresh token to be rotated")
+	}
+
+	if _, err := useCase.RefreshToken(ctx, rotated.RefreshToken); err != nil {
+		t.Errorf("Expected rotated token to be usable, got %v", err)
+	}
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	useCase, refreshRepo, _ := newTestUserUseCase()
+
+	tokens, _, _ := useCase.Login(ctx, "test@example.com", "password123")
+	rotated, _ := useCase.RefreshToken(ctx, tokens.RefreshToken)
+
+	_, err := useCase.RefreshToken(ctx, tokens.RefreshToken)
+	if !errors.Is(err, usecases.ErrRefreshTokenReused) {
+		t.Errorf("Expected ErrRefreshTokenReused, got %v", err)
+	}
+
+	if _, err := useCase.RefreshToken(ctx, rotated.RefreshToken); err == nil {
+		t.Error("Expected the whole token family to be revoked")
+	}
+
+	for _, token := range refreshRepo.tokens {
+		revoked, _ := useCase.IsTokenRevoked(ctx, token.AccessTokenID)
+		if !revoked {
+			t.Errorf("Expected access token %s to be revoked", token.AccessTokenID)
+		}
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	useCase, _, _ := newTestUserUseCase()
+
+	first, user, _ := useCase.Login(ctx, "test@example.com", "password123")
+	second, _, _ := useCase.Login(ctx, "test@example.com", "password123")
+
+	if err := useCase.LogoutAll(ctx, user.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, refreshToken := range []string{first.RefreshToken, second.RefreshToken} {
+		if _, err := useCase.RefreshToken(ctx, refreshToken); err == nil {
+			t.Error("Expected refresh token to be revoked")
+		}
+	}
+}
+
+func TestDeleteUserRevokesSessions(t *testing.T) {
+	useCase, _, _ := newTestUserUseCase()
+
+	tokens, user, _ := useCase.Login(ctx, "test@example.com", "password123")
+	if err := useCase.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := useCase.RefreshToken(ctx, tokens.RefreshToken); err == nil {
+		t.Error("Expected refresh token to be revoked")
+	}
+	if _, _, err := useCase.Login(ctx, "test@example.com", "password123"); !errors.Is(err, usecases.ErrInvalidCredentials) {
+		t.Errorf("Expected deleted user to be unable to log in, got %v", err)
+	}
+}
+
+func tokenFromEmail(t *testing.T, body string) string {
+	start := strings.Index(body, "http")
+	if start < 0 {
+		t.Fatal("Expected link in email body")
+	}
+	link, err := url.Parse(strings.Fields(body[start:])[0])
+	if err != nil {
+		t.Fatalf("Expected valid link, got %v", err)
+	}
+	return link.Query().Get("token")
+}
+
+func TestRegisterSendsVerificationEmail(t *testing.T) {
+	useCase, _, mail := newTestUserUseCase()
+
+	messages := mail.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(messages))
+	}
+
+	if messages[0].To != "test@example.com" {
+		t.Errorf("Expected email to test@example.com, got %s", messages[0].To)
+	}
+
+	token := tokenFromEmail(t, messages[0].Body)
+	if err := useCase.VerifyEmail(ctx, token); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	verified, _ := useCase.IsUserVerified(ctx, 1)
+	if !verified {
+		t.Error("Expected user to be verified")
+	}
+
+	if err := useCase.VerifyEmail(ctx, token); !errors.Is(err, usecases.ErrInvalidVerificationToken) {
+		t.Errorf("Expected token to be single use, got %v", err)
+	}
+}
+
+func TestRegisterWithAddress(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	txManager := NewMockTxManager(userRepo)
+	useCase := usecases.NewUserUseCase(userRepo, &MockRefreshTokenRepository{}, NewMockRevokedTokenRepository(), txManager, mailer.NewMemoryMailer(), newTestUserConfig())
+
+	user := &entities.User{Email: "budi@example.com", PasswordHash: "password123", FullName: "Budi Santoso"}
+	if err := useCase.Register(ctx, user, newTestAddress("Rumah")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	addresses, _ := txManager.addresses.ListByUserID(ctx, user.ID)
+	if len(addresses) != 1 {
+		t.Fatalf("Expected 1 address, got %d", len(addresses))
+	}
+	if !addresses[0].IsDefault || addresses[0].District.Name != "Tebet" {
+		t.Errorf("Expected a default address in Tebet, got %+v", addresses[0])
+	}
+
+	address := newTestAddress("Rumah")
+	address.DistrictCode = "31.74.99"
+	err := useCase.Register(ctx, &entities.User{Email: "siti@example.com", PasswordHash: "password123", FullName: "Siti"}, address)
+	if !errors.Is(err, usecases.ErrInvalidRegion) {
+		t.Fatalf("Expected ErrInvalidRegion, got %v", err)
+	}
+	if _, err := userRepo.GetByEmail(ctx, "siti@example.com"); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("Expected the user to be rolled back with the address, got %v", err)
+	}
+}
+
+func TestVerifyEmailInvalidToken(t *testing.T) {
+	useCase, _, _ := newTestUserUseCase()
+
+	for _, token := range []string{"", "not-a-token"} {
+		if err := useCase.VerifyEmail(ctx, token); !errors.Is(err, usecases.ErrInvalidVerificationToken) {
+			t.Errorf("Expected ErrInvalidVerificationToken for %q, got %v", token, err)
+		}
+	}
+}
+
+func TestResendVerificationIsRateLimited(t *testing.T) {
+	useCase, _, mail := newTestUserUseCase()
+
+	if err := useCase.ResendVerification(ctx, "test@example.com"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(mail.Messages()) != 1 {
+		t.Errorf("Expected resend within the interval to be skipped, got %d emails", len(mail.Messages()))
+	}
+
+	if err := useCase.ResendVerification(ctx, "unknown@example.com"); err != nil {
+		t.Errorf("Expected unknown email to be ignored, got %v", err)
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	useCase, _, mail := newTestUserUseCase()
+
+	tokens, user, _ := useCase.Login(ctx, "test@example.com", "password123")
+
+	if err := useCase.ForgotPassword(ctx, "test@example.com"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	messages := mail.Messages()
+	token := tokenFromEmail(t, messages[len(messages)-1].Body)
+
+	if err := useCase.ResetPassword(ctx, token, "newpassword123"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, _, err := useCase.Login(ctx, "test@example.com", "password123"); err == nil {
+		t.Error("Expected old password to be rejected")
+	}
+
+	if _, _, err := useCase.Login(ctx, "test@example.com", "newpassword123"); err != nil {
+		t.Errorf("Expected new password to be accepted, got %v", err)
+	}
+
+	if _, err := useCase.RefreshToken(ctx, tokens.RefreshToken); err == nil {
+		t.Error("Expected existing sessions to be revoked")
+	}
+
+	if err := useCase.ResetPassword(ctx, token, "anotherpassword"); !errors.Is(err, usecases.ErrInvalidResetToken) {
+		t.Errorf("Expected reset token to be single use, got %v", err)
+	}
+
+	if user.ResetPasswordToken != "" {
+		t.Error("Expected reset token to be cleared")
+	}
+}
+
+func TestForgotPasswordUnknownEmail(t *testing.T) {
+	useCase, _, mail := newTestUserUseCase()
+	sent := len(mail.Messages())
+
+	if err := useCase.ForgotPassword(ctx, "unknown@example.com"); err != nil {
+		t.Errorf("Expected no error for unknown email, got %v", err)
+	}
+
+	if len(mail.Messages()) != sent {
+		t.Error("Expected no email to be sent for unknown email")
+	}
+}
+
+type failingMailer struct{}
+
+func (failingMailer) Send(message *ports.MailMessage) error {
+	return errors.New("smtp unavailable")
+}
+
+func TestForgotPasswordHidesMailFailures(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	useCase := usecases.NewUserUseCase(userRepo, &MockRefreshTokenRepository{}, NewMockRevokedTokenRepository(), NewMockTxManager(userRepo), failingMailer{}, newTestUserConfig())
+	userRepo.Create(ctx, &entities.User{Email: "test@example.com", FullName: "Test User", IsActive: true})
+
+	// Registered and unknown emails must be indistinguishable.
+	for _, email := range []string{"test@example.com", "unknown@example.com"} {
+		if err := useCase.ForgotPassword(ctx, email); err != nil {
+			t.Errorf("Expected no error for %s, got %v", email, err)
+		}
 	}
 }
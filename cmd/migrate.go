@@ -1,22 +1,26 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/logging"
 	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
 	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/database"
 	"github.com/yourusername/ecommerce-go-vue/backend/seeders"
 )
 
 var (
-	migrateCmd = flag.NewFlagSet("migrate", flag.ExitOnError)
-	seedCmd    = flag.NewFlagSet("seed", flag.ExitOnError)
+	migrateCmd    = flag.NewFlagSet("migrate", flag.ExitOnError)
+	seedCmd       = flag.NewFlagSet("seed", flag.ExitOnError)
+	stockDriftCmd = flag.NewFlagSet("stock-drift", flag.ExitOnError)
+	fixDrift      = stockDriftCmd.Bool("fix", false, "set the stock of drifted products to their ledger balance")
 )
 
 func main() {
@@ -25,6 +29,7 @@ func main() {
 		fmt.Println("Commands:")
 		fmt.Println("  migrate - Run database migrations")
 		fmt.Println("  seed    - Run database seeders")
+		fmt.Println("  stock-drift [-fix] - Report products whose stock differs from the stock ledger")
 		os.Exit(1)
 	}
 
@@ -35,6 +40,9 @@ func main() {
 		runMigrate()
 	case "seed":
 		runSeed()
+	case "stock-drift":
+		stockDriftCmd.Parse(os.Args[2:])
+		runStockDrift(*fixDrift)
 	default:
 		fmt.Printf("Unknown command: %s\n", cmd)
 		os.Exit(1)
@@ -43,18 +51,15 @@ func main() {
 
 func runMigrate() {
 	cfg := config.LoadConfig()
+	logging.Setup(cfg.AppEnv, cfg.LogLevel)
 
-	if err := database.InitDB(cfg); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	db, err := database.Connect(cfg)
+	if err != nil {
+		fatal("Failed to connect to database", err)
 	}
-	defer func() {
-		sqlDB, _ := database.DB.DB()
-		if sqlDB != nil {
-			sqlDB.Close()
-		}
-	}()
+	defer database.Close(db)
 
-	log.Println("Running database migrations...")
+	slog.Info("Running database migrations")
 
 	m, err := migrate.New(
 		"file://migrations",
@@ -68,47 +73,107 @@ func runMigrate() {
 	)
 
 	if err != nil {
-		log.Fatalf("Failed to create migration instance: %v", err)
+		fatal("Failed to create migration instance", err)
 	}
 
 	currentVersion, dirty, err := m.Version()
 	if err != nil && err != migrate.ErrNilVersion {
-		log.Fatalf("Failed to get migration version: %v", err)
+		fatal("Failed to get migration version", err)
 	}
 
 	if err == migrate.ErrNilVersion {
-		log.Println("No migrations found. Database is clean.")
+		slog.Info("No migrations applied yet")
 	} else {
-		log.Printf("Current migration version: %d, dirty: %v", currentVersion, dirty)
+		slog.Info("Current migration version", "version", currentVersion, "dirty", dirty)
 	}
 
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		log.Fatalf("Failed to run migrations: %v", err)
+		fatal("Failed to run migrations", err)
 	}
 
 	if err == migrate.ErrNoChange {
-		log.Println("✓ Database is already up to date")
+		slog.Info("Database is already up to date")
 	} else {
-		log.Println("✓ Migrations completed successfully")
+		slog.Info("Migrations completed")
 	}
 }
 
 func runSeed() {
 	cfg := config.LoadConfig()
+	logging.Setup(cfg.AppEnv, cfg.LogLevel)
 
-	if err := database.InitDB(cfg); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	db, err := database.Connect(cfg)
+	if err != nil {
+		fatal("Failed to connect to database", err)
 	}
-	defer func() {
-		sqlDB, _ := database.DB.DB()
-		if sqlDB != nil {
-			sqlDB.Close()
-		}
-	}()
+	defer database.Close(db)
+
+	slog.Info("Running database seeders")
+
+	if err := seeders.RunSeeders(db); err != nil {
+		fatal("Failed to run seeders", err)
+	}
+}
+
+// runStockDrift compares every product's stock_quantity with the sum of its
+// stock movements. With fix, the stock is set to the ledger balance, as the
+// ledger is the source of truth.
+func runStockDrift(fix bool) {
+	cfg := config.LoadConfig()
+	logging.Setup(cfg.AppEnv, cfg.LogLevel)
+
+	db, err := database.Connect(cfg)
+	if err != nil {
+		fatal("Failed to connect to database", err)
+	}
+	defer database.Close(db)
+
+	slog.Info("Checking stock against the stock ledger")
 
-	log.Println("Running database seeders...")
+	ctx := context.Background()
+	stockMovementRepo := database.NewStockMovementRepository(db)
+	drifts, err := stockMovementRepo.ListDrift(ctx)
+	if err != nil {
+		fatal("Failed to check stock drift", err)
+	}
+
+	if len(drifts) == 0 {
+		slog.Info("Stock matches the ledger")
+		return
+	}
+
+	for _, drift := range drifts {
+		slog.Warn("Stock drifted from the ledger",
+			"product_id", drift.ProductID,
+			"sku", drift.SKU,
+			"name", drift.Name,
+			"stock", drift.StockQuantity,
+			"ledger", drift.LedgerBalance,
+			"drift", drift.Difference(),
+		)
+	}
+
+	if !fix {
+		slog.Warn("Products drifted; run with -fix to set their stock to the ledger balance", "count", len(drifts))
+		os.Exit(1)
+	}
 
-	if err := seeders.RunSeeders(); err != nil {
-		log.Fatalf("Failed to run seeders: %v", err)
+	failed := 0
+	for _, drift := range drifts {
+		if err := stockMovementRepo.Reconcile(ctx, drift.ProductID); err != nil {
+			slog.Error("Failed to reconcile product", "product_id", drift.ProductID, "error", err)
+			failed++
+		}
 	}
+	if failed > 0 {
+		slog.Error("Products could not be reconciled", "count", failed)
+		os.Exit(1)
+	}
+	slog.Info("Reconciled products", "count", len(drifts))
+}
+
+// fatal logs err and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
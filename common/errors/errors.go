@@ -4,9 +4,15 @@ import (
 	"fmt"
 )
 
+// AppError is the error type returned by repositories, use cases and
+// handlers. Code is the HTTP status, Type a stable machine readable code that
+// clients can switch on, and Details optional structured information such as
+// field validation errors.
 type AppError struct {
 	Code    int
+	Type    string
 	Message string
+	Details interface{}
 	Err     error
 }
 
@@ -17,31 +23,74 @@ func (e *AppError) Error() string {
 	return e.Message
 }
 
-func NewAppError(code int, message string, err error) *AppError {
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is match any AppError of the same type, so callers can
+// write errors.Is(err, errors.ErrNotFound) regardless of the message.
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Type == e.Type
+}
+
+// WithDetails returns a copy of the error carrying the given details.
+func (e *AppError) WithDetails(details interface{}) *AppError {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
+func NewAppError(code int, errType string, message string, err error) *AppError {
 	return &AppError{
 		Code:    code,
+		Type:    errType,
 		Message: message,
 		Err:     err,
 	}
 }
 
 var (
-	ErrBadRequest          = NewAppError(400, "Bad Request", nil)
-	ErrUnauthorized        = NewAppError(401, "Unauthorized", nil)
-	ErrForbidden           = NewAppError(403, "Forbidden", nil)
-	ErrNotFound            = NewAppError(404, "Resource Not Found", nil)
-	ErrConflict            = NewAppError(409, "Conflict", nil)
-	ErrInternalServerError = NewAppError(500, "Internal Server Error", nil)
+	ErrBadRequest          = NewAppError(400, "bad_request", "Bad Request", nil)
+	ErrUnauthorized        = NewAppError(401, "unauthorized", "Unauthorized", nil)
+	ErrForbidden           = NewAppError(403, "forbidden", "Forbidden", nil)
+	ErrNotFound            = NewAppError(404, "not_found", "Resource Not Found", nil)
+	ErrConflict            = NewAppError(409, "conflict", "Conflict", nil)
+	ErrValidation          = NewAppError(422, "validation_failed", "Validation failed", nil)
+	ErrTooManyRequests     = NewAppError(429, "too_many_requests", "Too Many Requests", nil)
+	ErrInternalServerError = NewAppError(500, "internal_error", "Internal Server Error", nil)
+	ErrRequestTimeout      = NewAppError(503, "request_timeout", "Request timed out", nil)
 )
 
+func NewBadRequestError(message string) *AppError {
+	return NewAppError(400, "bad_request", message, nil)
+}
+
 func NewValidationError(message string) *AppError {
-	return NewAppError(400, message, nil)
+	return NewAppError(400, "bad_request", message, nil)
+}
+
+// NewValidationFailedError reports field level validation errors.
+func NewValidationFailedError(details interface{}) *AppError {
+	return ErrValidation.WithDetails(details)
+}
+
+func NewUnauthorizedError(message string) *AppError {
+	return NewAppError(401, "unauthorized", message, nil)
+}
+
+func NewForbiddenError(message string) *AppError {
+	return NewAppError(403, "forbidden", message, nil)
 }
 
 func NewNotFoundError(resource string) *AppError {
-	return NewAppError(404, fmt.Sprintf("%s not found", resource), nil)
+	return NewAppError(404, "not_found", fmt.Sprintf("%s not found", resource), nil)
 }
 
 func NewConflictError(message string) *AppError {
-	return NewAppError(409, message, nil)
+	return NewAppError(409, "conflict", message, nil)
+}
+
+func NewInternalError(err error) *AppError {
+	return NewAppError(500, "internal_error", "Internal Server Error", err)
 }
@@ -0,0 +1,45 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/health"
+)
+
+func TestRegistry_ReportsEveryComponent(t *testing.T) {
+	registry := health.NewRegistry(time.Second)
+	registry.Register("database", func(ctx context.Context) error { return nil })
+	registry.Register("mailer", func(ctx context.Context) error { return nil })
+
+	report := registry.Check(context.Background())
+	assert.True(t, report.IsUp())
+	assert.Equal(t, []string{"database", "mailer"}, registry.Names())
+	assert.Equal(t, health.StatusUp, report.Components["database"].Status)
+	assert.Equal(t, health.StatusUp, report.Components["mailer"].Status)
+
+	registry.Register("mailer", func(ctx context.Context) error { return errors.New("connection refused") })
+	report = registry.Check(context.Background())
+	assert.False(t, report.IsUp(), "one failing component takes the service down")
+	assert.Equal(t, health.StatusUp, report.Components["database"].Status)
+	assert.Equal(t, health.Component{Status: health.StatusDown, LatencyMS: report.Components["mailer"].LatencyMS, Error: "connection refused"}, report.Components["mailer"])
+}
+
+func TestRegistry_TimesOutHangingChecks(t *testing.T) {
+	registry := health.NewRegistry(20 * time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	registry.Register("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	report := registry.Check(context.Background())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, health.StatusDown, report.Components["stuck"].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Components["stuck"].Error)
+}
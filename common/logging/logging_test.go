@@ -0,0 +1,64 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/logging"
+)
+
+func decodeLine(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("expected a JSON line, got %q: %v", buf.String(), err)
+	}
+	buf.Reset()
+	return line
+}
+
+func TestLogger_RedactsCredentials(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, slog.LevelInfo)
+
+	logger.Info("User updated",
+		"user_id", 7,
+		"password_hash", "$2a$10$abc",
+		slog.Group("tokens", "refresh_token", "r-123"),
+		"Authorization", "Bearer abc",
+	)
+
+	assert.NotContains(t, buf.String(), "abc")
+	line := decodeLine(t, &buf)
+	assert.Equal(t, float64(7), line["user_id"])
+	assert.Equal(t, logging.Redacted, line["password_hash"])
+	assert.Equal(t, map[string]interface{}{"refresh_token": logging.Redacted}, line["tokens"])
+	assert.Equal(t, logging.Redacted, line["Authorization"])
+}
+
+func TestLogger_AddsTheRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, slog.LevelInfo).With("component", "test")
+
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+	logger.InfoContext(ctx, "Handled")
+	assert.Equal(t, "req-1", decodeLine(t, &buf)["request_id"])
+
+	logger.Info("Background job")
+	assert.NotContains(t, decodeLine(t, &buf), "request_id")
+
+	logger.DebugContext(ctx, "Hidden below the level")
+	assert.Empty(t, buf.String())
+}
+
+func TestLevel_DependsOnTheEnvironment(t *testing.T) {
+	assert.Equal(t, slog.LevelInfo, logging.Level("production", ""))
+	assert.Equal(t, slog.LevelDebug, logging.Level("development", ""))
+	assert.Equal(t, slog.LevelWarn, logging.Level("production", "warn"))
+	assert.Equal(t, slog.LevelInfo, logging.Level("production", "loud"), "invalid levels fall back to the environment")
+}
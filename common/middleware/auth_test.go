@@ -0,0 +1,152 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
+)
+
+const testSecret = "test-secret"
+
+func newAuthTestApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler(false)})
+	app.Get("/protected", middleware.AuthMiddleware(testSecret, nil), func(c *fiber.Ctx) error {
+		userID, ok := middleware.GetUserID(c)
+		if !ok {
+			return c.SendStatus(500)
+		}
+		return c.JSON(fiber.Map{"user_id": userID})
+	})
+	return app
+}
+
+func signClaims(t *testing.T, claims utils.Claims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	assert.NoError(t, err)
+	return token
+}
+
+func TestAuthMiddlewareValidToken(t *testing.T) {
+	token, err := utils.GenerateToken(42, "test@example.com", 1, "test-jti", testSecret, "1h")
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := newAuthTestApp().Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestAuthMiddlewareMissingHeader(t *testing.T) {
+	req := httptest.NewRequest("GET", "/protected", nil)
+
+	resp, err := newAuthTestApp().Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestAuthMiddlewareWrongScheme(t *testing.T) {
+	token, _ := utils.GenerateToken(42, "test@example.com", 1, "test-jti", testSecret, "1h")
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Basic "+token)
+
+	resp, err := newAuthTestApp().Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestAuthMiddlewareWrongSecret(t *testing.T) {
+	token, _ := utils.GenerateToken(42, "test@example.com", 1, "test-jti", "other-secret", "1h")
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := newAuthTestApp().Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestAuthMiddlewareExpiredToken(t *testing.T) {
+	token := signClaims(t, utils.Claims{
+		UserID: 42,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+		},
+	})
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := newAuthTestApp().Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestAuthMiddlewareNotValidYet(t *testing.T) {
+	token := signClaims(t, utils.Claims{
+		UserID: 42,
+		RegisteredClaims: jwt.RegisteredClaims{
+			NotBefore: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+
+	req := httptest.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := newAuthTestApp().Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+type fakeRevocationChecker map[string]bool
+
+func (f fakeRevocationChecker) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	return f[tokenID], nil
+}
+
+func TestAuthMiddlewareRevokedToken(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler(false)})
+	app.Get("/protected", middleware.AuthMiddleware(testSecret, fakeRevocationChecker{"revoked-jti": true}), func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+
+	revoked, _ := utils.GenerateToken(42, "test@example.com", 1, "revoked-jti", testSecret, "1h")
+	active, _ := utils.GenerateToken(42, "test@example.com", 1, "active-jti", testSecret, "1h")
+	withoutID, _ := utils.GenerateToken(42, "test@example.com", 1, "", testSecret, "1h")
+
+	for token, want := range map[string]int{revoked: 401, active: 200, withoutID: 401} {
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, want, resp.StatusCode)
+	}
+}
+
+func TestOptionalAuth(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler(false)})
+	app.Get("/cart", middleware.OptionalAuth(testSecret, nil), func(c *fiber.Ctx) error {
+		userID, _ := middleware.GetUserID(c)
+		return c.JSON(fiber.Map{"user_id": userID})
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/cart", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode, "anonymous requests are allowed")
+
+	req := httptest.NewRequest("GET", "/cart", nil)
+	req.Header.Set("Authorization", "Bearer not-a-token")
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode, "invalid tokens are still rejected")
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/logging"
+)
+
+// ErrorResponse is the JSON envelope returned for every failed request.
+type ErrorResponse struct {
+	Error     string      `json:"error"`
+	Code      string      `json:"code"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// ErrorHandler renders errors returned by handlers and middleware as an
+// ErrorResponse. When hideInternalErrors is true the message of 5xx errors is
+// replaced with a generic one so internal details never reach clients.
+func ErrorHandler(hideInternalErrors bool) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		appErr := toAppError(err)
+
+		if appErr.Code >= fiber.StatusInternalServerError {
+			slog.ErrorContext(c.UserContext(), "Request failed",
+				"method", c.Method(),
+				"path", c.Path(),
+				"error", err,
+			)
+		}
+
+		message := appErr.Message
+		details := appErr.Details
+		if hideInternalErrors && appErr.Code >= fiber.StatusInternalServerError {
+			message = apperrors.ErrInternalServerError.Message
+			details = nil
+		} else if appErr.Code >= fiber.StatusInternalServerError && appErr.Err != nil {
+			message = appErr.Error()
+		}
+
+		return c.Status(appErr.Code).JSON(ErrorResponse{
+			Error:     message,
+			Code:      appErr.Type,
+			Details:   details,
+			RequestID: requestID(c),
+		})
+	}
+}
+
+func toAppError(err error) *apperrors.AppError {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return apperrors.NewAppError(fiberErr.Code, errorType(fiberErr.Code), fiberErr.Message, nil)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apperrors.ErrRequestTimeout
+	}
+
+	return apperrors.NewInternalError(err)
+}
+
+// errorType derives a machine readable code from an HTTP status, e.g. 404
+// becomes "not_found".
+func errorType(status int) string {
+	text := strings.ToLower(utils.StatusMessage(status))
+	if text == "" {
+		return "error"
+	}
+	return strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text)
+}
+
+func requestID(c *fiber.Ctx) string {
+	if id := logging.RequestID(c.UserContext()); id != "" {
+		return id
+	}
+	return c.GetRespHeader(fiber.HeaderXRequestID)
+}
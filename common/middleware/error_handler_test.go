@@ -0,0 +1,64 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
+)
+
+func errorResponseFor(t *testing.T, hideInternalErrors bool, handlerErr error) (int, middleware.ErrorResponse) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler(hideInternalErrors)})
+	app.Get("/", func(c *fiber.Ctx) error {
+		return handlerErr
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	assert.NoError(t, err)
+
+	var body middleware.ErrorResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestErrorHandlerAppError(t *testing.T) {
+	status, body := errorResponseFor(t, true, apperrors.NewNotFoundError("User"))
+
+	assert.Equal(t, 404, status)
+	assert.Equal(t, "not_found", body.Code)
+	assert.Equal(t, "User not found", body.Error)
+}
+
+func TestErrorHandlerDetails(t *testing.T) {
+	status, body := errorResponseFor(t, true, apperrors.NewValidationFailedError([]string{"email"}))
+
+	assert.Equal(t, 422, status)
+	assert.Equal(t, "validation_failed", body.Code)
+	assert.Equal(t, []interface{}{"email"}, body.Details)
+}
+
+func TestErrorHandlerFiberError(t *testing.T) {
+	status, body := errorResponseFor(t, true, fiber.ErrMethodNotAllowed)
+
+	assert.Equal(t, 405, status)
+	assert.Equal(t, "method_not_allowed", body.Code)
+}
+
+func TestErrorHandlerHidesInternalErrors(t *testing.T) {
+	status, body := errorResponseFor(t, true, errors.New("pq: connection refused"))
+
+	assert.Equal(t, 500, status)
+	assert.Equal(t, "internal_error", body.Code)
+	assert.Equal(t, "Internal Server Error", body.Error)
+}
+
+func TestErrorHandlerShowsInternalErrorsOutsideProduction(t *testing.T) {
+	_, body := errorResponseFor(t, false, errors.New("pq: connection refused"))
+
+	assert.Contains(t, body.Error, "connection refused")
+}
@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client chosen key of a request.
+	// Clients send the same key when they retry the request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses replayed from an earlier
+	// request with the same key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+
+	// cartTokenHeader carries the guest cart token, which identifies
+	// anonymous callers of the cart endpoints.
+	cartTokenHeader = "X-Cart-Token"
+)
+
+var (
+	ErrInvalidIdempotencyKey = apperrors.NewAppError(fiber.StatusBadRequest, "invalid_idempotency_key", "Idempotency key must be at most 255 characters", nil)
+	ErrIdempotencyKeyReused  = apperrors.NewAppError(fiber.StatusConflict, "idempotency_key_reused", "Idempotency key was already used for a different request", nil)
+	ErrIdempotencyKeyInUse   = apperrors.NewAppError(fiber.StatusConflict, "idempotency_key_in_use", "A request with this idempotency key is still being processed", nil)
+)
+
+// unreplayedHeaders are response headers that describe the response being
+// sent rather than the result of the request, and are not replayed.
+var unreplayedHeaders = map[string]bool{
+	"Content-Length": true,
+	"Date":           true,
+	"Server":         true,
+	"Set-Cookie":     true,
+	"Vary":           true,
+	"X-Cart-Token":   true,
+	"X-Request-Id":   true,
+}
+
+// IdempotencyStore keeps the keys of idempotent requests and their responses.
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, key *entities.IdempotencyKey) (bool, error)
+	GetByKey(ctx context.Context, scope, key string) (*entities.IdempotencyKey, error)
+	Complete(ctx context.Context, key *entities.IdempotencyKey) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// Idempotency makes POST and PUT requests sent with an Idempotency-Key header
+// safe to retry. It only covers the routes it is attached to; the router adds
+// it to every mutating route except the sign in endpoints and the payment
+// webhook. The first request with a key runs and its response is saved
+// for ttl; retries with the same key and body get the saved response back.
+// Reusing a key for a different request, or while the first one is still
+// running, fails with a conflict. Failed requests change nothing, so their
+// key is released and may be retried. Keys are scoped to the signed in user,
+// or to the guest cart token of anonymous callers, so Idempotency must run
+// after AuthMiddleware or OptionalAuth where the route has one. Guest cart
+// tokens are never saved; responses that issue one are not kept, so their
+// retries run again.
+func Idempotency(store IdempotencyStore, ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodPost && c.Method() != fiber.MethodPut {
+			return c.Next()
+		}
+		value := c.Get(IdempotencyKeyHeader)
+		if value == "" {
+			return c.Next()
+		}
+		if len(value) > maxIdempotencyKeyLength {
+			return ErrInvalidIdempotencyKey
+		}
+
+		scope := idempotencyScope(c)
+		key := &entities.IdempotencyKey{
+			Scope:       scope,
+			Key:         value,
+			Method:      c.Method(),
+			Path:        c.Path(),
+			RequestHash: requestHash(c, scope),
+			ExpiresAt:   time.Now().Add(ttl),
+		}
+		reserved, err := store.Reserve(c.UserContext(), key)
+		if err != nil {
+			return apperrors.NewInternalError(err)
+		}
+		if !reserved {
+			return replay(c, store, key)
+		}
+
+		// The key is settled even when the request ran out of time.
+		ctx := context.WithoutCancel(c.UserContext())
+		completed := false
+		defer func() {
+			// Also runs when the handler panics, so the key is not left
+			// in progress until it expires.
+			if !completed {
+				if err := store.Delete(ctx, key.ID); err != nil {
+					slog.ErrorContext(ctx, "Failed to release idempotency key", "idempotency_key_id", key.ID, "error", err)
+				}
+			}
+		}()
+
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				return err
+			}
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusBadRequest || len(c.Response().Header.Peek(cartTokenHeader)) > 0 {
+			return nil
+		}
+
+		now := time.Now()
+		key.StatusCode = status
+		key.ResponseHeaders = responseHeaders(c)
+		key.ResponseBody = append([]byte(nil), c.Response().Body()...)
+		key.CompletedAt = &now
+		if err := store.Complete(ctx, key); err != nil {
+			slog.ErrorContext(ctx, "Failed to save response for idempotency key", "idempotency_key_id", key.ID, "error", err)
+			return nil
+		}
+		completed = true
+		return nil
+	}
+}
+
+// replay answers a retried request with the saved response of the request
+// that reserved the key.
+func replay(c *fiber.Ctx, store IdempotencyStore, key *entities.IdempotencyKey) error {
+	saved, err := store.GetByKey(c.UserContext(), key.Scope, key.Key)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		// The first request failed and released the key just now.
+		return ErrIdempotencyKeyInUse
+	} else if err != nil {
+		return apperrors.NewInternalError(err)
+	}
+
+	if saved.Method != key.Method || saved.Path != key.Path || saved.RequestHash != key.RequestHash {
+		return ErrIdempotencyKeyReused
+	}
+	if !saved.IsCompleted() {
+		return ErrIdempotencyKeyInUse
+	}
+
+	for name, value := range saved.ResponseHeaders {
+		c.Set(name, value)
+	}
+	c.Set(IdempotentReplayedHeader, "true")
+	return c.Status(saved.StatusCode).Send(saved.ResponseBody)
+}
+
+// idempotencyScope identifies the caller a key belongs to: the signed in
+// user, the guest holding a cart token, or any other anonymous client. Cart
+// tokens are only stored hashed.
+func idempotencyScope(c *fiber.Ctx) string {
+	if userID, ok := GetUserID(c); ok {
+		return "user:" + strconv.FormatInt(userID, 10)
+	}
+	if token := c.Get(cartTokenHeader); token != "" {
+		return "cart:" + utils.HashToken(token)
+	}
+	return "anonymous"
+}
+
+// requestHash fingerprints the request a key was sent with, including the
+// identity of the caller.
+func requestHash(c *fiber.Ctx, scope string) string {
+	hash := sha256.New()
+	hash.Write([]byte(scope + "\n"))
+	hash.Write([]byte(c.Method() + " " + c.Path() + "\n"))
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func responseHeaders(c *fiber.Ctx) map[string]string {
+	headers := map[string]string{}
+	c.Response().Header.VisitAll(func(name, value []byte) {
+		canonical := http.CanonicalHeaderKey(string(name))
+		if !unreplayedHeaders[canonical] {
+			headers[canonical] = string(value)
+		}
+	})
+	return headers
+}
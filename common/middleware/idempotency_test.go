@@ -0,0 +1,241 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+type memoryIdempotencyStore struct {
+	mu   sync.Mutex
+	keys map[string]*entities.IdempotencyKey
+	next int64
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{keys: map[string]*entities.IdempotencyKey{}}
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, key *entities.IdempotencyKey) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.keys[key.Scope+"|"+key.Key]; ok && existing.ExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+	s.next++
+	key.ID = s.next
+	stored := *key
+	s.keys[key.Scope+"|"+key.Key] = &stored
+	return true, nil
+}
+
+func (s *memoryIdempotencyStore) GetByKey(ctx context.Context, scope, key string) (*entities.IdempotencyKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.keys[scope+"|"+key]; ok {
+		clone := *existing
+		return &clone, nil
+	}
+	return nil, apperrors.NewNotFoundError("Idempotency key")
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, key *entities.IdempotencyKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *key
+	s.keys[key.Scope+"|"+key.Key] = &stored
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Delete(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, key := range s.keys {
+		if key.ID == id {
+			delete(s.keys, name)
+		}
+	}
+	return nil
+}
+
+type idempotencyTestApp struct {
+	app     *fiber.App
+	store   *memoryIdempotencyStore
+	created int
+	release chan struct{}
+}
+
+func newIdempotencyTestApp() *idempotencyTestApp {
+	a := &idempotencyTestApp{store: newMemoryIdempotencyStore()}
+	a.app = fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler(false)})
+	a.app.Use(middleware.OptionalAuth(testSecret, nil), middleware.Idempotency(a.store, time.Hour))
+	a.app.Post("/orders", func(c *fiber.Ctx) error {
+		if a.release != nil {
+			<-a.release
+		}
+		if strings.Contains(string(c.Body()), "fail") {
+			return apperrors.NewBadRequestError("Invalid order")
+		}
+		a.created++
+		c.Set("Location", "/orders/"+strconv.Itoa(a.created))
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"id": a.created})
+	})
+	return a
+}
+
+func (a *idempotencyTestApp) post(t *testing.T, key, body string) (int, string, http.Header) {
+	req := httptest.NewRequest("POST", "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	}
+
+	resp, err := a.app.Test(req, -1)
+	assert.NoError(t, err)
+	payload, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return resp.StatusCode, string(payload), resp.Header
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	a := newIdempotencyTestApp()
+
+	status, body, first := a.post(t, "key-1", `{"items":[1]}`)
+	assert.Equal(t, 201, status)
+	assert.Empty(t, first.Get(middleware.IdempotentReplayedHeader))
+
+	status, replayed, retry := a.post(t, "key-1", `{"items":[1]}`)
+	assert.Equal(t, 201, status)
+	assert.Equal(t, body, replayed)
+	assert.Equal(t, "/orders/1", retry.Get("Location"))
+	assert.Equal(t, "true", retry.Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, 1, a.created, "the retry does not run the handler again")
+
+	status, _, _ = a.post(t, "key-2", `{"items":[1]}`)
+	assert.Equal(t, 201, status)
+	status, _, _ = a.post(t, "", `{"items":[1]}`)
+	assert.Equal(t, 201, status)
+	assert.Equal(t, 3, a.created)
+}
+
+func TestIdempotencyRejectsKeyReusedForDifferentBody(t *testing.T) {
+	a := newIdempotencyTestApp()
+
+	status, _, _ := a.post(t, "key-1", `{"items":[1]}`)
+	assert.Equal(t, 201, status)
+
+	status, body, _ := a.post(t, "key-1", `{"items":[2]}`)
+	assert.Equal(t, 409, status)
+	assert.Contains(t, body, "idempotency_key_reused")
+	assert.Equal(t, 1, a.created)
+}
+
+func TestIdempotencyReleasesKeyOfFailedRequest(t *testing.T) {
+	a := newIdempotencyTestApp()
+
+	status, _, _ := a.post(t, "key-1", `{"fail":true}`)
+	assert.Equal(t, 400, status)
+	assert.Empty(t, a.store.keys)
+
+	status, _, _ = a.post(t, "key-1", `{"items":[1]}`)
+	assert.Equal(t, 201, status)
+}
+
+func TestIdempotencyRejectsConcurrentRetry(t *testing.T) {
+	a := newIdempotencyTestApp()
+	a.release = make(chan struct{})
+
+	done := make(chan int)
+	go func() {
+		status, _, _ := a.post(t, "key-1", `{"items":[1]}`)
+		done <- status
+	}()
+
+	assert.Eventually(t, func() bool {
+		_, err := a.store.GetByKey(context.Background(), "anonymous", "key-1")
+		return err == nil
+	}, time.Second, time.Millisecond)
+
+	status, body, _ := a.post(t, "key-1", `{"items":[1]}`)
+	assert.Equal(t, 409, status)
+	assert.Contains(t, body, "idempotency_key_in_use")
+
+	close(a.release)
+	assert.Equal(t, 201, <-done)
+	assert.Equal(t, 1, a.created)
+}
+
+func TestIdempotencyScopesKeysPerUser(t *testing.T) {
+	a := newIdempotencyTestApp()
+
+	status, _, _ := a.post(t, "key-1", `{"items":[1]}`)
+	assert.Equal(t, 201, status)
+
+	token, err := utils.GenerateToken(42, "test@example.com", 1, "test-jti", testSecret, "1h")
+	assert.NoError(t, err)
+	req := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"items":[1]}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+	resp, err := a.app.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, 2, a.created)
+}
+
+func TestIdempotencyScopesGuestKeysPerCartAndNeverReplaysCartTokens(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	created := 0
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler(false)})
+	app.Use(middleware.OptionalAuth(testSecret, nil), middleware.Idempotency(store, time.Hour))
+	app.Post("/cart/items", func(c *fiber.Ctx) error {
+		created++
+		if c.Get("X-Cart-Token") == "" {
+			c.Set("X-Cart-Token", "new-token-"+strconv.Itoa(created))
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"cart": created})
+	})
+	post := func(cartToken string) *http.Response {
+		req := httptest.NewRequest("POST", "/cart/items", strings.NewReader(`{"product_id":1}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		if cartToken != "" {
+			req.Header.Set("X-Cart-Token", cartToken)
+		}
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	// A response issuing a cart token is not kept, so the retry runs again.
+	first := post("")
+	assert.Equal(t, "new-token-1", first.Header.Get("X-Cart-Token"))
+	retry := post("")
+	assert.Equal(t, "new-token-2", retry.Header.Get("X-Cart-Token"))
+	assert.Empty(t, retry.Header.Get(middleware.IdempotentReplayedHeader))
+
+	// Guests with different carts do not share keys.
+	assert.Empty(t, post("cart-a").Header.Get(middleware.IdempotentReplayedHeader))
+	assert.Empty(t, post("cart-b").Header.Get(middleware.IdempotentReplayedHeader))
+	replayed := post("cart-a")
+	assert.Equal(t, "true", replayed.Header.Get(middleware.IdempotentReplayedHeader))
+	assert.Empty(t, replayed.Header.Get("X-Cart-Token"))
+	assert.Equal(t, 4, created)
+
+	for _, key := range store.keys {
+		assert.NotContains(t, key.Scope, "cart-a")
+		assert.NotContains(t, key.ResponseHeaders, "X-Cart-Token")
+	}
+}
@@ -1,24 +0,0 @@
-package middleware
-
-import (
-	"log"
-
-	"github.com/gofiber/fiber/v2"
-)
-
-func LoggingMiddleware(c *fiber.Ctx) error {
-	log.Printf("[%s] %s", c.Method(), c.Path())
-	return c.Next()
-}
-
-func AuthMiddleware() fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		authHeader := c.Get("Authorization")
-		if authHeader == "" {
-			return c.Status(401).JSON(fiber.Map{
-				"error": "Authorization header required",
-			})
-		}
-		return c.Next()
-	}
-}
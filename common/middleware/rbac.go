@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+)
+
+// PermissionChecker resolves whether a role has been granted a permission.
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, roleID int, permission string) (bool, error)
+}
+
+// RequirePermission only lets the request through when the authenticated
+// user's role holds every one of the given permissions. It must run after
+// AuthMiddleware.
+func RequirePermission(checker PermissionChecker, permissions ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleID, ok := GetRoleID(c)
+		if !ok {
+			return unauthorized("missing_token", "Authentication required")
+		}
+
+		for _, permission := range permissions {
+			allowed, err := checker.HasPermission(c.UserContext(), roleID, permission)
+			if err != nil {
+				return apperrors.NewInternalError(err)
+			}
+			if !allowed {
+				return errForbidden
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// RequireOwnerOrPermission lets the request through when the user ID in the
+// given route parameter belongs to the authenticated user, or when the user's
+// role holds the given permission. It must run after AuthMiddleware.
+func RequireOwnerOrPermission(checker PermissionChecker, param, permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := GetClaims(c)
+		if !ok {
+			return unauthorized("missing_token", "Authentication required")
+		}
+
+		if id, err := strconv.ParseInt(c.Params(param), 10, 64); err == nil && id == claims.UserID {
+			return c.Next()
+		}
+
+		allowed, err := checker.HasPermission(c.UserContext(), claims.RoleID, permission)
+		if err != nil {
+			return apperrors.NewInternalError(err)
+		}
+		if !allowed {
+			return errForbidden
+		}
+
+		return c.Next()
+	}
+}
+
+var errForbidden = apperrors.NewForbiddenError("You do not have permission to perform this action")
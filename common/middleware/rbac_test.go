@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
+)
+
+type fakePermissionChecker struct {
+	granted map[int][]string
+	err     error
+}
+
+func (f *fakePermissionChecker) HasPermission(ctx context.Context, roleID int, permission string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	for _, p := range f.granted[roleID] {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newRBACTestApp(checker middleware.PermissionChecker) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler(false)})
+	auth := middleware.AuthMiddleware(testSecret, nil)
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(200) }
+
+	app.Get("/admin", auth, middleware.RequirePermission(checker, "users:list"), ok)
+	app.Put("/users/:id", auth, middleware.RequireOwnerOrPermission(checker, "id", "users:manage"), ok)
+	return app
+}
+
+func doRequest(t *testing.T, app *fiber.App, method, path string, userID int64, roleID int) int {
+	token, err := utils.GenerateToken(userID, "test@example.com", roleID, "test-jti", testSecret, "1h")
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	return resp.StatusCode
+}
+
+func TestRequirePermission(t *testing.T) {
+	app := newRBACTestApp(&fakePermissionChecker{granted: map[int][]string{3: {"users:list"}}})
+
+	assert.Equal(t, 200, doRequest(t, app, "GET", "/admin", 1, 3))
+	assert.Equal(t, 403, doRequest(t, app, "GET", "/admin", 2, 1))
+}
+
+func TestRequirePermissionCheckerError(t *testing.T) {
+	app := newRBACTestApp(&fakePermissionChecker{err: errors.New("database down")})
+
+	assert.Equal(t, 500, doRequest(t, app, "GET", "/admin", 1, 3))
+}
+
+func TestRequireOwnerOrPermission(t *testing.T) {
+	app := newRBACTestApp(&fakePermissionChecker{granted: map[int][]string{3: {"users:manage"}}})
+
+	assert.Equal(t, 200, doRequest(t, app, "PUT", "/users/7", 7, 1), "owner should be allowed")
+	assert.Equal(t, 403, doRequest(t, app, "PUT", "/users/8", 7, 1), "other customer should be forbidden")
+	assert.Equal(t, 200, doRequest(t, app, "PUT", "/users/8", 1, 3), "admin should be allowed")
+}
@@ -0,0 +1,70 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/logging"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
+)
+
+func newRequestIDTestApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler(false)})
+	app.Use(middleware.RequestID())
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(logging.RequestID(c.UserContext()))
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return errors.New("boom")
+	})
+	return app
+}
+
+func TestRequestIDKeepsTheClientID(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-ID", "client-id.1")
+
+	resp, err := newRequestIDTestApp().Test(req)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "client-id.1", resp.Header.Get("X-Request-ID"))
+	body := make([]byte, 64)
+	n, _ := resp.Body.Read(body)
+	assert.Equal(t, "client-id.1", string(body[:n]))
+}
+
+func TestRequestIDReplacesMissingAndInvalidIDs(t *testing.T) {
+	app := newRequestIDTestApp()
+
+	for _, id := range []string{"", "has spaces", strings.Repeat("a", 129)} {
+		req := httptest.NewRequest("GET", "/", nil)
+		if id != "" {
+			req.Header.Set("X-Request-ID", id)
+		}
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+
+		generated := resp.Header.Get("X-Request-ID")
+		assert.Len(t, generated, 36)
+		assert.NotEqual(t, id, generated)
+	}
+}
+
+func TestRequestIDIsIncludedInErrors(t *testing.T) {
+	req := httptest.NewRequest("GET", "/fail", nil)
+	req.Header.Set("X-Request-ID", "failing-request")
+
+	resp, err := newRequestIDTestApp().Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
+
+	var body middleware.ErrorResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "failing-request", body.RequestID)
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestTimeout gives the request a context, available through
+// c.UserContext(), that is cancelled after timeout or once the request has
+// been handled. Handlers pass it on so that database calls stop when the
+// request runs out of time; they then fail with ErrRequestTimeout.
+func RequestTimeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
@@ -0,0 +1,36 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
+)
+
+func TestRequestTimeoutCancelsTheRequestContext(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler(true)})
+	app.Use(middleware.RequestTimeout(10 * time.Millisecond))
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.UserContext().Err()
+	})
+	app.Get("/fast", func(c *fiber.Ctx) error {
+		_, ok := c.UserContext().Deadline()
+		return c.JSON(fiber.Map{"deadline": ok})
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/slow", nil))
+	assert.NoError(t, err)
+	var body middleware.ErrorResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 503, resp.StatusCode)
+	assert.Equal(t, "request_timeout", body.Code)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/fast", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
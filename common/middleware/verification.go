@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+)
+
+// VerificationChecker reports whether a user has verified their email address.
+type VerificationChecker interface {
+	IsUserVerified(ctx context.Context, userID int64) (bool, error)
+}
+
+// RequireVerified rejects requests from users whose email address has not
+// been verified yet. It must run after AuthMiddleware.
+func RequireVerified(checker VerificationChecker) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := GetUserID(c)
+		if !ok {
+			return unauthorized("missing_token", "Authentication required")
+		}
+
+		verified, err := checker.IsUserVerified(c.UserContext(), userID)
+		if err != nil {
+			return apperrors.NewInternalError(err)
+		}
+		if !verified {
+			return apperrors.NewAppError(fiber.StatusForbidden, "email_not_verified", "Please verify your email address first", nil)
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,106 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var ErrInvalidCursor = apperrors.NewAppError(400, "invalid_cursor", "Invalid pagination cursor", nil)
+
+// Params describes which page a list endpoint should return. Cursor is nil
+// for the first page.
+type Params struct {
+	Limit        int
+	Cursor       *Cursor
+	IncludeTotal bool
+}
+
+// Cursor points just past the last item of the previous page. Sort names the
+// ordering the cursor was created for, Value holds that ordering's key of the
+// last item and ID breaks ties between items with the same key.
+type Cursor struct {
+	Sort  string `json:"s,omitempty"`
+	Value string `json:"v,omitempty"`
+	ID    int64  `json:"id"`
+}
+
+// Page is the response envelope shared by every list endpoint.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	Total      *int64 `json:"total,omitempty"`
+}
+
+// NewParams applies the default and maximum limit and decodes the opaque
+// cursor sent by the client.
+func NewParams(limit int, cursor string, includeTotal bool) (Params, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	params := Params{Limit: limit, IncludeTotal: includeTotal}
+	if cursor != "" {
+		decoded, err := DecodeCursor(cursor)
+		if err != nil {
+			return Params{}, err
+		}
+		params.Cursor = decoded
+	}
+	return params, nil
+}
+
+// CursorFor returns the cursor when it was created for the given sort, and
+// ErrInvalidCursor when it belongs to another ordering.
+func (p Params) CursorFor(sort string) (*Cursor, error) {
+	if p.Cursor == nil {
+		return nil, nil
+	}
+	if p.Cursor.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return p.Cursor, nil
+}
+
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// NewPage builds a page from up to Limit+1 items: the extra item only tells
+// whether another page exists and is dropped. cursorOf returns the cursor
+// pointing at an item.
+func NewPage[T any](items []T, params Params, cursorOf func(T) Cursor) Page[T] {
+	page := Page[T]{Items: items}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	if len(items) > params.Limit {
+		page.Items = items[:params.Limit]
+		page.HasMore = true
+		page.NextCursor = EncodeCursor(cursorOf(page.Items[len(page.Items)-1]))
+	}
+	return page
+}
@@ -0,0 +1,61 @@
+package pagination_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+)
+
+func TestNewParams_AppliesLimitBounds(t *testing.T) {
+	params, err := pagination.NewParams(0, "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, pagination.DefaultLimit, params.Limit)
+
+	params, err = pagination.NewParams(1000, "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, pagination.MaxLimit, params.Limit)
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	encoded := pagination.EncodeCursor(pagination.Cursor{Sort: "price_asc", Value: "15000.5", ID: 42})
+
+	params, err := pagination.NewParams(10, encoded, false)
+	assert.NoError(t, err)
+
+	cursor, err := params.CursorFor("price_asc")
+	assert.NoError(t, err)
+	assert.Equal(t, "15000.5", cursor.Value)
+	assert.Equal(t, int64(42), cursor.ID)
+
+	_, err = params.CursorFor("newest")
+	assert.True(t, errors.Is(err, pagination.ErrInvalidCursor))
+}
+
+func TestDecodeCursor_RejectsGarbage(t *testing.T) {
+	for _, value := range []string{"not base64!", "bm90IGpzb24", pagination.EncodeCursor(pagination.Cursor{})} {
+		_, err := pagination.DecodeCursor(value)
+		assert.True(t, errors.Is(err, pagination.ErrInvalidCursor), value)
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	params := pagination.Params{Limit: 2}
+	cursorOf := func(id int64) pagination.Cursor { return pagination.Cursor{ID: id} }
+
+	page := pagination.NewPage([]int64{1, 2, 3}, params, cursorOf)
+	assert.Equal(t, []int64{1, 2}, page.Items)
+	assert.True(t, page.HasMore)
+
+	next, err := pagination.DecodeCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), next.ID)
+
+	page = pagination.NewPage([]int64{1, 2}, params, cursorOf)
+	assert.False(t, page.HasMore)
+	assert.Empty(t, page.NextCursor)
+
+	empty := pagination.NewPage[int64](nil, params, cursorOf)
+	assert.NotNil(t, empty.Items)
+}
@@ -14,10 +14,12 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID int64, email string, roleID int, secret string, expiry string) (string, error) {
+// GenerateToken signs an access token. tokenID is stored as the jti claim so
+// the token can be revoked before it expires.
+func GenerateToken(userID int64, email string, roleID int, tokenID string, secret string, expiry string) (string, error) {
 	expDuration, err := time.ParseDuration(expiry)
 	if err != nil {
-		expDuration = 24 * time.Hour
+		expDuration = 15 * time.Minute
 	}
 
 	claims := Claims{
@@ -25,6 +27,7 @@ func GenerateToken(userID int64, email string, roleID int, secret string, expiry
 		Email:  email,
 		RoleID: roleID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
@@ -38,7 +41,7 @@ func GenerateToken(userID int64, email string, roleID int, secret string, expiry
 func VerifyToken(tokenString, secret string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(secret), nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, err
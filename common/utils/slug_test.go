@@ -0,0 +1,21 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
+)
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"Kaos Polos Hitam":         "kaos-polos-hitam",
+		"  Sepatu   Lari -- Pria ": "sepatu-lari-pria",
+		"Café Crème 100%":          "cafe-creme-100",
+		"!!!":                      "",
+	}
+
+	for input, expected := range cases {
+		assert.Equal(t, expected, utils.Slugify(input), input)
+	}
+}
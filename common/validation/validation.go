@@ -0,0 +1,164 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a single failed validation rule.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Errors is returned by Validate when one or more fields are invalid.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// indonesianPhonePattern accepts mobile numbers written as 08xx, 628xx or
+// +628xx, with 8 to 13 digits after the leading 8.
+var indonesianPhonePattern = regexp.MustCompile(`^(\+62|62|0)8[1-9][0-9]{6,11}$`)
+
+// Genders mirrors the gender_enum type in the users table.
+var Genders = []string{"male", "female", "other"}
+
+const isoDateLayout = "2006-01-02"
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Tag.Get("query")
+		}
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	v.RegisterValidation("id_phone", func(fl validator.FieldLevel) bool {
+		return indonesianPhonePattern.MatchString(fl.Field().String())
+	})
+
+	v.RegisterValidation("gender", func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		for _, gender := range Genders {
+			if value == gender {
+				return true
+			}
+		}
+		return false
+	})
+
+	v.RegisterValidation("iso_date", func(fl validator.FieldLevel) bool {
+		_, err := time.Parse(isoDateLayout, fl.Field().String())
+		return err == nil
+	})
+
+	return v
+}
+
+// Validate checks the `validate` struct tags of s and returns Errors listing
+// every invalid field.
+func Validate(s interface{}) error {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	result := make(Errors, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		result = append(result, FieldError{
+			Field:   fieldPath(fieldErr),
+			Rule:    fieldErr.Tag(),
+			Message: message(fieldErr),
+		})
+	}
+	return result
+}
+
+// ParseDate parses a date validated with the iso_date rule.
+func ParseDate(value string) (time.Time, error) {
+	return time.Parse(isoDateLayout, value)
+}
+
+// fieldPath returns the JSON path of the field without the struct name, e.g.
+// "items[0].quantity".
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if _, path, found := strings.Cut(namespace, "."); found {
+		return path
+	}
+	return fieldErr.Field()
+}
+
+func message(fieldErr validator.FieldError) string {
+	field := fieldPath(fieldErr)
+
+	switch fieldErr.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "url":
+		return fmt.Sprintf("%s must be a valid URL", field)
+	case "min":
+		if fieldErr.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at least %s characters", field, fieldErr.Param())
+		}
+		if fieldErr.Kind() == reflect.Slice || fieldErr.Kind() == reflect.Map {
+			return fmt.Sprintf("%s must contain at least %s items", field, fieldErr.Param())
+		}
+		return fmt.Sprintf("%s must be at least %s", field, fieldErr.Param())
+	case "max":
+		if fieldErr.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at most %s characters", field, fieldErr.Param())
+		}
+		if fieldErr.Kind() == reflect.Slice || fieldErr.Kind() == reflect.Map {
+			return fmt.Sprintf("%s must contain at most %s items", field, fieldErr.Param())
+		}
+		return fmt.Sprintf("%s must be at most %s", field, fieldErr.Param())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, fieldErr.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", field, fieldErr.Param())
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", field, fieldErr.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.ReplaceAll(fieldErr.Param(), " ", ", "))
+	case "id_phone":
+		return fmt.Sprintf("%s must be a valid Indonesian phone number", field)
+	case "gender":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.Join(Genders, ", "))
+	case "iso_date":
+		return fmt.Sprintf("%s must be a date in YYYY-MM-DD format", field)
+	default:
+		return fmt.Sprintf("%s is invalid", field)
+	}
+}
@@ -0,0 +1,72 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/validation"
+)
+
+type testRequest struct {
+	Email       string `json:"email" validate:"required,email"`
+	Password    string `json:"password" validate:"required,min=6"`
+	Phone       string `json:"phone" validate:"omitempty,id_phone"`
+	Gender      string `json:"gender" validate:"omitempty,gender"`
+	DateOfBirth string `json:"date_of_birth" validate:"omitempty,iso_date"`
+}
+
+func fieldsOf(err error) map[string]string {
+	fields := map[string]string{}
+	if errs, ok := err.(validation.Errors); ok {
+		for _, fieldErr := range errs {
+			fields[fieldErr.Field] = fieldErr.Rule
+		}
+	}
+	return fields
+}
+
+func TestValidateValidRequest(t *testing.T) {
+	err := validation.Validate(&testRequest{
+		Email:       "test@example.com",
+		Password:    "password123",
+		Phone:       "081234567890",
+		Gender:      "female",
+		DateOfBirth: "1995-08-17",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestValidateReportsFieldErrorsByJSONName(t *testing.T) {
+	err := validation.Validate(&testRequest{Password: "1"})
+
+	assert.Equal(t, map[string]string{
+		"email":    "required",
+		"password": "min",
+	}, fieldsOf(err))
+}
+
+func TestValidateIndonesianPhone(t *testing.T) {
+	for _, phone := range []string{"081234567890", "6281234567890", "+6281234567890"} {
+		assert.NoError(t, validation.Validate(&testRequest{Email: "a@b.co", Password: "secret", Phone: phone}), phone)
+	}
+
+	for _, phone := range []string{"12345", "0212345678", "+15551234567", "08123"} {
+		err := validation.Validate(&testRequest{Email: "a@b.co", Password: "secret", Phone: phone})
+		assert.Equal(t, "id_phone", fieldsOf(err)["phone"], phone)
+	}
+}
+
+func TestValidateGenderAndDate(t *testing.T) {
+	err := validation.Validate(&testRequest{
+		Email:       "a@b.co",
+		Password:    "secret",
+		Gender:      "unknown",
+		DateOfBirth: "17-08-1995",
+	})
+
+	assert.Equal(t, map[string]string{
+		"gender":        "gender",
+		"date_of_birth": "iso_date",
+	}, fieldsOf(err))
+}
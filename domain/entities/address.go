@@ -0,0 +1,59 @@
+package entities
+
+import "time"
+
+// Address is an entry of a user's address book.
+type Address struct {
+	ID            int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID        int64     `json:"user_id" gorm:"not null;index"`
+	Label         string    `json:"label" gorm:"not null;size:50"`
+	RecipientName string    `json:"recipient_name" gorm:"not null;size:255"`
+	Phone         string    `json:"phone" gorm:"not null;size:20"`
+	Street        string    `json:"street" gorm:"not null;size:500"`
+	ProvinceCode  string    `json:"province_code" gorm:"not null;size:2"`
+	CityCode      string    `json:"city_code" gorm:"not null;size:5"`
+	DistrictCode  string    `json:"district_code" gorm:"not null;size:8"`
+	PostalCode    string    `json:"postal_code" gorm:"not null;size:5"`
+	IsDefault     bool      `json:"is_default" gorm:"not null;default:false"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Province *Province `json:"province,omitempty" gorm:"foreignKey:ProvinceCode"`
+	City     *City     `json:"city,omitempty" gorm:"foreignKey:CityCode"`
+	District *District `json:"district,omitempty" gorm:"foreignKey:DistrictCode"`
+}
+
+// ShippingAddress is a snapshot of an address stored on an order, so later
+// changes to the address book do not alter past orders.
+type ShippingAddress struct {
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone"`
+	Street        string `json:"street"`
+	DistrictCode  string `json:"district_code"`
+	District      string `json:"district"`
+	City          string `json:"city"`
+	Province      string `json:"province"`
+	PostalCode    string `json:"postal_code"`
+}
+
+// Snapshot copies the address for an order. The regions of the address must
+// be loaded.
+func (a *Address) Snapshot() *ShippingAddress {
+	snapshot := &ShippingAddress{
+		RecipientName: a.RecipientName,
+		Phone:         a.Phone,
+		Street:        a.Street,
+		DistrictCode:  a.DistrictCode,
+		PostalCode:    a.PostalCode,
+	}
+	if a.District != nil {
+		snapshot.District = a.District.Name
+	}
+	if a.City != nil {
+		snapshot.City = a.City.Name
+	}
+	if a.Province != nil {
+		snapshot.Province = a.Province.Name
+	}
+	return snapshot
+}
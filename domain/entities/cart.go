@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+)
+
+const (
+	CartIssueUnavailable       = "unavailable"
+	CartIssueInsufficientStock = "insufficient_stock"
+)
+
+// Cart belongs either to a user or, for guests, to an anonymous cart token
+// of which only the hash is stored.
+type Cart struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    *int64     `json:"user_id,omitempty" gorm:"uniqueIndex"`
+	TokenHash *string    `json:"-" gorm:"uniqueIndex;size:64"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" gorm:"type:timestamp"`
+	CouponID  *int64     `json:"-"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Items  []CartItem `json:"items" gorm:"foreignKey:CartID"`
+	Coupon *Coupon    `json:"coupon,omitempty" gorm:"foreignKey:CouponID"`
+
+	// Token is the raw guest cart token; it is only set on the response that
+	// created the guest cart.
+	Token string `json:"cart_token,omitempty" gorm:"-"`
+
+	// Totals are calculated by Recalculate and never stored.
+	ItemCount      int     `json:"item_count" gorm:"-"`
+	Subtotal       float64 `json:"subtotal" gorm:"-"`
+	DiscountTotal  float64 `json:"discount_total" gorm:"-"` // product and coupon discounts
+	CouponDiscount float64 `json:"coupon_discount" gorm:"-"`
+	Total          float64 `json:"total" gorm:"-"`
+	Weight         float64 `json:"weight" gorm:"-"` // kg, for shipping
+	HasIssues      bool    `json:"has_issues" gorm:"-"`
+
+	// CouponIssue is the error type explaining why the applied coupon takes
+	// nothing off, e.g. coupon_min_spend_not_met.
+	CouponIssue string `json:"coupon_issue,omitempty" gorm:"-"`
+}
+
+type CartItem struct {
+	ID        int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	CartID    int64     `json:"-" gorm:"not null;index"`
+	ProductID int64     `json:"product_id" gorm:"not null"`
+	Quantity  int       `json:"quantity" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Product *Product `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+
+	UnitPrice float64 `json:"unit_price" gorm:"-"`
+	LineTotal float64 `json:"line_total" gorm:"-"`
+	Issue     string  `json:"issue,omitempty" gorm:"-"`
+}
+
+// Recalculate prices every item from its current product and refreshes the
+// cart totals. Items whose product is no longer for sale are flagged and left
+// out of the totals; items exceeding the stock, including sold out products,
+// are flagged but still priced. The applied coupon, if any, is taken off the
+// total.
+func (c *Cart) Recalculate() {
+	c.ItemCount, c.Subtotal, c.DiscountTotal, c.Total, c.Weight, c.HasIssues = 0, 0, 0, 0, 0, false
+	c.CouponDiscount, c.CouponIssue = 0, ""
+
+	for i := range c.Items {
+		item := &c.Items[i]
+		item.Issue, item.UnitPrice, item.LineTotal = "", 0, 0
+
+		if item.Product == nil || !(item.Product.IsVisible() || item.Product.IsSoldOut()) {
+			item.Issue = CartIssueUnavailable
+			c.HasIssues = true
+			continue
+		}
+		if item.Quantity > item.Product.StockQuantity {
+			item.Issue = CartIssueInsufficientStock
+			c.HasIssues = true
+		}
+
+		item.UnitPrice = item.Product.EffectivePrice()
+		item.LineTotal = roundMoney(item.UnitPrice * float64(item.Quantity))
+
+		c.ItemCount += item.Quantity
+		c.Subtotal += item.Product.Price * float64(item.Quantity)
+		c.Total += item.LineTotal
+		c.Weight += item.Product.ShippingWeight() * float64(item.Quantity)
+	}
+
+	c.Subtotal = roundMoney(c.Subtotal)
+	c.Total = roundMoney(c.Total)
+	c.Weight = roundMoney(c.Weight)
+
+	if c.Coupon != nil {
+		discount, err := c.Coupon.ItemDiscount(c.Items, time.Now())
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			c.CouponIssue = appErr.Type
+		}
+		c.CouponDiscount = discount
+		c.Total = roundMoney(c.Total - discount)
+	}
+	c.DiscountTotal = roundMoney(c.Subtotal - c.Total)
+}
+
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
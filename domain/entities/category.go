@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Category struct {
+	ID          int            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string         `json:"name" gorm:"not null;size:100"`
+	Slug        string         `json:"slug" gorm:"uniqueIndex;not null;size:100"`
+	Description string         `json:"description,omitempty" gorm:"type:text"`
+	ImageURL    string         `json:"image_url,omitempty" gorm:"size:500"`
+	IsActive    bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitzero" gorm:"index"`
+
+	// ProductCount is only filled in by listings and counts published products.
+	ProductCount int64 `json:"product_count" gorm:"->;-:migration"`
+}
+
+type Tag struct {
+	ID        int            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string         `json:"name" gorm:"not null;size:50"`
+	Slug      string         `json:"slug" gorm:"uniqueIndex;not null;size:50"`
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitzero" gorm:"index"`
+}
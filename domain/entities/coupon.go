@@ -0,0 +1,156 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+)
+
+const (
+	CouponTypePercentage   = "percentage"
+	CouponTypeFixed        = "fixed"
+	CouponTypeFreeShipping = "free_shipping"
+)
+
+var (
+	ErrCouponUnavailable   = apperrors.NewAppError(422, "coupon_unavailable", "Coupon is not valid at this time", nil)
+	ErrCouponUsedUp        = apperrors.NewAppError(422, "coupon_used_up", "Coupon has reached its usage limit", nil)
+	ErrCouponUserLimit     = apperrors.NewAppError(422, "coupon_user_limit_reached", "You have already used this coupon the maximum number of times", nil)
+	ErrCouponNotApplicable = apperrors.NewAppError(422, "coupon_not_applicable", "Coupon does not apply to any item in the cart", nil)
+	ErrCouponMinSpend      = apperrors.NewAppError(422, "coupon_min_spend_not_met", "Cart does not reach the minimum spend of the coupon", nil)
+)
+
+// Coupon takes a percentage or a fixed amount off the items it applies to,
+// or pays for shipping. Without scopes it applies to every item; otherwise
+// to items matching any of ProductIDs, CategoryIDs, TagIDs or SellerIDs.
+type Coupon struct {
+	ID           int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code         string     `json:"code" gorm:"uniqueIndex;not null;size:50"`
+	Description  string     `json:"description,omitempty" gorm:"size:255"`
+	Type         string     `json:"type" gorm:"not null;size:20"`
+	Value        float64    `json:"value" gorm:"type:decimal(15,2);not null"`         // percent or amount
+	MaxDiscount  *float64   `json:"max_discount,omitempty" gorm:"type:decimal(15,2)"` // caps percentage and free shipping discounts
+	MinSpend     float64    `json:"min_spend" gorm:"type:decimal(15,2);not null"`     // of the items the coupon applies to
+	UsageLimit   *int       `json:"usage_limit,omitempty"`                            // orders in total
+	PerUserLimit *int       `json:"per_user_limit,omitempty"`                         // orders per customer
+	UsedCount    int        `json:"used_count" gorm:"->"`                             // maintained by orders, never written on save
+	StartsAt     *time.Time `json:"starts_at,omitempty" gorm:"type:timestamp"`
+	EndsAt       *time.Time `json:"ends_at,omitempty" gorm:"type:timestamp"`
+	IsActive     bool       `json:"is_active" gorm:"not null"`
+	ProductIDs   []int64    `json:"product_ids,omitempty" gorm:"serializer:json;type:jsonb"`
+	CategoryIDs  []int      `json:"category_ids,omitempty" gorm:"serializer:json;type:jsonb"`
+	TagIDs       []int      `json:"tag_ids,omitempty" gorm:"serializer:json;type:jsonb"`
+	SellerIDs    []int64    `json:"seller_ids,omitempty" gorm:"serializer:json;type:jsonb"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// CouponRedemption records the use of a coupon by an order. It is removed
+// again when the order is cancelled.
+type CouponRedemption struct {
+	ID        int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	CouponID  int64     `json:"coupon_id" gorm:"not null;index"`
+	UserID    int64     `json:"user_id" gorm:"not null"`
+	OrderID   int64     `json:"order_id" gorm:"uniqueIndex;not null"`
+	Discount  float64   `json:"discount" gorm:"type:decimal(15,2);not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// CheckUsable reports why the coupon cannot be used at now by a customer
+// who already used it userRedemptions times, or nil when it can.
+func (c *Coupon) CheckUsable(now time.Time, userRedemptions int) error {
+	if !c.IsActive || (c.StartsAt != nil && now.Before(*c.StartsAt)) || (c.EndsAt != nil && !now.Before(*c.EndsAt)) {
+		return ErrCouponUnavailable
+	}
+	if c.UsageLimit != nil && c.UsedCount >= *c.UsageLimit {
+		return ErrCouponUsedUp
+	}
+	if c.PerUserLimit != nil && userRedemptions >= *c.PerUserLimit {
+		return ErrCouponUserLimit
+	}
+	return nil
+}
+
+// AppliesTo reports whether the coupon covers product.
+func (c *Coupon) AppliesTo(product *Product) bool {
+	if len(c.ProductIDs) == 0 && len(c.CategoryIDs) == 0 && len(c.TagIDs) == 0 && len(c.SellerIDs) == 0 {
+		return true
+	}
+	for _, id := range c.ProductIDs {
+		if id == product.ID {
+			return true
+		}
+	}
+	for _, id := range c.SellerIDs {
+		if id == product.UserID {
+			return true
+		}
+	}
+	for _, category := range product.Categories {
+		for _, id := range c.CategoryIDs {
+			if id == category.ID {
+				return true
+			}
+		}
+	}
+	for _, tag := range product.Tags {
+		for _, id := range c.TagIDs {
+			if id == tag.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ItemDiscount works out what the coupon takes off the priced cart items
+// at now. Free shipping coupons take nothing off the items. It fails when the
+// coupon cannot be used, covers none of the items or the covered items do
+// not reach the minimum spend; per customer limits are not checked.
+func (c *Coupon) ItemDiscount(items []CartItem, now time.Time) (float64, error) {
+	if err := c.CheckUsable(now, 0); err != nil {
+		return 0, err
+	}
+
+	eligible := 0.0
+	for _, item := range items {
+		if item.Product != nil && item.LineTotal > 0 && c.AppliesTo(item.Product) {
+			eligible += item.LineTotal
+		}
+	}
+	eligible = roundMoney(eligible)
+	if eligible == 0 {
+		return 0, ErrCouponNotApplicable
+	}
+	if eligible < c.MinSpend {
+		return 0, ErrCouponMinSpend.WithDetails(map[string]interface{}{
+			"min_spend": c.MinSpend,
+			"eligible":  eligible,
+		})
+	}
+
+	switch c.Type {
+	case CouponTypePercentage:
+		return c.capped(roundMoney(eligible * c.Value / 100)), nil
+	case CouponTypeFixed:
+		return math.Min(c.Value, eligible), nil
+	default:
+		return 0, nil
+	}
+}
+
+// ShippingDiscount is what a free shipping coupon takes off shippingCost.
+func (c *Coupon) ShippingDiscount(shippingCost float64) float64 {
+	if c.Type != CouponTypeFreeShipping {
+		return 0
+	}
+	return c.capped(shippingCost)
+}
+
+func (c *Coupon) capped(discount float64) float64 {
+	if c.MaxDiscount != nil && discount > *c.MaxDiscount {
+		return *c.MaxDiscount
+	}
+	return discount
+}
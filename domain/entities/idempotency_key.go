@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// IdempotencyKey records a request sent with an Idempotency-Key header and
+// the response it got, so that retries of the request get the same response
+// instead of running it again. Scope is who sent the request, so keys of
+// different users never clash.
+type IdempotencyKey struct {
+	ID              int64             `json:"id" gorm:"primaryKey;autoIncrement"`
+	Scope           string            `json:"scope" gorm:"not null;size:50"`
+	Key             string            `json:"key" gorm:"not null;size:255"`
+	Method          string            `json:"method" gorm:"not null;size:10"`
+	Path            string            `json:"path" gorm:"not null"`
+	RequestHash     string            `json:"request_hash" gorm:"not null;size:64"`
+	StatusCode      int               `json:"status_code" gorm:"not null;default:0"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty" gorm:"serializer:json;type:jsonb"`
+	ResponseBody    []byte            `json:"-"`
+	ExpiresAt       time.Time         `json:"expires_at" gorm:"not null"`
+	CompletedAt     *time.Time        `json:"completed_at,omitempty"`
+	CreatedAt       time.Time         `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// IsCompleted reports whether the response of the request was saved. Keys
+// that are not completed belong to requests still running.
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.CompletedAt != nil
+}
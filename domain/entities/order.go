@@ -0,0 +1,193 @@
+package entities
+
+import (
+	"time"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+)
+
+const (
+	OrderStatusPendingPayment = "pending_payment"
+	OrderStatusPaid           = "paid"
+	OrderStatusProcessing     = "processing"
+	OrderStatusShipped        = "shipped"
+	OrderStatusDelivered      = "delivered"
+	OrderStatusCancelled      = "cancelled"
+	OrderStatusRefunded       = "refunded"
+)
+
+var ErrInvalidOrderTransition = apperrors.NewAppError(409, "invalid_status_transition", "Order cannot move to the requested status", nil)
+
+// orderTransitions is the order state machine: the statuses each status can
+// move to. Cancelled and refunded orders are final.
+var orderTransitions = map[string][]string{
+	OrderStatusPendingPayment: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:           {OrderStatusProcessing, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusProcessing:     {OrderStatusShipped, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusShipped:        {OrderStatusDelivered},
+	OrderStatusDelivered:      {OrderStatusRefunded},
+}
+
+type Order struct {
+	ID               int64            `json:"id" gorm:"primaryKey;autoIncrement"`
+	OrderNumber      string           `json:"order_number" gorm:"uniqueIndex;not null;size:32"`
+	UserID           int64            `json:"user_id" gorm:"not null;index"`
+	Status           string           `json:"status" gorm:"type:order_status_enum;default:pending_payment"`
+	ItemCount        int              `json:"item_count" gorm:"not null"`
+	Subtotal         float64          `json:"subtotal" gorm:"type:decimal(15,2);not null"`
+	DiscountTotal    float64          `json:"discount_total" gorm:"type:decimal(15,2);not null"`
+	CouponDiscount   float64          `json:"coupon_discount" gorm:"type:decimal(15,2);not null"` // part of discount_total
+	ShippingCost     float64          `json:"shipping_cost" gorm:"type:decimal(15,2);not null"`
+	ShippingDiscount float64          `json:"shipping_discount" gorm:"type:decimal(15,2);not null"`
+	Total            float64          `json:"total" gorm:"type:decimal(15,2);not null"`
+	Notes            string           `json:"notes,omitempty" gorm:"size:500"`
+	ShippingAddress  *ShippingAddress `json:"shipping_address,omitempty" gorm:"serializer:json;type:jsonb"`
+	ShippingCourier  string           `json:"shipping_courier,omitempty" gorm:"size:50"`
+	ShippingService  string           `json:"shipping_service,omitempty" gorm:"size:50"`
+	ShippingWeight   float64          `json:"shipping_weight" gorm:"type:decimal(10,2);not null"` // kg
+	CouponID         *int64           `json:"coupon_id,omitempty"`
+	CouponCode       string           `json:"coupon_code,omitempty" gorm:"size:50"`
+	PaymentDueAt     *time.Time       `json:"payment_due_at,omitempty"` // stock stays reserved until then
+	CreatedAt        time.Time        `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time        `json:"updated_at" gorm:"autoUpdateTime"`
+
+	User    *User                `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Items   []OrderItem          `json:"items,omitempty" gorm:"foreignKey:OrderID"`
+	History []OrderStatusHistory `json:"history,omitempty" gorm:"foreignKey:OrderID"`
+}
+
+// OrderItem is a snapshot of a product at the time of purchase, so later
+// changes to the product do not alter past orders.
+type OrderItem struct {
+	ID            int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	OrderID       int64     `json:"-" gorm:"not null;index"`
+	ProductID     *int64    `json:"product_id" gorm:"index"`
+	SellerID      int64     `json:"seller_id" gorm:"not null;index"`
+	ProductName   string    `json:"product_name" gorm:"not null;size:255"`
+	ProductSKU    string    `json:"product_sku" gorm:"column:product_sku;not null;size:100"`
+	UnitPrice     float64   `json:"unit_price" gorm:"type:decimal(15,2);not null"`
+	OriginalPrice float64   `json:"original_price" gorm:"type:decimal(15,2);not null"`
+	Quantity      int       `json:"quantity" gorm:"not null"`
+	LineTotal     float64   `json:"line_total" gorm:"type:decimal(15,2);not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// OrderStatusHistory records every status change of an order. FromStatus is
+// nil for the entry written when the order is placed.
+type OrderStatusHistory struct {
+	ID         int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	OrderID    int64     `json:"-" gorm:"not null;index"`
+	FromStatus *string   `json:"from_status" gorm:"type:order_status_enum"`
+	ToStatus   string    `json:"to_status" gorm:"type:order_status_enum;not null"`
+	ChangedBy  *int64    `json:"changed_by,omitempty"`
+	Note       string    `json:"note,omitempty" gorm:"size:500"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (OrderStatusHistory) TableName() string {
+	return "order_status_history"
+}
+
+// NewOrderItem snapshots product for an order line of quantity units.
+func NewOrderItem(product *Product, quantity int) OrderItem {
+	productID := product.ID
+	unitPrice := product.EffectivePrice()
+	return OrderItem{
+		ProductID:     &productID,
+		SellerID:      product.UserID,
+		ProductName:   product.Name,
+		ProductSKU:    product.SKU,
+		UnitPrice:     unitPrice,
+		OriginalPrice: product.Price,
+		Quantity:      quantity,
+		LineTotal:     roundMoney(unitPrice * float64(quantity)),
+	}
+}
+
+// CalculateTotals sums up the order items, takes off the coupon discount and
+// adds the shipping cost less the shipping discount to the total.
+func (o *Order) CalculateTotals() {
+	o.ItemCount, o.Subtotal, o.Total = 0, 0, 0
+	for _, item := range o.Items {
+		o.ItemCount += item.Quantity
+		o.Subtotal += item.OriginalPrice * float64(item.Quantity)
+		o.Total += item.LineTotal
+	}
+	o.Subtotal = roundMoney(o.Subtotal)
+	o.Total = roundMoney(o.Total - o.CouponDiscount)
+	o.DiscountTotal = roundMoney(o.Subtotal - o.Total)
+	o.Total = roundMoney(o.Total + o.ShippingCost - o.ShippingDiscount)
+}
+
+// CanTransitionTo reports whether the state machine allows moving the order
+// to status.
+func (o *Order) CanTransitionTo(status string) bool {
+	for _, next := range orderTransitions[o.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the order to status and returns the history entry to
+// record, or ErrInvalidOrderTransition when the move is not allowed.
+func (o *Order) TransitionTo(status string, changedBy *int64, note string) (*OrderStatusHistory, error) {
+	if !o.CanTransitionTo(status) {
+		return nil, ErrInvalidOrderTransition.WithDetails(map[string]interface{}{
+			"from":    o.Status,
+			"to":      status,
+			"allowed": o.NextStatuses(),
+		})
+	}
+
+	from := o.Status
+	o.Status = status
+	return &OrderStatusHistory{
+		OrderID:    o.ID,
+		FromStatus: &from,
+		ToStatus:   status,
+		ChangedBy:  changedBy,
+		Note:       note,
+	}, nil
+}
+
+// NextStatuses lists the statuses the order can move to.
+func (o *Order) NextStatuses() []string {
+	next := orderTransitions[o.Status]
+	if next == nil {
+		return []string{}
+	}
+	return next
+}
+
+// HasSeller reports whether the order contains products of the seller.
+func (o *Order) HasSeller(sellerID int64) bool {
+	for _, item := range o.Items {
+		if item.SellerID == sellerID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasOnlySeller reports whether every product of the order belongs to the
+// seller.
+func (o *Order) HasOnlySeller(sellerID int64) bool {
+	for _, item := range o.Items {
+		if item.SellerID != sellerID {
+			return false
+		}
+	}
+	return len(o.Items) > 0
+}
+
+// IsValidOrderStatus reports whether status is one of order_status_enum.
+func IsValidOrderStatus(status string) bool {
+	switch status {
+	case OrderStatusPendingPayment, OrderStatusPaid, OrderStatusProcessing, OrderStatusShipped,
+		OrderStatusDelivered, OrderStatusCancelled, OrderStatusRefunded:
+		return true
+	}
+	return false
+}
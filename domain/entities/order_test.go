@@ -0,0 +1,138 @@
+package entities_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+func TestOrder_TransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{entities.OrderStatusPendingPayment, entities.OrderStatusPaid, true},
+		{entities.OrderStatusPendingPayment, entities.OrderStatusCancelled, true},
+		{entities.OrderStatusPendingPayment, entities.OrderStatusShipped, false},
+		{entities.OrderStatusPaid, entities.OrderStatusProcessing, true},
+		{entities.OrderStatusPaid, entities.OrderStatusRefunded, true},
+		{entities.OrderStatusProcessing, entities.OrderStatusShipped, true},
+		{entities.OrderStatusShipped, entities.OrderStatusDelivered, true},
+		{entities.OrderStatusShipped, entities.OrderStatusCancelled, false},
+		{entities.OrderStatusDelivered, entities.OrderStatusRefunded, true},
+		{entities.OrderStatusCancelled, entities.OrderStatusPaid, false},
+		{entities.OrderStatusRefunded, entities.OrderStatusPaid, false},
+		{entities.OrderStatusPaid, entities.OrderStatusPaid, false},
+	}
+
+	changedBy := int64(7)
+	for _, tt := range tests {
+		order := &entities.Order{ID: 1, Status: tt.from}
+		entry, err := order.TransitionTo(tt.to, &changedBy, "")
+
+		if !tt.allowed {
+			assert.True(t, errors.Is(err, entities.ErrInvalidOrderTransition), "%s -> %s", tt.from, tt.to)
+			assert.Equal(t, tt.from, order.Status)
+			continue
+		}
+		assert.NoError(t, err, "%s -> %s", tt.from, tt.to)
+		assert.Equal(t, tt.to, order.Status)
+		assert.Equal(t, tt.from, *entry.FromStatus)
+		assert.Equal(t, tt.to, entry.ToStatus)
+		assert.Equal(t, int64(1), entry.OrderID)
+	}
+}
+
+func TestOrder_CalculateTotals(t *testing.T) {
+	discount := 9999.99
+	order := &entities.Order{Items: []entities.OrderItem{
+		entities.NewOrderItem(&entities.Product{ID: 1, Price: 12500.50}, 3),
+		entities.NewOrderItem(&entities.Product{ID: 2, Price: 15000, DiscountPrice: &discount}, 2),
+	}}
+
+	order.CalculateTotals()
+
+	assert.Equal(t, 5, order.ItemCount)
+	assert.Equal(t, 67501.5, order.Subtotal)
+	assert.Equal(t, 57501.48, order.Total)
+	assert.Equal(t, 10000.02, order.DiscountTotal)
+
+	order.ShippingCost = 18000
+	order.CalculateTotals()
+	assert.Equal(t, 75501.48, order.Total)
+	assert.Equal(t, 10000.02, order.DiscountTotal)
+}
+
+func TestShippingRate_Specificity(t *testing.T) {
+	anywhere := &entities.ShippingRate{OriginCode: "*", DestinationCode: "*"}
+	province := &entities.ShippingRate{OriginCode: "*", DestinationCode: "31"}
+	city := &entities.ShippingRate{OriginCode: "*", DestinationCode: "31.74"}
+	cityFromCity := &entities.ShippingRate{OriginCode: "31.71", DestinationCode: "31.74"}
+
+	assert.Less(t, anywhere.Specificity(), province.Specificity())
+	assert.Less(t, province.Specificity(), city.Specificity())
+	assert.Less(t, city.Specificity(), cityFromCity.Specificity())
+	assert.Equal(t, []string{"31.74", "31", "*"}, entities.ShippingRegionCodes("31.74"))
+}
+
+func TestProduct_SyncStockStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		stock  int
+		want   string
+	}{
+		{entities.ProductStatusPublished, 0, entities.ProductStatusOutOfStock},
+		{entities.ProductStatusPublished, 3, entities.ProductStatusPublished},
+		{entities.ProductStatusOutOfStock, 3, entities.ProductStatusPublished},
+		{entities.ProductStatusOutOfStock, 0, entities.ProductStatusOutOfStock},
+		{entities.ProductStatusDraft, 0, entities.ProductStatusDraft},
+		{entities.ProductStatusArchived, 3, entities.ProductStatusArchived},
+	}
+
+	for _, tt := range tests {
+		product := &entities.Product{Status: tt.status, StockQuantity: tt.stock}
+		product.SyncStockStatus()
+		assert.Equal(t, tt.want, product.Status, "%s with stock %d", tt.status, tt.stock)
+	}
+}
+
+func TestCoupon_ItemDiscountCoversScopedItems(t *testing.T) {
+	items := []entities.CartItem{
+		{LineTotal: 60000, Product: &entities.Product{ID: 1, Categories: []entities.Category{{ID: 3}}}},
+		{LineTotal: 40000, Product: &entities.Product{ID: 2, Tags: []entities.Tag{{ID: 9}}}},
+		{LineTotal: 30000, Product: &entities.Product{ID: 4}},
+	}
+	maxDiscount := 15000.0
+	now := time.Now()
+
+	coupon := &entities.Coupon{Type: entities.CouponTypePercentage, Value: 20, CategoryIDs: []int{3}, TagIDs: []int{9}, IsActive: true}
+	discount, err := coupon.ItemDiscount(items, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 20000.0, discount)
+
+	coupon.MaxDiscount = &maxDiscount
+	discount, err = coupon.ItemDiscount(items, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 15000.0, discount)
+
+	coupon.MinSpend = 120000
+	_, err = coupon.ItemDiscount(items, now)
+	assert.True(t, errors.Is(err, entities.ErrCouponMinSpend))
+
+	fixed := &entities.Coupon{Type: entities.CouponTypeFixed, Value: 50000, ProductIDs: []int64{4}, IsActive: true}
+	discount, err = fixed.ItemDiscount(items, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 30000.0, discount)
+
+	fixed.ProductIDs = []int64{5}
+	_, err = fixed.ItemDiscount(items, now)
+	assert.True(t, errors.Is(err, entities.ErrCouponNotApplicable))
+
+	ended := now.Add(-time.Hour)
+	fixed.ProductIDs, fixed.EndsAt = nil, &ended
+	_, err = fixed.ItemDiscount(items, now)
+	assert.True(t, errors.Is(err, entities.ErrCouponUnavailable))
+}
@@ -0,0 +1,87 @@
+package entities
+
+import "time"
+
+const (
+	PaymentStatusPending = "pending"
+	PaymentStatusPaid    = "paid"
+	// PaymentStatusRefunding marks a refund requested, or about to be
+	// requested, at the gateway under the payment's RefundReference.
+	PaymentStatusRefunding = "refunding"
+	PaymentStatusFailed    = "failed"
+	PaymentStatusExpired   = "expired"
+	PaymentStatusRefunded  = "refunded"
+)
+
+// paymentTransitions lists the statuses each payment status can move to.
+// Failed and expired charges may still be reported as paid by the gateway;
+// the money is then refunded if the order can no longer be paid.
+var paymentTransitions = map[string][]string{
+	PaymentStatusPending:   {PaymentStatusPaid, PaymentStatusFailed, PaymentStatusExpired},
+	PaymentStatusFailed:    {PaymentStatusPaid},
+	PaymentStatusExpired:   {PaymentStatusPaid},
+	PaymentStatusPaid:      {PaymentStatusRefunding, PaymentStatusRefunded},
+	PaymentStatusRefunding: {PaymentStatusRefunded},
+}
+
+// Payment is a charge created at a payment gateway for an order. An order
+// has at most one pending payment at a time.
+type Payment struct {
+	ID         int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	OrderID    int64      `json:"order_id" gorm:"not null;index"`
+	Gateway    string     `json:"gateway" gorm:"not null;size:50"`
+	Reference  string     `json:"reference" gorm:"not null;size:100"`
+	Status     string     `json:"status" gorm:"type:payment_status_enum;default:pending"`
+	Amount     float64    `json:"amount" gorm:"type:decimal(15,2);not null"`
+	Currency   string     `json:"currency" gorm:"not null;size:3"`
+	PaymentURL string     `json:"payment_url,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	PaidAt     *time.Time `json:"paid_at,omitempty"`
+	RefundedAt *time.Time `json:"refunded_at,omitempty"`
+	// RefundReference identifies the refund at the gateway, so that a
+	// retried refund is not paid out twice.
+	RefundReference *string   `json:"refund_reference,omitempty" gorm:"size:100"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// PaymentNotification is a webhook call received from a gateway, kept to
+// recognise duplicates and for auditing.
+type PaymentNotification struct {
+	ID        int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Gateway   string    `json:"gateway" gorm:"not null;size:50"`
+	EventID   string    `json:"event_id" gorm:"not null;size:150"`
+	PaymentID *int64    `json:"payment_id,omitempty"`
+	Status    string    `json:"status" gorm:"not null;size:20"`
+	Payload   string    `json:"payload" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// CanTransitionTo reports whether the payment can move to status.
+func (p *Payment) CanTransitionTo(status string) bool {
+	for _, next := range paymentTransitions[p.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the payment to status, stamping when it was paid or
+// refunded, and returns the previous status. It reports false when the
+// payment cannot move to status.
+func (p *Payment) TransitionTo(status string, at time.Time) (string, bool) {
+	if !p.CanTransitionTo(status) {
+		return p.Status, false
+	}
+
+	from := p.Status
+	p.Status = status
+	switch status {
+	case PaymentStatusPaid:
+		p.PaidAt = &at
+	case PaymentStatusRefunded:
+		p.RefundedAt = &at
+	}
+	return from, true
+}
@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+const (
+	PermissionUsersList        = "users:list"
+	PermissionUsersManage      = "users:manage"
+	PermissionRolesManage      = "roles:manage"
+	PermissionProductsWrite    = "products:write"
+	PermissionProductsManage   = "products:manage"
+	PermissionCategoriesManage = "categories:manage"
+	PermissionOrdersCreate     = "orders:create"
+	PermissionOrdersManage     = "orders:manage"
+	PermissionShippingManage   = "shipping:manage"
+	PermissionCouponsManage    = "coupons:manage"
+)
+
+type Permission struct {
+	ID          int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"uniqueIndex;not null;size:100"`
+	Description string    `json:"description,omitempty" gorm:"size:255"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
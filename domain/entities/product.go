@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"time"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	ProductStatusDraft      = "draft"
+	ProductStatusPublished  = "published"
+	ProductStatusArchived   = "archived"
+	ProductStatusOutOfStock = "out_of_stock"
+)
+
+var (
+	ErrProductUnavailable = apperrors.NewAppError(409, "product_unavailable", "Product is not available for purchase", nil)
+	ErrInsufficientStock  = apperrors.NewAppError(409, "insufficient_stock", "Not enough stock for the requested quantity", nil)
+)
+
+type Product struct {
+	ID               int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID           int64          `json:"user_id" gorm:"not null;index"`
+	Name             string         `json:"name" gorm:"not null;size:255"`
+	Slug             string         `json:"slug" gorm:"uniqueIndex;not null;size:255"`
+	SKU              string         `json:"sku" gorm:"column:sku;uniqueIndex;not null;size:100"`
+	Description      string         `json:"description,omitempty" gorm:"type:text"`
+	ShortDescription string         `json:"short_description,omitempty" gorm:"size:500"`
+	Price            float64        `json:"price" gorm:"type:decimal(15,2);not null"`
+	DiscountPrice    *float64       `json:"discount_price,omitempty" gorm:"type:decimal(15,2)"`
+	StockQuantity    int            `json:"stock_quantity" gorm:"default:0"`
+	Weight           *float64       `json:"weight,omitempty" gorm:"type:decimal(10,2)"`
+	IsActive         bool           `json:"is_active" gorm:"default:true"`
+	IsFeatured       bool           `json:"is_featured" gorm:"default:false"`
+	Status           string         `json:"status" gorm:"type:product_status_enum;default:draft"`
+	SoldCount        int            `json:"sold_count" gorm:"->"` // maintained by orders, never written on save
+	CreatedAt        time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at,omitzero" gorm:"index"`
+
+	// Only filled in by search results.
+	SearchRank float64 `json:"search_rank,omitempty" gorm:"->;-:migration"`
+	Highlight  string  `json:"highlight,omitempty" gorm:"->;-:migration"`
+	Snippet    string  `json:"snippet,omitempty" gorm:"->;-:migration"`
+
+	User       *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Categories []Category `json:"categories,omitempty" gorm:"many2many:product_categories"`
+	Tags       []Tag      `json:"tags,omitempty" gorm:"many2many:product_tags"`
+}
+
+// IsVisible reports whether the product can be shown to shoppers.
+func (p *Product) IsVisible() bool {
+	return p.IsActive && p.Status == ProductStatusPublished
+}
+
+// IsSoldOut reports whether the product is for sale but has run out of stock.
+func (p *Product) IsSoldOut() bool {
+	return p.IsActive && p.Status == ProductStatusOutOfStock
+}
+
+// SyncStockStatus moves published products without stock to out_of_stock and
+// sold out products back to published once they are restocked.
+func (p *Product) SyncStockStatus() {
+	switch {
+	case p.Status == ProductStatusPublished && p.StockQuantity <= 0:
+		p.Status = ProductStatusOutOfStock
+	case p.Status == ProductStatusOutOfStock && p.StockQuantity > 0:
+		p.Status = ProductStatusPublished
+	}
+}
+
+// EffectivePrice is the price a customer pays, taking the discount into account.
+func (p *Product) EffectivePrice() float64 {
+	if p.DiscountPrice != nil {
+		return *p.DiscountPrice
+	}
+	return p.Price
+}
+
+// DefaultProductWeight is the shipping weight in kg of products without a
+// weight.
+const DefaultProductWeight = 1.0
+
+// ShippingWeight is the weight of one unit in kg, as used for shipping.
+func (p *Product) ShippingWeight() float64 {
+	if p.Weight != nil && *p.Weight > 0 {
+		return *p.Weight
+	}
+	return DefaultProductWeight
+}
+
+// IsValidProductStatus reports whether status is one of product_status_enum.
+func IsValidProductStatus(status string) bool {
+	switch status {
+	case ProductStatusDraft, ProductStatusPublished, ProductStatusArchived, ProductStatusOutOfStock:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package entities
+
+// Province, City and District are the Indonesian administrative regions
+// addresses are validated against. Cities include regencies (kabupaten).
+type Province struct {
+	Code string `json:"code" gorm:"primaryKey;size:2"`
+	Name string `json:"name" gorm:"not null;size:100"`
+}
+
+type City struct {
+	Code         string `json:"code" gorm:"primaryKey;size:5"`
+	ProvinceCode string `json:"province_code" gorm:"not null;size:2;index"`
+	Name         string `json:"name" gorm:"not null;size:100"`
+
+	Province *Province `json:"province,omitempty" gorm:"foreignKey:ProvinceCode"`
+}
+
+type District struct {
+	Code     string `json:"code" gorm:"primaryKey;size:8"`
+	CityCode string `json:"city_code" gorm:"not null;size:5;index"`
+	Name     string `json:"name" gorm:"not null;size:100"`
+
+	City *City `json:"city,omitempty" gorm:"foreignKey:CityCode"`
+}
@@ -0,0 +1,15 @@
+package entities
+
+const (
+	SuggestionTypeProduct  = "product"
+	SuggestionTypeCategory = "category"
+	SuggestionTypeTag      = "tag"
+)
+
+// SearchSuggestion is an autocomplete entry for the search box.
+type SearchSuggestion struct {
+	Type string  `json:"type"`
+	Text string  `json:"text"`
+	Slug string  `json:"slug"`
+	Rank float64 `json:"-"`
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// ShippingRateAnyRegion matches every origin or destination in a shipping
+// rate.
+const ShippingRateAnyRegion = "*"
+
+// ShippingRate is a row of the built-in shipping rate table: what a courier
+// service charges to ship a parcel weighing more than MinWeight and up to
+// MaxWeight kg between two regions. OriginCode and DestinationCode are a
+// province or city code, or ShippingRateAnyRegion.
+type ShippingRate struct {
+	ID              int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Courier         string    `json:"courier" gorm:"not null;size:50"`
+	Service         string    `json:"service" gorm:"not null;size:50"`
+	Description     string    `json:"description,omitempty" gorm:"size:255"`
+	OriginCode      string    `json:"origin_code" gorm:"not null;size:5"`
+	DestinationCode string    `json:"destination_code" gorm:"not null;size:5"`
+	MinWeight       float64   `json:"min_weight" gorm:"type:decimal(10,2);not null"`
+	MaxWeight       float64   `json:"max_weight" gorm:"type:decimal(10,2);not null"`
+	Price           float64   `json:"price" gorm:"type:decimal(15,2);not null"`
+	EtdMinDays      int       `json:"etd_min_days" gorm:"not null"`
+	EtdMaxDays      int       `json:"etd_max_days" gorm:"not null"`
+	IsActive        bool      `json:"is_active" gorm:"not null"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Specificity ranks how precisely the rate matches its regions: a city
+// beats a province, which beats any region. Destinations count more than
+// origins.
+func (r *ShippingRate) Specificity() int {
+	return 3*regionSpecificity(r.DestinationCode) + regionSpecificity(r.OriginCode)
+}
+
+func regionSpecificity(code string) int {
+	switch {
+	case code == ShippingRateAnyRegion:
+		return 0
+	case strings.Contains(code, "."):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// ShippingRegionCodes lists the codes a shipping rate may use to match the
+// city with cityCode, from the most to the least specific.
+func ShippingRegionCodes(cityCode string) []string {
+	codes := []string{cityCode}
+	if province, _, found := strings.Cut(cityCode, "."); found {
+		codes = append(codes, province)
+	}
+	return append(codes, ShippingRateAnyRegion)
+}
+
+// ShippingQuote is the price of shipping a parcel with a courier service.
+type ShippingQuote struct {
+	Courier     string  `json:"courier"`
+	Service     string  `json:"service"`
+	Description string  `json:"description,omitempty"`
+	Weight      float64 `json:"weight"`
+	Price       float64 `json:"price"`
+	EtdMinDays  int     `json:"etd_min_days"`
+	EtdMaxDays  int     `json:"etd_max_days"`
+}
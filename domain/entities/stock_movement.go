@@ -0,0 +1,56 @@
+package entities
+
+import "time"
+
+const (
+	StockMovementInitial     = "initial"
+	StockMovementRestock     = "restock"
+	StockMovementSale        = "sale"
+	StockMovementReservation = "reservation"
+	StockMovementRelease     = "release"
+	StockMovementReturn      = "return"
+	StockMovementAdjustment  = "adjustment"
+)
+
+// StockMovement is an entry of the append-only stock ledger. StockChange is
+// what the movement did to the product's stock, so the changes of a product
+// add up to its stock quantity. Quantity is the number of units involved:
+// sales have no stock change, their units left the stock when they were
+// reserved.
+type StockMovement struct {
+	ID           int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProductID    int64     `json:"product_id" gorm:"not null;index"`
+	Type         string    `json:"type" gorm:"type:stock_movement_type_enum;not null"`
+	Quantity     int       `json:"quantity" gorm:"not null"`
+	StockChange  int       `json:"stock_change" gorm:"not null"`
+	BalanceAfter int       `json:"balance_after" gorm:"not null"`
+	OrderID      *int64    `json:"order_id,omitempty" gorm:"index"`
+	ActorID      *int64    `json:"actor_id,omitempty"`
+	Reason       string    `json:"reason,omitempty" gorm:"size:500"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// StockDrift reports a product whose stock quantity no longer matches the
+// balance of its stock ledger.
+type StockDrift struct {
+	ProductID     int64  `json:"product_id"`
+	SKU           string `json:"sku"`
+	Name          string `json:"name"`
+	StockQuantity int    `json:"stock_quantity"`
+	LedgerBalance int    `json:"ledger_balance"`
+}
+
+// Difference is how many units the stock quantity is above the ledger.
+func (d *StockDrift) Difference() int {
+	return d.StockQuantity - d.LedgerBalance
+}
+
+// IsManualStockMovement reports whether movements of the type are recorded by
+// hand rather than by checkout and order processing.
+func IsManualStockMovement(movementType string) bool {
+	switch movementType {
+	case StockMovementRestock, StockMovementReturn, StockMovementAdjustment:
+		return true
+	}
+	return false
+}
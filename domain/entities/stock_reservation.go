@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusCommitted = "committed"
+	ReservationStatusReleased  = "released"
+)
+
+// StockReservation holds stock taken out of a product for an unpaid order
+// until it is paid (committed) or cancelled (released).
+type StockReservation struct {
+	ID        int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	OrderID   int64     `json:"order_id" gorm:"not null;index"`
+	ProductID int64     `json:"product_id" gorm:"not null;index"`
+	Quantity  int       `json:"quantity" gorm:"not null"`
+	Status    string    `json:"status" gorm:"type:stock_reservation_status_enum;default:active"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// RefreshToken is an opaque, single-use token that can be exchanged for a new
+// access token. Tokens issued from the same login share a FamilyID so that
+// reuse of a rotated token can revoke the whole chain.
+type RefreshToken struct {
+	ID            int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID        int64      `json:"user_id" gorm:"not null;index"`
+	TokenHash     string     `json:"-" gorm:"uniqueIndex;not null;size:64"`
+	FamilyID      string     `json:"family_id" gorm:"not null;size:64;index"`
+	AccessTokenID string     `json:"-" gorm:"not null;size:64"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// RevokedToken marks an access token ID (jti) as no longer valid.
+type RevokedToken struct {
+	TokenID   string    `json:"token_id" gorm:"primaryKey;size:64"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -1,30 +1,42 @@
 package entities
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
-	ID                   int64      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Email                string     `json:"email" gorm:"uniqueIndex;not null;size:255"`
-	PasswordHash         string     `json:"-" gorm:"not null;size:255"`
-	FullName             string     `json:"full_name" gorm:"not null;size:255"`
-	Phone                string     `json:"phone,omitempty" gorm:"size:20"`
-	AvatarURL            string     `json:"avatar_url,omitempty" gorm:"size:500"`
-	Gender               string     `json:"gender,omitempty" gorm:"size:50"`
-	DateOfBirth          *time.Time `json:"date_of_birth,omitempty" gorm:"type:date"`
-	IsActive             bool       `json:"is_active" gorm:"default:true"`
-	IsVerified           bool       `json:"is_verified" gorm:"default:false"`
-	VerificationToken    string     `json:"-" gorm:"size:255"`
-	RoleID               int        `json:"role_id" gorm:"default:1"`
-	ResetPasswordToken   string     `json:"-" gorm:"size:255"`
-	ResetPasswordExpires *time.Time `json:"-" gorm:"type:timestamp"`
-	LastLoginAt          *time.Time `json:"last_login_at,omitempty" gorm:"type:timestamp"`
-	CreatedAt            time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt            time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt            *time.Time `json:"deleted_at,omitempty" gorm:"index"`
+	ID                   int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	Email                string         `json:"email" gorm:"uniqueIndex;not null;size:255"`
+	PasswordHash         string         `json:"-" gorm:"not null;size:255"`
+	FullName             string         `json:"full_name" gorm:"not null;size:255"`
+	Phone                string         `json:"phone,omitempty" gorm:"size:20"`
+	AvatarURL            string         `json:"avatar_url,omitempty" gorm:"size:500"`
+	Gender               string         `json:"gender,omitempty" gorm:"size:50"`
+	DateOfBirth          *time.Time     `json:"date_of_birth,omitempty" gorm:"type:date"`
+	IsActive             bool           `json:"is_active" gorm:"default:true"`
+	IsVerified           bool           `json:"is_verified" gorm:"default:false"`
+	VerificationToken    string         `json:"-" gorm:"size:255"`
+	VerificationExpires  *time.Time     `json:"-" gorm:"column:verification_token_expires;type:timestamp"`
+	VerificationSentAt   *time.Time     `json:"-" gorm:"type:timestamp"`
+	RoleID               int            `json:"role_id" gorm:"default:1"`
+	ResetPasswordToken   string         `json:"-" gorm:"size:255"`
+	ResetPasswordExpires *time.Time     `json:"-" gorm:"type:timestamp"`
+	LastLoginAt          *time.Time     `json:"last_login_at,omitempty" gorm:"type:timestamp"`
+	CreatedAt            time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt            time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt            gorm.DeletedAt `json:"deleted_at,omitzero" gorm:"index"`
 
 	Role *Role `json:"role,omitempty" gorm:"foreignKey:RoleID"`
 }
 
+const (
+	RoleCustomer = 1
+	RoleToko     = 2
+	RoleAdmin    = 3
+)
+
 type Role struct {
 	ID          int       `json:"id" gorm:"primaryKey;autoIncrement"`
 	Name        string    `json:"name" gorm:"uniqueIndex;not null;size:50"`
@@ -32,5 +44,11 @@ type Role struct {
 	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 
-	Users []User `json:"users,omitempty" gorm:"foreignKey:RoleID"`
+	Users       []User       `json:"users,omitempty" gorm:"foreignKey:RoleID"`
+	Permissions []Permission `json:"permissions,omitempty" gorm:"many2many:role_permissions"`
+}
+
+// IsBuiltIn reports whether the role is one of the seeded system roles.
+func (r *Role) IsBuiltIn() bool {
+	return r.ID == RoleCustomer || r.ID == RoleToko || r.ID == RoleAdmin
 }
@@ -1,23 +1,300 @@
 package repositories
 
 import (
+	"context"
+	"time"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
 	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
 )
 
 type UserRepository interface {
-	Create(user *entities.User) error
-	GetByID(id int64) (*entities.User, error)
-	GetByEmail(email string) (*entities.User, error)
-	Update(user *entities.User) error
-	Delete(id int64) error
-	List(offset, limit int) ([]*entities.User, error)
+	Create(ctx context.Context, user *entities.User) error
+	GetByID(ctx context.Context, id int64) (*entities.User, error)
+	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+	GetByVerificationToken(ctx context.Context, tokenHash string) (*entities.User, error)
+	// ResetPassword sets the password of the user holding the unexpired
+	// reset token and clears the token in one conditional update, so a token
+	// works once even when it is sent concurrently. It returns the user's ID.
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) (int64, error)
+	Update(ctx context.Context, user *entities.User) error
+	// Delete soft deletes the user together with their products.
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error)
+	ListDeleted(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error)
+	// Restore brings back a deleted user and the products deleted with them.
+	Restore(ctx context.Context, id int64) error
+	// PurgeDeleted permanently removes the users deleted before the given
+	// time, except those that still appear on orders.
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
+
+const (
+	ProductSortNewest    = "newest"
+	ProductSortPriceAsc  = "price_asc"
+	ProductSortPriceDesc = "price_desc"
+	ProductSortPopular   = "popular"
+	ProductSortRelevance = "relevance" // search only
+)
+
+// ProductFilter narrows down product listings. Zero values are ignored.
+// Products match when they are in any of CategorySlugs and carry any of
+// TagSlugs; prices are compared against the discounted price.
+type ProductFilter struct {
+	UserID        int64
+	Status        string
+	OnlyVisible   bool
+	Featured      *bool
+	InStock       bool
+	MinPrice      *float64
+	MaxPrice      *float64
+	CategoryID    int
+	TagID         int
+	CategorySlugs []string
+	TagSlugs      []string
+	Sort          string
+}
+
+type ProductRepository interface {
+	Create(ctx context.Context, product *entities.Product) error
+	GetByID(ctx context.Context, id int64) (*entities.Product, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.Product, error)
+	SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error)
+	Update(ctx context.Context, product *entities.Product) error
+	AdjustStock(ctx context.Context, movement *entities.StockMovement) error
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, filter ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error)
+	Search(ctx context.Context, text string, filter ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error)
+	Suggest(ctx context.Context, text string, limit int) ([]*entities.SearchSuggestion, error)
+	AttachCategories(ctx context.Context, productID int64, categoryIDs []int) error
+	DetachCategory(ctx context.Context, productID int64, categoryID int) error
+	AttachTags(ctx context.Context, productID int64, tagIDs []int) error
+	DetachTag(ctx context.Context, productID int64, tagID int) error
+	GetDeletedByID(ctx context.Context, id int64) (*entities.Product, error)
+	ListDeleted(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Product], error)
+	Restore(ctx context.Context, id int64) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
+
+// StockMovementRepository reads the stock ledger. Movements are written by
+// the product and order repositories together with the stock change.
+type StockMovementRepository interface {
+	ListByProduct(ctx context.Context, productID int64, params pagination.Params) (pagination.Page[*entities.StockMovement], error)
+	ListDrift(ctx context.Context) ([]*entities.StockDrift, error)
+	Reconcile(ctx context.Context, productID int64) error
+}
+
+type CartRepository interface {
+	GetOrCreateForUser(ctx context.Context, userID int64) (*entities.Cart, error)
+	GetByUserID(ctx context.Context, userID int64) (*entities.Cart, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.Cart, error)
+	Create(ctx context.Context, cart *entities.Cart) error
+	Delete(ctx context.Context, id int64) error
+	SetItemQuantity(ctx context.Context, cartID, productID int64, quantity int) error
+	RemoveItem(ctx context.Context, cartID, productID int64) error
+	ClearItems(ctx context.Context, cartID int64) error
+	// MergeInto moves the items of the source cart into the target cart and
+	// carries the source coupon over when the target has none.
+	MergeInto(ctx context.Context, sourceCartID, targetCartID int64) error
+	// SetCoupon applies the coupon to the cart; nil removes it.
+	SetCoupon(ctx context.Context, cartID int64, couponID *int64) error
+	// DeleteExpiredGuestCarts removes the guest carts expired at now, i.e.
+	// whose expires_at is not after it, together with their items.
+	DeleteExpiredGuestCarts(ctx context.Context, now time.Time) error
+}
+
+type AddressRepository interface {
+	// Create stores the address. The first address of a user becomes the
+	// default; a new default address replaces the previous one.
+	Create(ctx context.Context, address *entities.Address) error
+	GetByID(ctx context.Context, id int64) (*entities.Address, error)
+	GetDefault(ctx context.Context, userID int64) (*entities.Address, error)
+	ListByUserID(ctx context.Context, userID int64) ([]*entities.Address, error)
+	// Update saves the address, replacing the user's previous default when it
+	// is marked as default.
+	Update(ctx context.Context, address *entities.Address) error
+	// Delete removes the address. When it was the default, the most recently
+	// created remaining address becomes the default.
+	Delete(ctx context.Context, address *entities.Address) error
+}
+
+// RegionRepository reads the Indonesian region reference data.
+type RegionRepository interface {
+	ListProvinces(ctx context.Context) ([]*entities.Province, error)
+	ListCities(ctx context.Context, provinceCode string) ([]*entities.City, error)
+	ListDistricts(ctx context.Context, cityCode string) ([]*entities.District, error)
+	// GetDistrict returns the district with its city and province.
+	GetDistrict(ctx context.Context, code string) (*entities.District, error)
+}
+
+type CouponRepository interface {
+	Create(ctx context.Context, coupon *entities.Coupon) error
+	GetByID(ctx context.Context, id int64) (*entities.Coupon, error)
+	GetByCode(ctx context.Context, code string) (*entities.Coupon, error)
+	Update(ctx context.Context, coupon *entities.Coupon) error
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Coupon], error)
+	// CountRedemptions returns how many orders of the user used the coupon.
+	CountRedemptions(ctx context.Context, couponID, userID int64) (int, error)
+}
+
+type ShippingRateRepository interface {
+	Create(ctx context.Context, rate *entities.ShippingRate) error
+	GetByID(ctx context.Context, id int64) (*entities.ShippingRate, error)
+	Update(ctx context.Context, rate *entities.ShippingRate) error
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.ShippingRate], error)
+	// ListMatching returns the active rates from any of originCodes to any of
+	// destinationCodes whose weight bracket contains weight.
+	ListMatching(ctx context.Context, originCodes, destinationCodes []string, weight float64) ([]*entities.ShippingRate, error)
+}
+
+// OrderFilter narrows down order listings. Zero values are ignored. SellerID
+// matches orders containing at least one product of the seller.
+type OrderFilter struct {
+	UserID   int64
+	SellerID int64
+	Status   string
+}
+
+type OrderRepository interface {
+	// Place stores the order with its items and first history entry, reserves
+	// the ordered quantities until order.PaymentDueAt and empties the cart, all
+	// in one transaction. It fails with entities.ErrInsufficientStock when a
+	// product no longer has enough stock. The order's coupon is redeemed in
+	// the same transaction, failing with the coupon errors of entities when
+	// it can no longer be used.
+	Place(ctx context.Context, order *entities.Order, cartID int64) error
+	GetByID(ctx context.Context, id int64) (*entities.Order, error)
+	List(ctx context.Context, filter OrderFilter, params pagination.Params) (pagination.Page[*entities.Order], error)
+	// ListPaymentOverdue returns up to limit orders still awaiting payment
+	// after their payment deadline.
+	ListPaymentOverdue(ctx context.Context, now time.Time, limit int) ([]*entities.Order, error)
+	// UpdateStatus saves the transition recorded in entry, provided the order
+	// is still in entry.FromStatus. Stock reservations follow the order: they
+	// are committed when it is paid and released, returning the stock, when it
+	// is cancelled. Cancelling also gives back the coupon the order used.
+	UpdateStatus(ctx context.Context, order *entities.Order, entry *entities.OrderStatusHistory) error
+}
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *entities.Payment) error
+	GetByReference(ctx context.Context, gateway, reference string) (*entities.Payment, error)
+	GetPendingByOrderID(ctx context.Context, orderID int64) (*entities.Payment, error)
+	ListByOrderID(ctx context.Context, orderID int64) ([]*entities.Payment, error)
+	// ListExpired returns up to limit pending payments past their expiry.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*entities.Payment, error)
+	NotificationExists(ctx context.Context, gateway, eventID string) (bool, error)
+	// RecordNotification stores a notification that did not change the
+	// payment. Already recorded notifications are ignored.
+	RecordNotification(ctx context.Context, notification *entities.PaymentNotification) error
+	// UpdateStatus saves the payment's new status, provided it is still in
+	// from, and records the notification that caused it, if any, in the same
+	// transaction. Both a changed status and an already recorded notification
+	// fail with a conflict.
+	UpdateStatus(ctx context.Context, payment *entities.Payment, from string, notification *entities.PaymentNotification) error
+}
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *entities.Category) error
+	GetByID(ctx context.Context, id int) (*entities.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.Category, error)
+	GetByIDs(ctx context.Context, ids []int) ([]*entities.Category, error)
+	SlugExists(ctx context.Context, slug string, excludeID int) (bool, error)
+	Update(ctx context.Context, category *entities.Category) error
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context, onlyActive bool, params pagination.Params) (pagination.Page[*entities.Category], error)
+	ListDeleted(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Category], error)
+	Restore(ctx context.Context, id int) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
+
+type TagRepository interface {
+	Create(ctx context.Context, tag *entities.Tag) error
+	GetByID(ctx context.Context, id int) (*entities.Tag, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.Tag, error)
+	GetByIDs(ctx context.Context, ids []int) ([]*entities.Tag, error)
+	SlugExists(ctx context.Context, slug string, excludeID int) (bool, error)
+	Update(ctx context.Context, tag *entities.Tag) error
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Tag], error)
 }
 
 type RoleRepository interface {
-	Create(role *entities.Role) error
-	GetByID(id int) (*entities.Role, error)
-	GetByName(name string) (*entities.Role, error)
-	Update(role *entities.Role) error
-	Delete(id int) error
-	List(offset, limit int) ([]*entities.Role, error)
+	Create(ctx context.Context, role *entities.Role) error
+	GetByID(ctx context.Context, id int) (*entities.Role, error)
+	GetByName(ctx context.Context, name string) (*entities.Role, error)
+	Update(ctx context.Context, role *entities.Role) error
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Role], error)
+}
+
+type PermissionRepository interface {
+	List(ctx context.Context) ([]*entities.Permission, error)
+	GetByNames(ctx context.Context, names []string) ([]*entities.Permission, error)
+	ListByRoleID(ctx context.Context, roleID int) ([]*entities.Permission, error)
+	RoleHasPermission(ctx context.Context, roleID int, name string) (bool, error)
+	ReplaceRolePermissions(ctx context.Context, roleID int, permissions []*entities.Permission) error
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entities.RefreshToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error)
+	MarkRevoked(ctx context.Context, id int64) (bool, error)
+	ListByFamilyIDSince(ctx context.Context, familyID string, since time.Time) ([]*entities.RefreshToken, error)
+	ListByUserIDSince(ctx context.Context, userID int64, since time.Time) ([]*entities.RefreshToken, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllByUserID(ctx context.Context, userID int64) error
+}
+
+type IdempotencyKeyRepository interface {
+	// Reserve stores key for a request that is about to run and reports
+	// whether it did. It reports false when the scope already holds the key
+	// and it has not expired; expired keys are taken over.
+	Reserve(ctx context.Context, key *entities.IdempotencyKey) (bool, error)
+	GetByKey(ctx context.Context, scope, key string) (*entities.IdempotencyKey, error)
+	// Complete saves the response of the request that reserved key.
+	Complete(ctx context.Context, key *entities.IdempotencyKey) error
+	Delete(ctx context.Context, id int64) error
+	DeleteExpired(ctx context.Context) error
+}
+
+type RevokedTokenRepository interface {
+	Create(ctx context.Context, token *entities.RevokedToken) error
+	Exists(ctx context.Context, tokenID string) (bool, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+// Repositories hands out the repositories of a unit of work, all bound to
+// the same transaction.
+type Repositories interface {
+	Users() UserRepository
+	Roles() RoleRepository
+	Permissions() PermissionRepository
+	RefreshTokens() RefreshTokenRepository
+	RevokedTokens() RevokedTokenRepository
+	Products() ProductRepository
+	StockMovements() StockMovementRepository
+	Categories() CategoryRepository
+	Tags() TagRepository
+	Carts() CartRepository
+	Addresses() AddressRepository
+	Regions() RegionRepository
+	Coupons() CouponRepository
+	ShippingRates() ShippingRateRepository
+	Orders() OrderRepository
+	Payments() PaymentRepository
+	IdempotencyKeys() IdempotencyKeyRepository
+}
+
+// TxManager runs units of work, so that several repository calls either all
+// take effect or none does.
+type TxManager interface {
+	// Do runs fn in a transaction that is committed when fn returns nil and
+	// rolled back otherwise; fn must only use the repositories and context it
+	// is given. Calling Do again with that context nests a savepoint, which
+	// is rolled back on its own. When the transaction fails with a
+	// serialization failure or a deadlock, fn is run again, so it must not
+	// have side effects outside the database.
+	Do(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error
 }
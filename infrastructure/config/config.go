@@ -1,15 +1,17 @@
 package config
 
 import (
-	"log"
+	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	AppEnv string
-	Port   string
+	AppEnv   string
+	LogLevel string
+	Port     string
 
 	DBHost     string
 	DBPort     string
@@ -17,31 +19,132 @@ type Config struct {
 	DBPassword string
 	DBName     string
 
-	JWTSecret string
-	JWTExpiry string
+	JWTSecret          string
+	JWTExpiry          string
+	RefreshTokenExpiry string
+
+	AppBaseURL string
+
+	MailDriver   string
+	MailFrom     string
+	MailDir      string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	VerificationTokenExpiry    string
+	VerificationResendInterval string
+	VerifiedActions            []string
+
+	PasswordResetURL         string
+	PasswordResetTokenExpiry string
+
+	GuestCartExpiry string
+
+	StockReservationTTL string
+
+	PaymentGateway       string
+	PaymentWebhookSecret string
+
+	ShippingProvider   string
+	ShippingOriginCity string
+
+	IdempotencyKeyTTL string
+
+	RequestTimeout string
+
+	TrashRetention string
+
+	ShutdownTimeout    string
+	HealthCheckTimeout string
 }
 
 func LoadConfig() *Config {
 	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
+		slog.Info("No .env file found, using environment variables")
 	}
 
 	return &Config{
 		AppEnv:     getEnv("APP_ENV", "development"),
+		LogLevel:   getEnv("LOG_LEVEL", ""),
 		Port:       getEnv("PORT", "8080"),
 		DBHost:     getEnv("DB_HOST", "localhost"),
 		DBPort:     getEnv("DB_PORT", "5432"),
 		DBUser:     getEnv("DB_USER", "postgres"),
 		DBPassword: getEnv("DB_PASSWORD", ""),
 		DBName:     getEnv("DB_NAME", "ecommerce"),
-		JWTSecret:  getEnv("JWT_SECRET", ""),
-		JWTExpiry:  getEnv("JWT_EXPIRY", "24h"),
+
+		JWTSecret:          getEnv("JWT_SECRET", ""),
+		JWTExpiry:          getEnv("JWT_EXPIRY", "15m"),
+		RefreshTokenExpiry: getEnv("REFRESH_TOKEN_EXPIRY", "720h"),
+
+		AppBaseURL: getEnv("APP_BASE_URL", "http://localhost:8080"),
+
+		MailDriver:   getEnv("MAIL_DRIVER", "file"),
+		MailFrom:     getEnv("MAIL_FROM", "no-reply@ecommerce.local"),
+		MailDir:      getEnv("MAIL_DIR", "tmp/mail"),
+		SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+
+		VerificationTokenExpiry:    getEnv("VERIFICATION_TOKEN_EXPIRY", "24h"),
+		VerificationResendInterval: getEnv("VERIFICATION_RESEND_INTERVAL", "1m"),
+		VerifiedActions:            getEnvList("VERIFIED_ACTIONS", "checkout"),
+
+		PasswordResetURL:         getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+		PasswordResetTokenExpiry: getEnv("PASSWORD_RESET_TOKEN_EXPIRY", "1h"),
+
+		GuestCartExpiry: getEnv("GUEST_CART_EXPIRY", "720h"),
+
+		StockReservationTTL: getEnv("STOCK_RESERVATION_TTL", "30m"),
+
+		PaymentGateway:       getEnv("PAYMENT_GATEWAY", "fake"),
+		PaymentWebhookSecret: getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+
+		ShippingProvider:   getEnv("SHIPPING_PROVIDER", "table"),
+		ShippingOriginCity: getEnv("SHIPPING_ORIGIN_CITY", "31.74"),
+
+		IdempotencyKeyTTL: getEnv("IDEMPOTENCY_KEY_TTL", "24h"),
+
+		RequestTimeout: getEnv("REQUEST_TIMEOUT", "30s"),
+
+		TrashRetention: getEnv("TRASH_RETENTION", "720h"),
+
+		ShutdownTimeout:    getEnv("SHUTDOWN_TIMEOUT", "15s"),
+		HealthCheckTimeout: getEnv("HEALTH_CHECK_TIMEOUT", "2s"),
 	}
 }
 
+func (c *Config) IsProduction() bool {
+	return c.AppEnv == "production"
+}
+
+// RequiresVerification reports whether the given action is restricted to
+// users with a verified email address.
+func (c *Config) RequiresVerification(action string) bool {
+	for _, a := range c.VerifiedActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
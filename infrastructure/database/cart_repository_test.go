@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+func TestCartRepository_DeleteExpiredGuestCarts(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	carts := NewCartRepository(db)
+	// Postgres keeps microseconds, so the boundary compares exactly.
+	now := time.Now().Truncate(time.Microsecond)
+
+	create := func(expiresAt time.Time) int64 {
+		token, err := utils.GenerateRandomToken(16)
+		assert.NoError(t, err)
+		hash := utils.HashToken(token)
+		cart := &entities.Cart{TokenHash: &hash, ExpiresAt: &expiresAt}
+		assert.NoError(t, carts.Create(ctx, cart))
+		t.Cleanup(func() { db.Delete(&entities.Cart{}, cart.ID) })
+		return cart.ID
+	}
+	expired := create(now.Add(-time.Second))
+	expiresNow := create(now)
+	valid := create(now.Add(time.Second))
+
+	assert.NoError(t, carts.DeleteExpiredGuestCarts(ctx, now))
+
+	var remaining []int64
+	err := db.Model(&entities.Cart{}).Where("id IN ?", []int64{expired, expiresNow, valid}).Pluck("id", &remaining).Error
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{valid}, remaining, "carts expiring at now are expired, like GetByTokenHash treats them")
+}
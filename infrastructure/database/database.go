@@ -1,8 +1,9 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
@@ -11,9 +12,9 @@ import (
 	"gorm.io/gorm/logger"
 )
 
-var DB *gorm.DB
-
-func InitDB(cfg *config.Config) error {
+// Connect opens the database described by cfg. The returned handle is
+// passed to the repositories and seeders; it is safe for concurrent use.
+func Connect(cfg *config.Config) (*gorm.DB, error) {
 	dsn := fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		cfg.DBHost,
@@ -23,28 +24,52 @@ func InitDB(cfg *config.Config) error {
 		cfg.DBName,
 	)
 
-	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+	// Statements are logged without their arguments, which hold password
+	// hashes and tokens. Every statement is logged only at debug level.
+	level := logger.Warn
+	if slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		level = logger.Info
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.NewSlogLogger(slog.Default(), logger.Config{
+			SlowThreshold:             200 * time.Millisecond,
+			IgnoreRecordNotFoundError: true,
+			ParameterizedQueries:      true,
+			LogLevel:                  level,
+		}),
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	sqlDB, err := DB.DB()
+	sqlDB, err := db.DB()
 	if err != nil {
-		return fmt.Errorf("failed to get database instance: %w", err)
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
 	}
 
 	sqlDB.SetMaxIdleConns(10)
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
-	log.Println("Database connected successfully")
-	return nil
+	slog.Info("Database connected", "host", cfg.DBHost, "database", cfg.DBName)
+	return db, nil
+}
+
+// Close closes the connection pool of db.
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
 
-func GetDB() *gorm.DB {
-	return DB
+// Ping checks that the database accepts queries, for readiness checks.
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
 }
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgInvalidTextValue    = "22P02"
+
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// uniqueConstraintMessages maps unique constraints to the conflict message
+// shown to clients.
+var uniqueConstraintMessages = map[string]string{
+	"users_email_key":      "Email is already registered",
+	"roles_name_key":       "Role name already exists",
+	"permissions_name_key": "Permission already exists",
+	"products_sku_key":     "SKU is already used by another product",
+	"products_slug_key":    "Product slug already exists",
+	"categories_slug_key":  "Category slug already exists",
+	"tags_slug_key":        "Tag slug already exists",
+	"coupons_code_key":     "Coupon code already exists",
+
+	"uq_addresses_default_user_id": "User already has a default address",
+	"uq_shipping_rates_bracket":    "Shipping rate already exists for this weight bracket",
+
+	"uq_payments_pending_order_id":           "Order already has a pending payment",
+	"uq_payment_notifications_gateway_event": "Payment notification was already processed",
+	"uq_idempotency_keys_scope_key":          "Idempotency key is already in use",
+}
+
+// translateError converts GORM and PostgreSQL errors into AppErrors so the
+// layers above never need to know about the database driver. resource names
+// the entity in not found messages, e.g. "User".
+func translateError(err error, resource string) error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.NewNotFoundError(resource)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apperrors.ErrRequestTimeout
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			if message, ok := uniqueConstraintMessages[pgErr.ConstraintName]; ok {
+				return apperrors.NewConflictError(message)
+			}
+			return apperrors.NewConflictError(resource + " already exists")
+		case pgForeignKeyViolation:
+			return apperrors.NewConflictError(resource + " is referenced by or references a missing record")
+		case pgCheckViolation, pgInvalidTextValue:
+			return apperrors.NewBadRequestError("Invalid value for " + resource)
+		}
+	}
+
+	return apperrors.NewInternalError(err)
+}
+
+// isRetryable reports whether err aborted a transaction that may succeed when
+// run again.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"gorm.io/gorm"
+)
+
+// keyset describes an ordering that supports cursor pagination. Rows are
+// ordered by expr and then by idColumn so the order is total; when expr is
+// empty the rows are only ordered by ID.
+type keyset[T any] struct {
+	name     string
+	expr     string
+	idColumn string
+	desc     bool
+	parse    func(value string) (interface{}, error)
+	value    func(item T) string
+	id       func(item T) int64
+}
+
+// paginate returns the page of query described by params. The total is
+// counted before the cursor is applied; scopes only apply to the row query,
+// e.g. for preloads or computed columns.
+func paginate[T any](query *gorm.DB, params pagination.Params, order keyset[T], scopes ...func(*gorm.DB) *gorm.DB) (pagination.Page[T], error) {
+	var total *int64
+	if params.IncludeTotal {
+		var count int64
+		if err := query.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+			return pagination.Page[T]{}, err
+		}
+		total = &count
+	}
+
+	cursor, err := params.CursorFor(order.name)
+	if err != nil {
+		return pagination.Page[T]{}, err
+	}
+
+	comparison, direction := ">", "ASC"
+	if order.desc {
+		comparison, direction = "<", "DESC"
+	}
+
+	rows := query.Session(&gorm.Session{})
+	if cursor != nil {
+		if order.expr == "" {
+			rows = rows.Where(fmt.Sprintf("%s %s ?", order.idColumn, comparison), cursor.ID)
+		} else {
+			value, err := order.parse(cursor.Value)
+			if err != nil {
+				return pagination.Page[T]{}, pagination.ErrInvalidCursor
+			}
+			rows = rows.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", order.expr, order.idColumn, comparison), value, cursor.ID)
+		}
+	}
+	if order.expr != "" {
+		rows = rows.Order(fmt.Sprintf("%s %s", order.expr, direction))
+	}
+	rows = rows.Order(fmt.Sprintf("%s %s", order.idColumn, direction))
+
+	var items []T
+	if err := rows.Scopes(scopes...).Limit(params.Limit + 1).Find(&items).Error; err != nil {
+		return pagination.Page[T]{}, err
+	}
+
+	page := pagination.NewPage(items, params, func(item T) pagination.Cursor {
+		cursor := pagination.Cursor{Sort: order.name, ID: order.id(item)}
+		if order.value != nil {
+			cursor.Value = order.value(item)
+		}
+		return cursor
+	})
+	page.Total = total
+	return page, nil
+}
+
+func parseTimeCursor(value string) (interface{}, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func parseFloatCursor(value string) (interface{}, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+func parseIntCursor(value string) (interface{}, error) {
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func parseStringCursor(value string) (interface{}, error) {
+	return value, nil
+}
+
+func formatTimeCursor(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// deletedKeyset orders the soft deleted rows of table by when they were
+// deleted, most recent first.
+func deletedKeyset[T any](table string, deletedAt func(item T) gorm.DeletedAt, id func(item T) int64) keyset[T] {
+	return keyset[T]{
+		name:     "deleted",
+		expr:     table + ".deleted_at",
+		idColumn: table + ".id",
+		desc:     true,
+		parse:    parseTimeCursor,
+		value:    func(item T) string { return formatTimeCursor(deletedAt(item).Time) },
+		id:       id,
+	}
+}
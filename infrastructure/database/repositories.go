@@ -1,83 +1,1695 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
 	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
+	db *gorm.DB
 }
 
-func NewUserRepository() *UserRepository {
-	return &UserRepository{}
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
 }
 
-func (r *UserRepository) Create(user *entities.User) error {
-	return DB.Create(user).Error
+func (r *UserRepository) Create(ctx context.Context, user *entities.User) error {
+	return translateError(r.db.WithContext(ctx).Create(user).Error, "User")
 }
 
-func (r *UserRepository) GetByID(id int64) (*entities.User, error) {
+func (r *UserRepository) GetByID(ctx context.Context, id int64) (*entities.User, error) {
 	var user entities.User
-	err := DB.First(&user, id).Error
-	return &user, err
+	err := r.db.WithContext(ctx).First(&user, id).Error
+	return &user, translateError(err, "User")
 }
 
-func (r *UserRepository) GetByEmail(email string) (*entities.User, error) {
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var user entities.User
-	err := DB.Where("email = ?", email).First(&user).Error
-	return &user, err
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	return &user, translateError(err, "User")
 }
 
-func (r *UserRepository) Update(user *entities.User) error {
-	return DB.Save(user).Error
+func (r *UserRepository) GetByVerificationToken(ctx context.Context, tokenHash string) (*entities.User, error) {
+	var user entities.User
+	err := r.db.WithContext(ctx).Where("verification_token = ?", tokenHash).First(&user).Error
+	return &user, translateError(err, "User")
 }
 
-func (r *UserRepository) Delete(id int64) error {
+func (r *UserRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) (int64, error) {
 	var user entities.User
-	if err := DB.First(&user, id).Error; err != nil {
+	result := r.db.WithContext(ctx).Model(&user).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("reset_password_token = ? AND reset_password_expires > ?", tokenHash, now).
+		Updates(map[string]interface{}{
+			"password_hash":          passwordHash,
+			"reset_password_token":   "",
+			"reset_password_expires": nil,
+		})
+	if result.Error != nil {
+		return 0, translateError(result.Error, "User")
+	}
+	if result.RowsAffected == 0 {
+		return 0, apperrors.NewNotFoundError("User")
+	}
+	return user.ID, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *entities.User) error {
+	return translateError(r.db.WithContext(ctx).Save(user).Error, "User")
+}
+
+// Delete soft deletes the user and the products they sell. Both are stamped
+// with the same time, which is how Restore finds the products again.
+func (r *UserRepository) Delete(ctx context.Context, id int64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&entities.User{}).Where("id = ?", id).Update("deleted_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&entities.Product{}).Where("user_id = ?", id).Update("deleted_at", now).Error
+	})
+	return translateError(err, "User")
+}
+
+func (r *UserRepository) List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error) {
+	page, err := paginate(r.db.WithContext(ctx).Model(&entities.User{}), params, keyset[*entities.User]{
+		idColumn: "id",
+		id:       func(user *entities.User) int64 { return user.ID },
+	})
+	return page, translateError(err, "User")
+}
+
+func (r *UserRepository) ListDeleted(ctx context.Context, params pagination.Params) (pagination.Page[*entities.User], error) {
+	query := r.db.WithContext(ctx).Unscoped().Model(&entities.User{}).Where("deleted_at IS NOT NULL")
+	page, err := paginate(query, params, deletedKeyset("users",
+		func(user *entities.User) gorm.DeletedAt { return user.DeletedAt },
+		func(user *entities.User) int64 { return user.ID },
+	))
+	return page, translateError(err, "User")
+}
+
+// Restore brings back the user and the products that were deleted together
+// with them; products the user had deleted before stay deleted.
+func (r *UserRepository) Restore(ctx context.Context, id int64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user entities.User
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&user, id).Error; err != nil {
+			return err
+		}
+
+		err := tx.Unscoped().Model(&entities.Product{}).
+			Where("user_id = ? AND deleted_at = ?", id, user.DeletedAt).
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&user).Update("deleted_at", nil).Error
+	})
+	return translateError(err, "User")
+}
+
+// PurgeDeleted skips users that placed or sold orders, since orders keep
+// referencing them.
+func (r *UserRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id)").
+		Where("NOT EXISTS (SELECT 1 FROM order_items WHERE order_items.seller_id = users.id)").
+		Delete(&entities.User{})
+	return result.RowsAffected, translateError(result.Error, "User")
+}
+
+type ProductRepository struct {
+	db *gorm.DB
+}
+
+func NewProductRepository(db *gorm.DB) *ProductRepository {
+	return &ProductRepository{db: db}
+}
+
+// Categories and tags are managed through the Attach/Detach methods, so
+// associations are never written when the product itself is saved.
+// Create also opens the stock ledger of the product with its initial stock.
+func (r *ProductRepository) Create(ctx context.Context, product *entities.Product) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(product).Error; err != nil {
+			return err
+		}
+
+		actorID := product.UserID
+		return recordMovement(tx, &entities.StockMovement{
+			ProductID:   product.ID,
+			Type:        entities.StockMovementInitial,
+			Quantity:    product.StockQuantity,
+			StockChange: product.StockQuantity,
+			ActorID:     &actorID,
+			Reason:      "Product created",
+		}, product.StockQuantity)
+	})
+	return translateError(err, "Product")
+}
+
+func (r *ProductRepository) GetByID(ctx context.Context, id int64) (*entities.Product, error) {
+	var product entities.Product
+	err := r.withTaxonomy(r.db.WithContext(ctx)).First(&product, id).Error
+	return &product, translateError(err, "Product")
+}
+
+func (r *ProductRepository) GetBySlug(ctx context.Context, slug string) (*entities.Product, error) {
+	var product entities.Product
+	err := r.withTaxonomy(r.db.WithContext(ctx)).Where("slug = ?", slug).First(&product).Error
+	return &product, translateError(err, "Product")
+}
+
+// SlugExists also considers deleted products because their slugs still
+// occupy the unique index.
+func (r *ProductRepository) SlugExists(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&entities.Product{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error
+	return count > 0, translateError(err, "Product")
+}
+
+// Update saves everything but the stock quantity, which only changes
+// through AdjustStock so that every change ends up in the stock ledger.
+func (r *ProductRepository) Update(ctx context.Context, product *entities.Product) error {
+	return translateError(r.db.WithContext(ctx).Omit(clause.Associations, "stock_quantity").Save(product).Error, "Product")
+}
+
+// AdjustStock applies the stock change of movement to the product and
+// records the movement. Changes that would leave the stock negative fail with
+// ErrInsufficientStock. Published products without stock become out_of_stock
+// and sold out products are published again once restocked.
+func (r *ProductRepository) AdjustStock(ctx context.Context, movement *entities.StockMovement) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var balances []int
+		err := tx.Raw(`
+			UPDATE products
+			SET stock_quantity = stock_quantity + @change,
+			    status = CASE WHEN status = 'published' AND stock_quantity + @change = 0 THEN 'out_of_stock'
+			                  WHEN status = 'out_of_stock' AND stock_quantity + @change > 0 THEN 'published'
+			                  ELSE status END,
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE id = @id AND stock_quantity + @change >= 0 AND deleted_at IS NULL
+			RETURNING stock_quantity`,
+			sql.Named("change", movement.StockChange), sql.Named("id", movement.ProductID),
+		).Scan(&balances).Error
+		if err != nil {
+			return err
+		}
+		if len(balances) == 0 {
+			return entities.ErrInsufficientStock.WithDetails(map[string]interface{}{
+				"product_id": movement.ProductID,
+			})
+		}
+		return recordMovement(tx, movement, balances[0])
+	})
+	return translateError(err, "Product")
+}
+
+// Delete soft deletes the product. It stays in carts, where it shows up as
+// unavailable, until it is purged.
+func (r *ProductRepository) Delete(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Delete(&entities.Product{}, id)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "Product")
+	}
+	return translateError(result.Error, "Product")
+}
+
+func (r *ProductRepository) GetDeletedByID(ctx context.Context, id int64) (*entities.Product, error) {
+	var product entities.Product
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&product, id).Error
+	return &product, translateError(err, "Product")
+}
+
+func (r *ProductRepository) ListDeleted(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	query := r.db.WithContext(ctx).Unscoped().Model(&entities.Product{}).Where("deleted_at IS NOT NULL")
+	page, err := paginate(query, params, deletedKeyset("products",
+		func(product *entities.Product) gorm.DeletedAt { return product.DeletedAt },
+		func(product *entities.Product) int64 { return product.ID },
+	))
+	return page, translateError(err, "Product")
+}
+
+func (r *ProductRepository) Restore(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&entities.Product{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "Product")
+	}
+	return translateError(result.Error, "Product")
+}
+
+func (r *ProductRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before).Delete(&entities.Product{})
+	return result.RowsAffected, translateError(result.Error, "Product")
+}
+
+func (r *ProductRepository) List(ctx context.Context, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	query := r.applyFilter(r.db.WithContext(ctx).Model(&entities.Product{}), filter)
+	page, err := paginate(query, params, productSort(filter.Sort), r.withTaxonomy)
+	return page, translateError(err, "Product")
+}
+
+// Search ranks the products matching text by full-text relevance, weighted
+// name > short description > description, plus trigram word similarity on
+// the name so that misspelled queries still find products. Results are
+// ordered by relevance unless filter.Sort asks for another order.
+func (r *ProductRepository) Search(ctx context.Context, text string, filter repositories.ProductFilter, params pagination.Params) (pagination.Page[*entities.Product], error) {
+	matches := r.applyFilter(r.db.WithContext(ctx).Model(&entities.Product{}), filter).
+		Select(`products.*,
+			ts_rank_cd(products.search_vector, websearch_to_tsquery('simple', @q)) +
+			word_similarity(@q, products.name) AS search_rank`, sql.Named("q", text)).
+		Where(`products.search_vector @@ websearch_to_tsquery('simple', @q) OR @q <% products.name`, sql.Named("q", text))
+
+	order := productSort(filter.Sort)
+	if filter.Sort == "" || filter.Sort == repositories.ProductSortRelevance {
+		order = keyset[*entities.Product]{
+			name:     repositories.ProductSortRelevance,
+			expr:     "products.search_rank",
+			idColumn: "products.id",
+			desc:     true,
+			parse:    parseFloatCursor,
+			value: func(product *entities.Product) string {
+				return strconv.FormatFloat(product.SearchRank, 'g', -1, 64)
+			},
+			id: func(product *entities.Product) int64 { return product.ID },
+		}
+	}
+
+	// Highlights are only computed for the rows of the returned page.
+	highlight := func(db *gorm.DB) *gorm.DB {
+		return db.Select(`products.*,
+			ts_headline('simple', products.name, websearch_to_tsquery('simple', @q),
+				'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS highlight,
+			ts_headline('simple', coalesce(products.description, products.short_description, ''), websearch_to_tsquery('simple', @q),
+				'StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2') AS snippet`,
+			sql.Named("q", text))
+	}
+
+	query := r.db.WithContext(ctx).Table("(?) AS products", matches).Model(&entities.Product{})
+	page, err := paginate(query, params, order, highlight, r.withTaxonomy)
+	return page, translateError(err, "Product")
+}
+
+// Suggest returns autocomplete entries for product names, categories and
+// tags that start with or closely resemble text, best matches first.
+func (r *ProductRepository) Suggest(ctx context.Context, text string, limit int) ([]*entities.SearchSuggestion, error) {
+	var suggestions []*entities.SearchSuggestion
+	err := r.db.WithContext(ctx).Raw(`
+		(SELECT 'product' AS type, name AS text, slug, word_similarity(@q, name) + (name ILIKE @prefix)::int AS rank
+			FROM products
+			WHERE is_active AND status = @published AND deleted_at IS NULL AND (name ILIKE @prefix OR @q <% name)
+			ORDER BY rank DESC, sold_count DESC LIMIT @limit)
+		UNION ALL
+		(SELECT 'category', name, slug, word_similarity(@q, name) + (name ILIKE @prefix)::int AS rank
+			FROM categories
+			WHERE is_active AND deleted_at IS NULL AND (name ILIKE @prefix OR @q <% name)
+			ORDER BY rank DESC LIMIT @limit)
+		UNION ALL
+		(SELECT 'tag', name, slug, word_similarity(@q, name) + (name ILIKE @prefix)::int AS rank
+			FROM tags
+			WHERE deleted_at IS NULL AND (name ILIKE @prefix OR @q <% name)
+			ORDER BY rank DESC LIMIT @limit)
+		ORDER BY rank DESC
+		LIMIT @limit`,
+		sql.Named("q", text),
+		sql.Named("prefix", escapeLike(text)+"%"),
+		sql.Named("published", entities.ProductStatusPublished),
+		sql.Named("limit", limit),
+	).Scan(&suggestions).Error
+	return suggestions, translateError(err, "Product")
+}
+
+func (r *ProductRepository) applyFilter(query *gorm.DB, filter repositories.ProductFilter) *gorm.DB {
+	if filter.UserID != 0 {
+		query = query.Where("products.user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("products.status = ?", filter.Status)
+	}
+	if filter.OnlyVisible {
+		query = query.Where("products.is_active = ? AND products.status = ?", true, entities.ProductStatusPublished)
+	}
+	if filter.Featured != nil {
+		query = query.Where("products.is_featured = ?", *filter.Featured)
+	}
+	if filter.InStock {
+		query = query.Where("products.stock_quantity > 0")
+	}
+	if filter.MinPrice != nil {
+		query = query.Where(effectivePriceExpr+" >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		query = query.Where(effectivePriceExpr+" <= ?", *filter.MaxPrice)
+	}
+	if filter.CategoryID != 0 {
+		query = query.Where("products.id IN (SELECT product_id FROM product_categories WHERE category_id = ?)", filter.CategoryID)
+	}
+	if filter.TagID != 0 {
+		query = query.Where("products.id IN (SELECT product_id FROM product_tags WHERE tag_id = ?)", filter.TagID)
+	}
+	if len(filter.CategorySlugs) > 0 {
+		query = query.Where(`products.id IN (
+			SELECT product_categories.product_id FROM product_categories
+			JOIN categories ON categories.id = product_categories.category_id
+			WHERE categories.slug IN ? AND categories.deleted_at IS NULL)`, filter.CategorySlugs)
+	}
+	if len(filter.TagSlugs) > 0 {
+		query = query.Where(`products.id IN (
+			SELECT product_tags.product_id FROM product_tags
+			JOIN tags ON tags.id = product_tags.tag_id
+			WHERE tags.slug IN ? AND tags.deleted_at IS NULL)`, filter.TagSlugs)
+	}
+	return query
+}
+
+func (r *ProductRepository) AttachCategories(ctx context.Context, productID int64, categoryIDs []int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, categoryID := range categoryIDs {
+			if err := tx.Exec(
+				"INSERT INTO product_categories (product_id, category_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+				productID, categoryID,
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return translateError(err, "Product category")
+}
+
+func (r *ProductRepository) DetachCategory(ctx context.Context, productID int64, categoryID int) error {
+	return translateError(r.db.WithContext(ctx).Exec(
+		"DELETE FROM product_categories WHERE product_id = ? AND category_id = ?",
+		productID, categoryID,
+	).Error, "Product category")
+}
+
+func (r *ProductRepository) AttachTags(ctx context.Context, productID int64, tagIDs []int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, tagID := range tagIDs {
+			if err := tx.Exec(
+				"INSERT INTO product_tags (product_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+				productID, tagID,
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return translateError(err, "Product tag")
+}
+
+func (r *ProductRepository) DetachTag(ctx context.Context, productID int64, tagID int) error {
+	return translateError(r.db.WithContext(ctx).Exec(
+		"DELETE FROM product_tags WHERE product_id = ? AND tag_id = ?",
+		productID, tagID,
+	).Error, "Product tag")
+}
+
+// escapeLike escapes the LIKE wildcards in value.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+const effectivePriceExpr = "COALESCE(products.discount_price, products.price)"
+
+// productSort returns the keyset for one of the repositories.ProductSort*
+// orderings, defaulting to newest first.
+func productSort(sort string) keyset[*entities.Product] {
+	order := keyset[*entities.Product]{
+		name:     repositories.ProductSortNewest,
+		expr:     "products.created_at",
+		idColumn: "products.id",
+		desc:     true,
+		parse:    parseTimeCursor,
+		value:    func(product *entities.Product) string { return formatTimeCursor(product.CreatedAt) },
+		id:       func(product *entities.Product) int64 { return product.ID },
+	}
+
+	switch sort {
+	case repositories.ProductSortPriceAsc, repositories.ProductSortPriceDesc:
+		order.name = sort
+		order.expr = effectivePriceExpr
+		order.desc = sort == repositories.ProductSortPriceDesc
+		order.parse = parseFloatCursor
+		order.value = func(product *entities.Product) string {
+			return strconv.FormatFloat(product.EffectivePrice(), 'f', -1, 64)
+		}
+	case repositories.ProductSortPopular:
+		order.name = sort
+		order.expr = "products.sold_count"
+		order.parse = parseIntCursor
+		order.value = func(product *entities.Product) string { return strconv.Itoa(product.SoldCount) }
+	}
+
+	return order
+}
+
+// withTaxonomy preloads the categories and tags that have not been deleted.
+// Deleted categories are left out by GORM.
+func (r *ProductRepository) withTaxonomy(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Categories").
+		Preload("Tags")
+}
+
+type CartRepository struct {
+	db *gorm.DB
+}
+
+func NewCartRepository(db *gorm.DB) *CartRepository {
+	return &CartRepository{db: db}
+}
+
+// GetOrCreateForUser returns the cart of the user, creating an empty one the
+// first time. Concurrent calls end up with the same cart.
+func (r *CartRepository) GetOrCreateForUser(ctx context.Context, userID int64) (*entities.Cart, error) {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}}, DoNothing: true}).
+		Create(&entities.Cart{UserID: &userID}).Error
+	if err != nil {
+		return nil, translateError(err, "Cart")
+	}
+	return r.GetByUserID(ctx, userID)
+}
+
+func (r *CartRepository) GetByUserID(ctx context.Context, userID int64) (*entities.Cart, error) {
+	var cart entities.Cart
+	err := r.withItems(r.db.WithContext(ctx)).Where("user_id = ?", userID).First(&cart).Error
+	return &cart, translateError(err, "Cart")
+}
+
+// GetByTokenHash returns an unexpired guest cart.
+func (r *CartRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.Cart, error) {
+	var cart entities.Cart
+	err := r.withItems(r.db.WithContext(ctx)).
+		Where("token_hash = ? AND (expires_at IS NULL OR expires_at > ?)", tokenHash, time.Now()).
+		First(&cart).Error
+	return &cart, translateError(err, "Cart")
+}
+
+func (r *CartRepository) Create(ctx context.Context, cart *entities.Cart) error {
+	return translateError(r.db.WithContext(ctx).Omit(clause.Associations).Create(cart).Error, "Cart")
+}
+
+func (r *CartRepository) Delete(ctx context.Context, id int64) error {
+	return translateError(r.db.WithContext(ctx).Delete(&entities.Cart{}, id).Error, "Cart")
+}
+
+func (r *CartRepository) SetItemQuantity(ctx context.Context, cartID, productID int64, quantity int) error {
+	err := r.db.WithContext(ctx).Exec(`
+		INSERT INTO cart_items (cart_id, product_id, quantity) VALUES (?, ?, ?)
+		ON CONFLICT (cart_id, product_id)
+		DO UPDATE SET quantity = EXCLUDED.quantity, updated_at = CURRENT_TIMESTAMP`,
+		cartID, productID, quantity,
+	).Error
+	if err == nil {
+		err = r.touch(ctx, cartID)
+	}
+	return translateError(err, "Cart item")
+}
+
+func (r *CartRepository) RemoveItem(ctx context.Context, cartID, productID int64) error {
+	result := r.db.WithContext(ctx).Where("cart_id = ? AND product_id = ?", cartID, productID).Delete(&entities.CartItem{})
+	if result.Error == nil && result.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "Cart item")
+	}
+	if result.Error != nil {
+		return translateError(result.Error, "Cart item")
+	}
+	return translateError(r.touch(ctx, cartID), "Cart")
+}
+
+func (r *CartRepository) ClearItems(ctx context.Context, cartID int64) error {
+	err := r.db.WithContext(ctx).Where("cart_id = ?", cartID).Delete(&entities.CartItem{}).Error
+	if err == nil {
+		err = r.touch(ctx, cartID)
+	}
+	return translateError(err, "Cart item")
+}
+
+// MergeInto moves the items of the source cart into the target cart, adding
+// up quantities of products present in both, and deletes the source cart. The
+// target keeps its coupon, or takes over the one of the source.
+func (r *CartRepository) MergeInto(ctx context.Context, sourceCartID, targetCartID int64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO cart_items (cart_id, product_id, quantity)
+			SELECT ?, product_id, quantity FROM cart_items WHERE cart_id = ?
+			ON CONFLICT (cart_id, product_id)
+			DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity, updated_at = CURRENT_TIMESTAMP`,
+			targetCartID, sourceCartID,
+		).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			UPDATE carts SET coupon_id = (SELECT coupon_id FROM carts WHERE id = ?)
+			WHERE id = ? AND coupon_id IS NULL`,
+			sourceCartID, targetCartID,
+		).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&entities.Cart{}, sourceCartID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entities.Cart{}).Where("id = ?", targetCartID).Update("updated_at", time.Now()).Error
+	})
+	return translateError(err, "Cart")
+}
+
+func (r *CartRepository) SetCoupon(ctx context.Context, cartID int64, couponID *int64) error {
+	err := r.db.WithContext(ctx).Model(&entities.Cart{}).Where("id = ?", cartID).
+		Updates(map[string]interface{}{"coupon_id": couponID, "updated_at": time.Now()}).Error
+	return translateError(err, "Cart")
+}
+
+func (r *CartRepository) DeleteExpiredGuestCarts(ctx context.Context, now time.Time) error {
+	return translateError(r.db.WithContext(ctx).Where("user_id IS NULL AND expires_at <= ?", now).Delete(&entities.Cart{}).Error, "Cart")
+}
+
+func (r *CartRepository) touch(ctx context.Context, cartID int64) error {
+	return r.db.WithContext(ctx).Model(&entities.Cart{}).Where("id = ?", cartID).Update("updated_at", time.Now()).Error
+}
+
+func (r *CartRepository) withItems(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("cart_items.id") }).
+		Preload("Items.Product").
+		Preload("Items.Product.Categories").
+		Preload("Items.Product.Tags").
+		Preload("Coupon")
+}
+
+type AddressRepository struct {
+	db *gorm.DB
+}
+
+func NewAddressRepository(db *gorm.DB) *AddressRepository {
+	return &AddressRepository{db: db}
+}
+
+// Changes to an address book lock the user, so that concurrent requests
+// cannot leave a user with no or two default addresses.
+func (r *AddressRepository) lockUser(tx *gorm.DB, userID int64) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").First(&entities.User{}, userID).Error
+}
+
+func (r *AddressRepository) withRegions(db *gorm.DB) *gorm.DB {
+	return db.Preload("Province").Preload("City").Preload("District")
+}
+
+func (r *AddressRepository) Create(ctx context.Context, address *entities.Address) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lockUser(tx, address.UserID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entities.Address{}).Where("user_id = ?", address.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			address.IsDefault = true
+		} else if address.IsDefault {
+			if err := r.clearDefault(tx, address.UserID); err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).Create(address).Error
+	})
+	return translateError(err, "Address")
+}
+
+func (r *AddressRepository) GetByID(ctx context.Context, id int64) (*entities.Address, error) {
+	var address entities.Address
+	err := r.withRegions(r.db.WithContext(ctx)).First(&address, id).Error
+	return &address, translateError(err, "Address")
+}
+
+func (r *AddressRepository) GetDefault(ctx context.Context, userID int64) (*entities.Address, error) {
+	var address entities.Address
+	err := r.withRegions(r.db.WithContext(ctx)).Where("user_id = ? AND is_default", userID).First(&address).Error
+	return &address, translateError(err, "Address")
+}
+
+func (r *AddressRepository) ListByUserID(ctx context.Context, userID int64) ([]*entities.Address, error) {
+	var addresses []*entities.Address
+	err := r.withRegions(r.db.WithContext(ctx)).Where("user_id = ?", userID).
+		Order("is_default DESC, created_at DESC, id DESC").Find(&addresses).Error
+	return addresses, translateError(err, "Address")
+}
+
+func (r *AddressRepository) Update(ctx context.Context, address *entities.Address) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lockUser(tx, address.UserID); err != nil {
+			return err
+		}
+		if address.IsDefault {
+			if err := r.clearDefault(tx, address.UserID); err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).Save(address).Error
+	})
+	return translateError(err, "Address")
+}
+
+func (r *AddressRepository) Delete(ctx context.Context, address *entities.Address) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lockUser(tx, address.UserID); err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ? AND user_id = ?", address.ID, address.UserID).Delete(&entities.Address{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Exec(`
+			UPDATE addresses SET is_default = TRUE, updated_at = CURRENT_TIMESTAMP
+			WHERE id = (
+				SELECT id FROM addresses WHERE user_id = ?
+				ORDER BY created_at DESC, id DESC LIMIT 1
+			)
+			AND NOT EXISTS (SELECT 1 FROM addresses WHERE user_id = ? AND is_default)`,
+			address.UserID, address.UserID).Error
+	})
+	return translateError(err, "Address")
+}
+
+func (r *AddressRepository) clearDefault(tx *gorm.DB, userID int64) error {
+	return tx.Model(&entities.Address{}).
+		Where("user_id = ? AND is_default", userID).
+		Updates(map[string]interface{}{"is_default": false, "updated_at": time.Now()}).Error
+}
+
+type RegionRepository struct {
+	db *gorm.DB
+}
+
+func NewRegionRepository(db *gorm.DB) *RegionRepository {
+	return &RegionRepository{db: db}
+}
+
+func (r *RegionRepository) ListProvinces(ctx context.Context) ([]*entities.Province, error) {
+	var provinces []*entities.Province
+	err := r.db.WithContext(ctx).Order("code").Find(&provinces).Error
+	return provinces, translateError(err, "Province")
+}
+
+func (r *RegionRepository) ListCities(ctx context.Context, provinceCode string) ([]*entities.City, error) {
+	var cities []*entities.City
+	err := r.db.WithContext(ctx).Where("province_code = ?", provinceCode).Order("code").Find(&cities).Error
+	return cities, translateError(err, "City")
+}
+
+func (r *RegionRepository) ListDistricts(ctx context.Context, cityCode string) ([]*entities.District, error) {
+	var districts []*entities.District
+	err := r.db.WithContext(ctx).Where("city_code = ?", cityCode).Order("code").Find(&districts).Error
+	return districts, translateError(err, "District")
+}
+
+func (r *RegionRepository) GetDistrict(ctx context.Context, code string) (*entities.District, error) {
+	var district entities.District
+	err := r.db.WithContext(ctx).Preload("City.Province").Where("code = ?", code).First(&district).Error
+	return &district, translateError(err, "District")
+}
+
+type CouponRepository struct {
+	db *gorm.DB
+}
+
+func NewCouponRepository(db *gorm.DB) *CouponRepository {
+	return &CouponRepository{db: db}
+}
+
+func (r *CouponRepository) Create(ctx context.Context, coupon *entities.Coupon) error {
+	return translateError(r.db.WithContext(ctx).Create(coupon).Error, "Coupon")
+}
+
+func (r *CouponRepository) GetByID(ctx context.Context, id int64) (*entities.Coupon, error) {
+	var coupon entities.Coupon
+	err := r.db.WithContext(ctx).First(&coupon, id).Error
+	return &coupon, translateError(err, "Coupon")
+}
+
+func (r *CouponRepository) GetByCode(ctx context.Context, code string) (*entities.Coupon, error) {
+	var coupon entities.Coupon
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&coupon).Error
+	return &coupon, translateError(err, "Coupon")
+}
+
+func (r *CouponRepository) Update(ctx context.Context, coupon *entities.Coupon) error {
+	return translateError(r.db.WithContext(ctx).Save(coupon).Error, "Coupon")
+}
+
+func (r *CouponRepository) Delete(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Delete(&entities.Coupon{}, id)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "Coupon")
+	}
+	return translateError(result.Error, "Coupon")
+}
+
+func (r *CouponRepository) List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Coupon], error) {
+	page, err := paginate(r.db.WithContext(ctx).Model(&entities.Coupon{}), params, keyset[*entities.Coupon]{
+		idColumn: "id",
+		desc:     true,
+		id:       func(coupon *entities.Coupon) int64 { return coupon.ID },
+	})
+	return page, translateError(err, "Coupon")
+}
+
+func (r *CouponRepository) CountRedemptions(ctx context.Context, couponID, userID int64) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.CouponRedemption{}).Where("coupon_id = ? AND user_id = ?", couponID, userID).Count(&count).Error
+	return int(count), translateError(err, "Coupon")
+}
+
+type ShippingRateRepository struct {
+	db *gorm.DB
+}
+
+func NewShippingRateRepository(db *gorm.DB) *ShippingRateRepository {
+	return &ShippingRateRepository{db: db}
+}
+
+func (r *ShippingRateRepository) Create(ctx context.Context, rate *entities.ShippingRate) error {
+	return translateError(r.db.WithContext(ctx).Create(rate).Error, "Shipping rate")
+}
+
+func (r *ShippingRateRepository) GetByID(ctx context.Context, id int64) (*entities.ShippingRate, error) {
+	var rate entities.ShippingRate
+	err := r.db.WithContext(ctx).First(&rate, id).Error
+	return &rate, translateError(err, "Shipping rate")
+}
+
+func (r *ShippingRateRepository) Update(ctx context.Context, rate *entities.ShippingRate) error {
+	return translateError(r.db.WithContext(ctx).Save(rate).Error, "Shipping rate")
+}
+
+func (r *ShippingRateRepository) Delete(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Delete(&entities.ShippingRate{}, id)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "Shipping rate")
+	}
+	return translateError(result.Error, "Shipping rate")
+}
+
+func (r *ShippingRateRepository) List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.ShippingRate], error) {
+	page, err := paginate(r.db.WithContext(ctx).Model(&entities.ShippingRate{}), params, keyset[*entities.ShippingRate]{
+		idColumn: "id",
+		id:       func(rate *entities.ShippingRate) int64 { return rate.ID },
+	})
+	return page, translateError(err, "Shipping rate")
+}
+
+func (r *ShippingRateRepository) ListMatching(ctx context.Context, originCodes, destinationCodes []string, weight float64) ([]*entities.ShippingRate, error) {
+	var rates []*entities.ShippingRate
+	err := r.db.WithContext(ctx).Where("is_active AND origin_code IN ? AND destination_code IN ?", originCodes, destinationCodes).
+		Where("min_weight < ? AND max_weight >= ?", weight, weight).
+		Order("courier, service, id").
+		Find(&rates).Error
+	return rates, translateError(err, "Shipping rate")
+}
+
+type OrderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) *OrderRepository {
+	return &OrderRepository{db: db}
+}
+
+func (r *OrderRepository) Place(ctx context.Context, order *entities.Order, cartID int64) error {
+	// Take stock in a fixed product order so concurrent checkouts cannot
+	// deadlock.
+	items := make([]entities.OrderItem, len(order.Items))
+	copy(items, order.Items)
+	sort.Slice(items, func(i, j int) bool { return *items[i].ProductID < *items[j].ProductID })
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User").Create(order).Error; err != nil {
+			return err
+		}
+
+		reservations := make([]entities.StockReservation, len(items))
+		for i, item := range items {
+			if err := reserveStock(tx, order, *item.ProductID, item.Quantity); err != nil {
+				return err
+			}
+			reservations[i] = entities.StockReservation{
+				OrderID:   order.ID,
+				ProductID: *item.ProductID,
+				Quantity:  item.Quantity,
+				Status:    entities.ReservationStatusActive,
+				ExpiresAt: *order.PaymentDueAt,
+			}
+		}
+		if err := tx.Create(&reservations).Error; err != nil {
+			return err
+		}
+
+		if order.CouponID != nil {
+			if err := redeemCoupon(tx, order); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("cart_id = ?", cartID).Delete(&entities.CartItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entities.Cart{}).Where("id = ?", cartID).Update("coupon_id", nil).Error
+	})
+	return translateError(err, "Order")
+}
+
+// redeemCoupon records the use of the order's coupon. The coupon row stays
+// locked until the transaction ends, so concurrent checkouts cannot exceed
+// its usage limits.
+func redeemCoupon(tx *gorm.DB, order *entities.Order) error {
+	var coupon entities.Coupon
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&coupon, *order.CouponID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ErrCouponUnavailable
+		}
+		return err
+	}
+
+	var redemptions int64
+	if err := tx.Model(&entities.CouponRedemption{}).
+		Where("coupon_id = ? AND user_id = ?", coupon.ID, order.UserID).
+		Count(&redemptions).Error; err != nil {
+		return err
+	}
+	if err := coupon.CheckUsable(time.Now(), int(redemptions)); err != nil {
+		return err
+	}
+
+	if err := tx.Create(&entities.CouponRedemption{
+		CouponID: coupon.ID,
+		UserID:   order.UserID,
+		OrderID:  order.ID,
+		Discount: order.CouponDiscount + order.ShippingDiscount,
+	}).Error; err != nil {
+		return err
+	}
+	return tx.Exec("UPDATE coupons SET used_count = used_count + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+		coupon.ID).Error
+}
+
+// releaseCoupon gives back the coupon a cancelled order used.
+func releaseCoupon(tx *gorm.DB, order *entities.Order) error {
+	var couponIDs []int64
+	if err := tx.Raw("DELETE FROM coupon_redemptions WHERE order_id = ? RETURNING coupon_id", order.ID).
+		Scan(&couponIDs).Error; err != nil {
+		return err
+	}
+	if len(couponIDs) == 0 {
+		return nil
+	}
+	return tx.Exec("UPDATE coupons SET used_count = used_count - 1, updated_at = CURRENT_TIMESTAMP WHERE id IN ?",
+		couponIDs).Error
+}
+
+// reserveStock takes quantity out of the product's stock for order with a
+// conditional update, so concurrent checkouts can never take more than is in
+// stock. A product whose stock reaches zero becomes out_of_stock.
+func reserveStock(tx *gorm.DB, order *entities.Order, productID int64, quantity int) error {
+	var balances []int
+	err := tx.Raw(`
+		UPDATE products
+		SET stock_quantity = stock_quantity - @quantity,
+		    status = CASE WHEN stock_quantity = @quantity THEN 'out_of_stock' ELSE status END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = @id AND stock_quantity >= @quantity
+		  AND is_active AND status = 'published' AND deleted_at IS NULL
+		RETURNING stock_quantity`,
+		sql.Named("quantity", quantity), sql.Named("id", productID),
+	).Scan(&balances).Error
+	if err != nil {
+		return err
+	}
+	if len(balances) == 0 {
+		return entities.ErrInsufficientStock.WithDetails(map[string]interface{}{
+			"product_id": productID,
+		})
+	}
+
+	return recordMovement(tx, &entities.StockMovement{
+		ProductID:   productID,
+		Type:        entities.StockMovementReservation,
+		Quantity:    quantity,
+		StockChange: -quantity,
+		OrderID:     &order.ID,
+		ActorID:     &order.UserID,
+		Reason:      "Reserved for order " + order.OrderNumber,
+	}, balances[0])
+}
+
+// recordMovement appends movement to the stock ledger, balance being the
+// product's stock after it.
+func recordMovement(tx *gorm.DB, movement *entities.StockMovement, balance int) error {
+	movement.BalanceAfter = balance
+	return tx.Create(movement).Error
+}
+
+func (r *OrderRepository) GetByID(ctx context.Context, id int64) (*entities.Order, error) {
+	var order entities.Order
+	err := r.withItems(r.db.WithContext(ctx)).
+		Preload("History", func(db *gorm.DB) *gorm.DB { return db.Order("order_status_history.id") }).
+		First(&order, id).Error
+	return &order, translateError(err, "Order")
+}
+
+func (r *OrderRepository) List(ctx context.Context, filter repositories.OrderFilter, params pagination.Params) (pagination.Page[*entities.Order], error) {
+	query := r.db.WithContext(ctx).Model(&entities.Order{})
+	if filter.UserID != 0 {
+		query = query.Where("orders.user_id = ?", filter.UserID)
+	}
+	if filter.SellerID != 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM order_items WHERE order_items.order_id = orders.id AND order_items.seller_id = ?)", filter.SellerID)
+	}
+	if filter.Status != "" {
+		query = query.Where("orders.status = ?", filter.Status)
+	}
+
+	page, err := paginate(query, params, keyset[*entities.Order]{
+		name:     "newest",
+		expr:     "orders.created_at",
+		idColumn: "orders.id",
+		desc:     true,
+		parse:    parseTimeCursor,
+		value:    func(order *entities.Order) string { return formatTimeCursor(order.CreatedAt) },
+		id:       func(order *entities.Order) int64 { return order.ID },
+	}, r.withItems)
+	return page, translateError(err, "Order")
+}
+
+func (r *OrderRepository) ListPaymentOverdue(ctx context.Context, now time.Time, limit int) ([]*entities.Order, error) {
+	var orders []*entities.Order
+	err := r.db.WithContext(ctx).Where("status = ? AND payment_due_at <= ?", entities.OrderStatusPendingPayment, now).
+		Order("payment_due_at").
+		Limit(limit).
+		Find(&orders).Error
+	return orders, translateError(err, "Order")
+}
+
+func (r *OrderRepository) UpdateStatus(ctx context.Context, order *entities.Order, entry *entities.OrderStatusHistory) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Order{}).
+			Where("id = ? AND status = ?", order.ID, *entry.FromStatus).
+			Update("status", entry.ToStatus)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NewConflictError("Order status was changed by another request")
+		}
+
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+
+		switch entry.ToStatus {
+		case entities.OrderStatusPaid:
+			return commitReservations(tx, order, entry)
+		case entities.OrderStatusCancelled:
+			if err := releaseReservations(tx, order, entry); err != nil {
+				return err
+			}
+			return releaseCoupon(tx, order)
+		case entities.OrderStatusRefunded:
+			// Stock of an order refunded before it shipped goes back on
+			// sale; delivered goods are not returned to stock.
+			if *entry.FromStatus != entities.OrderStatusPaid && *entry.FromStatus != entities.OrderStatusProcessing {
+				return nil
+			}
+			if err := releaseReservations(tx, order, entry); err != nil {
+				return err
+			}
+			return releaseCoupon(tx, order)
+		}
+		return nil
+	})
+	return translateError(err, "Order")
+}
+
+// commitReservations turns the active reservations of a paid order into
+// sales. The stock already left with the reservation, so sales are recorded
+// without a stock change.
+func commitReservations(tx *gorm.DB, order *entities.Order, entry *entities.OrderStatusHistory) error {
+	reservations, err := orderReservations(tx, order.ID, entities.ReservationStatusActive)
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		var balance int
+		err := tx.Raw(`
+			UPDATE products
+			SET sold_count = sold_count + ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?
+			RETURNING stock_quantity`,
+			reservation.Quantity, reservation.ProductID,
+		).Scan(&balance).Error
+		if err != nil {
+			return err
+		}
+
+		err = recordMovement(tx, &entities.StockMovement{
+			ProductID: reservation.ProductID,
+			Type:      entities.StockMovementSale,
+			Quantity:  reservation.Quantity,
+			OrderID:   &order.ID,
+			ActorID:   entry.ChangedBy,
+			Reason:    "Order " + order.OrderNumber + " paid",
+		}, balance)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(&entities.StockReservation{}).
+		Where("order_id = ? AND status = ?", order.ID, entities.ReservationStatusActive).
+		Update("status", entities.ReservationStatusCommitted).Error
+}
+
+// releaseReservations returns the reserved stock of a cancelled or refunded
+// order, undoing the sale if it had already been paid. Sold out products are
+// published again.
+func releaseReservations(tx *gorm.DB, order *entities.Order, entry *entities.OrderStatusHistory) error {
+	reservations, err := orderReservations(tx, order.ID, entities.ReservationStatusActive, entities.ReservationStatusCommitted)
+	if err != nil {
 		return err
 	}
-	return DB.Delete(&user).Error
+
+	reason := entry.Note
+	if reason == "" {
+		reason = "Order " + order.OrderNumber + " " + entry.ToStatus
+	}
+	for _, reservation := range reservations {
+		var balance int
+		err := tx.Raw(`
+			UPDATE products
+			SET stock_quantity = stock_quantity + @quantity,
+			    sold_count = CASE WHEN @committed THEN GREATEST(sold_count - @quantity, 0) ELSE sold_count END,
+			    status = CASE WHEN status = 'out_of_stock' THEN 'published' ELSE status END,
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE id = @id
+			RETURNING stock_quantity`,
+			sql.Named("quantity", reservation.Quantity),
+			sql.Named("committed", reservation.Status == entities.ReservationStatusCommitted),
+			sql.Named("id", reservation.ProductID),
+		).Scan(&balance).Error
+		if err != nil {
+			return err
+		}
+
+		err = recordMovement(tx, &entities.StockMovement{
+			ProductID:   reservation.ProductID,
+			Type:        entities.StockMovementRelease,
+			Quantity:    reservation.Quantity,
+			StockChange: reservation.Quantity,
+			OrderID:     &order.ID,
+			ActorID:     entry.ChangedBy,
+			Reason:      reason,
+		}, balance)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(&entities.StockReservation{}).
+		Where("order_id = ? AND status IN ?", order.ID, []string{entities.ReservationStatusActive, entities.ReservationStatusCommitted}).
+		Update("status", entities.ReservationStatusReleased).Error
+}
+
+// orderReservations returns the reservations of the order in the given
+// statuses, in the same product order as checkout takes stock.
+func orderReservations(tx *gorm.DB, orderID int64, statuses ...string) ([]entities.StockReservation, error) {
+	var reservations []entities.StockReservation
+	err := tx.Where("order_id = ? AND status IN ?", orderID, statuses).
+		Order("product_id").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *OrderRepository) withItems(query *gorm.DB) *gorm.DB {
+	return query.Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("order_items.id") })
+}
+
+type PaymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+func (r *PaymentRepository) Create(ctx context.Context, payment *entities.Payment) error {
+	return translateError(r.db.WithContext(ctx).Create(payment).Error, "Payment")
+}
+
+func (r *PaymentRepository) GetByReference(ctx context.Context, gateway, reference string) (*entities.Payment, error) {
+	var payment entities.Payment
+	err := r.db.WithContext(ctx).Where("gateway = ? AND reference = ?", gateway, reference).First(&payment).Error
+	return &payment, translateError(err, "Payment")
+}
+
+func (r *PaymentRepository) GetPendingByOrderID(ctx context.Context, orderID int64) (*entities.Payment, error) {
+	var payment entities.Payment
+	err := r.db.WithContext(ctx).Where("order_id = ? AND status = ?", orderID, entities.PaymentStatusPending).First(&payment).Error
+	return &payment, translateError(err, "Payment")
+}
+
+func (r *PaymentRepository) ListByOrderID(ctx context.Context, orderID int64) ([]*entities.Payment, error) {
+	var payments []*entities.Payment
+	err := r.db.WithContext(ctx).Where("order_id = ?", orderID).Order("id").Find(&payments).Error
+	return payments, translateError(err, "Payment")
+}
+
+func (r *PaymentRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entities.Payment, error) {
+	var payments []*entities.Payment
+	err := r.db.WithContext(ctx).Where("status = ? AND expires_at <= ?", entities.PaymentStatusPending, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&payments).Error
+	return payments, translateError(err, "Payment")
+}
+
+func (r *PaymentRepository) NotificationExists(ctx context.Context, gateway, eventID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.PaymentNotification{}).
+		Where("gateway = ? AND event_id = ?", gateway, eventID).
+		Count(&count).Error
+	return count > 0, translateError(err, "Payment notification")
+}
+
+func (r *PaymentRepository) RecordNotification(ctx context.Context, notification *entities.PaymentNotification) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(notification).Error
+	return translateError(err, "Payment notification")
+}
+
+func (r *PaymentRepository) UpdateStatus(ctx context.Context, payment *entities.Payment, from string, notification *entities.PaymentNotification) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Payment{}).
+			Where("id = ? AND status = ?", payment.ID, from).
+			Updates(map[string]interface{}{
+				"status":           payment.Status,
+				"paid_at":          payment.PaidAt,
+				"refunded_at":      payment.RefundedAt,
+				"refund_reference": payment.RefundReference,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NewConflictError("Payment status was changed by another request")
+		}
+
+		if notification == nil {
+			return nil
+		}
+		return tx.Create(notification).Error
+	})
+	return translateError(err, "Payment")
+}
+
+type StockMovementRepository struct {
+	db *gorm.DB
+}
+
+func NewStockMovementRepository(db *gorm.DB) *StockMovementRepository {
+	return &StockMovementRepository{db: db}
+}
+
+func (r *StockMovementRepository) ListByProduct(ctx context.Context, productID int64, params pagination.Params) (pagination.Page[*entities.StockMovement], error) {
+	page, err := paginate(r.db.WithContext(ctx).Model(&entities.StockMovement{}).Where("product_id = ?", productID), params, keyset[*entities.StockMovement]{
+		idColumn: "id",
+		desc:     true,
+		id:       func(movement *entities.StockMovement) int64 { return movement.ID },
+	})
+	return page, translateError(err, "Stock movement")
+}
+
+// ListDrift returns the products whose stock quantity differs from the sum
+// of their stock movements.
+func (r *StockMovementRepository) ListDrift(ctx context.Context) ([]*entities.StockDrift, error) {
+	var drifts []*entities.StockDrift
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT products.id AS product_id, products.sku, products.name,
+		       COALESCE(products.stock_quantity, 0) AS stock_quantity,
+		       COALESCE(SUM(stock_movements.stock_change), 0) AS ledger_balance
+		FROM products
+		LEFT JOIN stock_movements ON stock_movements.product_id = products.id
+		GROUP BY products.id
+		HAVING COALESCE(products.stock_quantity, 0) <> COALESCE(SUM(stock_movements.stock_change), 0)
+		ORDER BY products.id`,
+	).Scan(&drifts).Error
+	return drifts, translateError(err, "Stock movement")
+}
+
+// Reconcile sets the stock quantity of the product to the balance of its
+// ledger, which is the source of truth, and syncs the sold out status. The
+// product row is locked first, as every ledger writer does, so the balance
+// cannot change underneath.
+func (r *StockMovementRepository) Reconcile(ctx context.Context, productID int64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var product entities.Product
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&product, productID).Error
+		if err != nil {
+			return err
+		}
+
+		var balance int
+		err = tx.Model(&entities.StockMovement{}).
+			Where("product_id = ?", productID).
+			Select("COALESCE(SUM(stock_change), 0)").
+			Scan(&balance).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE products
+			SET stock_quantity = @balance,
+			    status = CASE WHEN status = 'published' AND @balance <= 0 THEN 'out_of_stock'
+			                  WHEN status = 'out_of_stock' AND @balance > 0 THEN 'published'
+			                  ELSE status END,
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE id = @id`,
+			sql.Named("balance", balance), sql.Named("id", productID),
+		).Error
+	})
+	return translateError(err, "Product")
+}
+
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	return translateError(r.db.WithContext(ctx).Create(category).Error, "Category")
+}
+
+func (r *CategoryRepository) GetByID(ctx context.Context, id int) (*entities.Category, error) {
+	var category entities.Category
+	err := r.db.WithContext(ctx).First(&category, id).Error
+	return &category, translateError(err, "Category")
 }
 
-func (r *UserRepository) List(offset, limit int) ([]*entities.User, error) {
-	var users []*entities.User
-	err := DB.Offset(offset).Limit(limit).Find(&users).Error
-	return users, err
+func (r *CategoryRepository) GetBySlug(ctx context.Context, slug string) (*entities.Category, error) {
+	var category entities.Category
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&category).Error
+	return &category, translateError(err, "Category")
+}
+
+func (r *CategoryRepository) GetByIDs(ctx context.Context, ids []int) ([]*entities.Category, error) {
+	var categories []*entities.Category
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&categories).Error
+	return categories, translateError(err, "Category")
+}
+
+// SlugExists also considers deleted categories because their slugs still
+// occupy the unique index.
+func (r *CategoryRepository) SlugExists(ctx context.Context, slug string, excludeID int) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&entities.Category{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error
+	return count > 0, translateError(err, "Category")
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	return translateError(r.db.WithContext(ctx).Save(category).Error, "Category")
+}
+
+// Delete soft deletes the category; its products keep the category once it
+// is restored.
+func (r *CategoryRepository) Delete(ctx context.Context, id int) error {
+	result := r.db.WithContext(ctx).Delete(&entities.Category{}, id)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "Category")
+	}
+	return translateError(result.Error, "Category")
+}
+
+// List returns the categories ordered by name together with the number of
+// published products in each of them.
+func (r *CategoryRepository) List(ctx context.Context, onlyActive bool, params pagination.Params) (pagination.Page[*entities.Category], error) {
+	query := r.db.WithContext(ctx).Model(&entities.Category{})
+	if onlyActive {
+		query = query.Where("categories.is_active = ?", true)
+	}
+
+	withProductCount := func(db *gorm.DB) *gorm.DB {
+		return db.Select(`categories.*, (
+			SELECT COUNT(*) FROM product_categories
+			JOIN products ON products.id = product_categories.product_id
+			WHERE product_categories.category_id = categories.id
+				AND products.is_active AND products.status = ? AND products.deleted_at IS NULL
+		) AS product_count`, entities.ProductStatusPublished)
+	}
+
+	page, err := paginate(query, params, keyset[*entities.Category]{
+		name:     "name",
+		expr:     "categories.name",
+		idColumn: "categories.id",
+		parse:    parseStringCursor,
+		value:    func(category *entities.Category) string { return category.Name },
+		id:       func(category *entities.Category) int64 { return int64(category.ID) },
+	}, withProductCount)
+	return page, translateError(err, "Category")
+}
+
+func (r *CategoryRepository) ListDeleted(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Category], error) {
+	query := r.db.WithContext(ctx).Unscoped().Model(&entities.Category{}).Where("deleted_at IS NOT NULL")
+	page, err := paginate(query, params, deletedKeyset("categories",
+		func(category *entities.Category) gorm.DeletedAt { return category.DeletedAt },
+		func(category *entities.Category) int64 { return int64(category.ID) },
+	))
+	return page, translateError(err, "Category")
+}
+
+func (r *CategoryRepository) Restore(ctx context.Context, id int) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&entities.Category{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "Category")
+	}
+	return translateError(result.Error, "Category")
+}
+
+func (r *CategoryRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before).Delete(&entities.Category{})
+	return result.RowsAffected, translateError(result.Error, "Category")
+}
+
+type TagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+func (r *TagRepository) Create(ctx context.Context, tag *entities.Tag) error {
+	return translateError(r.db.WithContext(ctx).Create(tag).Error, "Tag")
+}
+
+func (r *TagRepository) GetByID(ctx context.Context, id int) (*entities.Tag, error) {
+	var tag entities.Tag
+	err := r.db.WithContext(ctx).First(&tag, id).Error
+	return &tag, translateError(err, "Tag")
+}
+
+func (r *TagRepository) GetBySlug(ctx context.Context, slug string) (*entities.Tag, error) {
+	var tag entities.Tag
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&tag).Error
+	return &tag, translateError(err, "Tag")
+}
+
+func (r *TagRepository) GetByIDs(ctx context.Context, ids []int) ([]*entities.Tag, error) {
+	var tags []*entities.Tag
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&tags).Error
+	return tags, translateError(err, "Tag")
+}
+
+// SlugExists also considers deleted tags because their slugs still occupy
+// the unique index.
+func (r *TagRepository) SlugExists(ctx context.Context, slug string, excludeID int) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&entities.Tag{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error
+	return count > 0, translateError(err, "Tag")
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag *entities.Tag) error {
+	return translateError(r.db.WithContext(ctx).Save(tag).Error, "Tag")
+}
+
+// Delete soft deletes the tag by setting deleted_at.
+func (r *TagRepository) Delete(ctx context.Context, id int) error {
+	result := r.db.WithContext(ctx).Delete(&entities.Tag{}, id)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "Tag")
+	}
+	return translateError(result.Error, "Tag")
+}
+
+func (r *TagRepository) List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Tag], error) {
+	page, err := paginate(r.db.WithContext(ctx).Model(&entities.Tag{}), params, keyset[*entities.Tag]{
+		name:     "name",
+		expr:     "name",
+		idColumn: "id",
+		parse:    parseStringCursor,
+		value:    func(tag *entities.Tag) string { return tag.Name },
+		id:       func(tag *entities.Tag) int64 { return int64(tag.ID) },
+	})
+	return page, translateError(err, "Tag")
 }
 
 type RoleRepository struct {
+	db *gorm.DB
 }
 
-func NewRoleRepository() *RoleRepository {
-	return &RoleRepository{}
+func NewRoleRepository(db *gorm.DB) *RoleRepository {
+	return &RoleRepository{db: db}
 }
 
-func (r *RoleRepository) Create(role *entities.Role) error {
-	return DB.Create(role).Error
+func (r *RoleRepository) Create(ctx context.Context, role *entities.Role) error {
+	return translateError(r.db.WithContext(ctx).Create(role).Error, "Role")
 }
 
-func (r *RoleRepository) GetByID(id int) (*entities.Role, error) {
+func (r *RoleRepository) GetByID(ctx context.Context, id int) (*entities.Role, error) {
 	var role entities.Role
-	err := DB.First(&role, id).Error
-	return &role, err
+	err := r.db.WithContext(ctx).First(&role, id).Error
+	return &role, translateError(err, "Role")
 }
 
-func (r *RoleRepository) GetByName(name string) (*entities.Role, error) {
+func (r *RoleRepository) GetByName(ctx context.Context, name string) (*entities.Role, error) {
 	var role entities.Role
-	err := DB.Where("name = ?", name).First(&role).Error
-	return &role, err
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&role).Error
+	return &role, translateError(err, "Role")
+}
+
+func (r *RoleRepository) Update(ctx context.Context, role *entities.Role) error {
+	return translateError(r.db.WithContext(ctx).Save(role).Error, "Role")
+}
+
+func (r *RoleRepository) Delete(ctx context.Context, id int) error {
+	return translateError(r.db.WithContext(ctx).Delete(&entities.Role{}, id).Error, "Role")
+}
+
+func (r *RoleRepository) List(ctx context.Context, params pagination.Params) (pagination.Page[*entities.Role], error) {
+	page, err := paginate(r.db.WithContext(ctx).Model(&entities.Role{}), params, keyset[*entities.Role]{
+		idColumn: "id",
+		id:       func(role *entities.Role) int64 { return int64(role.ID) },
+	})
+	return page, translateError(err, "Role")
+}
+
+type PermissionRepository struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepository(db *gorm.DB) *PermissionRepository {
+	return &PermissionRepository{db: db}
+}
+
+func (r *PermissionRepository) List(ctx context.Context) ([]*entities.Permission, error) {
+	var permissions []*entities.Permission
+	err := r.db.WithContext(ctx).Order("name").Find(&permissions).Error
+	return permissions, translateError(err, "Permission")
+}
+
+func (r *PermissionRepository) GetByNames(ctx context.Context, names []string) ([]*entities.Permission, error) {
+	var permissions []*entities.Permission
+	err := r.db.WithContext(ctx).Where("name IN ?", names).Find(&permissions).Error
+	return permissions, translateError(err, "Permission")
+}
+
+func (r *PermissionRepository) ListByRoleID(ctx context.Context, roleID int) ([]*entities.Permission, error) {
+	var permissions []*entities.Permission
+	err := r.db.WithContext(ctx).Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Where("role_permissions.role_id = ?", roleID).
+		Order("permissions.name").
+		Find(&permissions).Error
+	return permissions, translateError(err, "Permission")
+}
+
+func (r *PermissionRepository) RoleHasPermission(ctx context.Context, roleID int, name string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table("role_permissions").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("role_permissions.role_id = ? AND permissions.name = ?", roleID, name).
+		Count(&count).Error
+	return count > 0, translateError(err, "Permission")
+}
+
+func (r *PermissionRepository) ReplaceRolePermissions(ctx context.Context, roleID int, permissions []*entities.Permission) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM role_permissions WHERE role_id = ?", roleID).Error; err != nil {
+			return err
+		}
+		for _, permission := range permissions {
+			if err := tx.Exec(
+				"INSERT INTO role_permissions (role_id, permission_id) VALUES (?, ?)",
+				roleID, permission.ID,
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return translateError(err, "Permission")
+}
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *entities.RefreshToken) error {
+	return translateError(r.db.WithContext(ctx).Create(token).Error, "Refresh token")
+}
+
+func (r *RefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	return &token, translateError(err, "Refresh token")
+}
+
+// MarkRevoked revokes a single token and reports whether this call was the one
+// that revoked it, so concurrent rotations of the same token can be detected.
+func (r *RefreshTokenRepository) MarkRevoked(ctx context.Context, id int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entities.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected == 1, translateError(result.Error, "Refresh token")
+}
+
+func (r *RefreshTokenRepository) ListByFamilyIDSince(ctx context.Context, familyID string, since time.Time) ([]*entities.RefreshToken, error) {
+	var tokens []*entities.RefreshToken
+	err := r.db.WithContext(ctx).Where("family_id = ? AND created_at >= ?", familyID, since).Find(&tokens).Error
+	return tokens, translateError(err, "Refresh token")
+}
+
+func (r *RefreshTokenRepository) ListByUserIDSince(ctx context.Context, userID int64, since time.Time) ([]*entities.RefreshToken, error) {
+	var tokens []*entities.RefreshToken
+	err := r.db.WithContext(ctx).Where("user_id = ? AND created_at >= ?", userID, since).Find(&tokens).Error
+	return tokens, translateError(err, "Refresh token")
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return translateError(r.db.WithContext(ctx).Model(&entities.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error, "Refresh token")
+}
+
+func (r *RefreshTokenRepository) RevokeAllByUserID(ctx context.Context, userID int64) error {
+	return translateError(r.db.WithContext(ctx).Model(&entities.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error, "Refresh token")
+}
+
+type RevokedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRevokedTokenRepository(db *gorm.DB) *RevokedTokenRepository {
+	return &RevokedTokenRepository{db: db}
+}
+
+func (r *RevokedTokenRepository) Create(ctx context.Context, token *entities.RevokedToken) error {
+	return translateError(r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error, "Revoked token")
+}
+
+func (r *RevokedTokenRepository) Exists(ctx context.Context, tokenID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.RevokedToken{}).Where("token_id = ?", tokenID).Count(&count).Error
+	return count > 0, translateError(err, "Revoked token")
+}
+
+func (r *RevokedTokenRepository) DeleteExpired(ctx context.Context) error {
+	return translateError(r.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&entities.RevokedToken{}).Error, "Revoked token")
+}
+
+type IdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{db: db}
+}
+
+func (r *IdempotencyKeyRepository) Reserve(ctx context.Context, key *entities.IdempotencyKey) (bool, error) {
+	// An expired key is overwritten in place, as if it had been deleted.
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "scope"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"method", "path", "request_hash", "status_code", "response_headers",
+			"response_body", "expires_at", "completed_at", "created_at",
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			gorm.Expr("idempotency_keys.expires_at <= ?", time.Now()),
+		}},
+	}).Create(key)
+	if result.Error != nil {
+		return false, translateError(result.Error, "Idempotency key")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *IdempotencyKeyRepository) GetByKey(ctx context.Context, scope, key string) (*entities.IdempotencyKey, error) {
+	var idempotencyKey entities.IdempotencyKey
+	err := r.db.WithContext(ctx).Where("scope = ? AND key = ?", scope, key).First(&idempotencyKey).Error
+	if err != nil {
+		return nil, translateError(err, "Idempotency key")
+	}
+	return &idempotencyKey, nil
 }
 
-func (r *RoleRepository) Update(role *entities.Role) error {
-	return DB.Save(role).Error
+func (r *IdempotencyKeyRepository) Complete(ctx context.Context, key *entities.IdempotencyKey) error {
+	return translateError(r.db.WithContext(ctx).Model(key).
+		Select("status_code", "response_headers", "response_body", "completed_at").
+		Updates(key).Error, "Idempotency key")
 }
 
-func (r *RoleRepository) Delete(id int) error {
-	return DB.Delete(&entities.Role{}, id).Error
+func (r *IdempotencyKeyRepository) Delete(ctx context.Context, id int64) error {
+	return translateError(r.db.WithContext(ctx).Delete(&entities.IdempotencyKey{}, id).Error, "Idempotency key")
 }
 
-func (r *RoleRepository) List(offset, limit int) ([]*entities.Role, error) {
-	var roles []*entities.Role
-	err := DB.Offset(offset).Limit(limit).Find(&roles).Error
-	return roles, err
+func (r *IdempotencyKeyRepository) DeleteExpired(ctx context.Context) error {
+	return translateError(r.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&entities.IdempotencyKey{}).Error, "Idempotency key")
 }
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+func TestTagRepository_DeletedTagsKeepTheirSlug(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	tags := NewTagRepository(db)
+
+	tag := &entities.Tag{Name: "Deleted Tag", Slug: fmt.Sprintf("deleted-tag-%d", time.Now().UnixNano())}
+	assert.NoError(t, tags.Create(ctx, tag))
+	t.Cleanup(func() { db.Unscoped().Delete(&entities.Tag{}, tag.ID) })
+
+	assert.NoError(t, tags.Delete(ctx, tag.ID))
+	_, err := tags.GetBySlug(ctx, tag.Slug)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+	exists, err := tags.SlugExists(ctx, tag.Slug, 0)
+	assert.NoError(t, err)
+	assert.True(t, exists, "the slug of a deleted tag is still taken")
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	// txMaxAttempts bounds how often a unit of work is run when its
+	// transaction keeps failing with serialization failures or deadlocks.
+	txMaxAttempts = 3
+	txRetryDelay  = 20 * time.Millisecond
+)
+
+type txContextKey struct{}
+
+// TxManager runs units of work in GORM transactions.
+type TxManager struct {
+	db        *gorm.DB
+	isolation sql.IsolationLevel
+}
+
+func NewTxManager(db *gorm.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+// WithIsolation returns a copy of the manager that starts its transactions
+// at the given isolation level instead of the database default.
+func (m *TxManager) WithIsolation(level sql.IsolationLevel) *TxManager {
+	return &TxManager{db: m.db, isolation: level}
+}
+
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context, repos repositories.Repositories) error) error {
+	// Inside a unit of work, GORM turns the nested transaction into a
+	// savepoint. Retrying is left to the outermost call, since a failed
+	// serialization aborts the whole transaction.
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return m.run(ctx, tx, fn)
+		})
+	}
+
+	var opts []*sql.TxOptions
+	if m.isolation != sql.LevelDefault {
+		opts = append(opts, &sql.TxOptions{Isolation: m.isolation})
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return m.run(ctx, tx, fn)
+		}, opts...)
+		if err == nil || attempt == txMaxAttempts || !isRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return translateError(ctx.Err(), "Transaction")
+		case <-time.After(time.Duration(attempt) * txRetryDelay):
+		}
+	}
+}
+
+func (m *TxManager) run(ctx context.Context, tx *gorm.DB, fn func(ctx context.Context, repos repositories.Repositories) error) error {
+	return fn(context.WithValue(ctx, txContextKey{}, tx), txRepositories{db: tx})
+}
+
+// txRepositories builds repositories on a transaction.
+type txRepositories struct {
+	db *gorm.DB
+}
+
+func (r txRepositories) Users() repositories.UserRepository { return NewUserRepository(r.db) }
+func (r txRepositories) Roles() repositories.RoleRepository { return NewRoleRepository(r.db) }
+func (r txRepositories) Permissions() repositories.PermissionRepository {
+	return NewPermissionRepository(r.db)
+}
+func (r txRepositories) RefreshTokens() repositories.RefreshTokenRepository {
+	return NewRefreshTokenRepository(r.db)
+}
+func (r txRepositories) RevokedTokens() repositories.RevokedTokenRepository {
+	return NewRevokedTokenRepository(r.db)
+}
+func (r txRepositories) Products() repositories.ProductRepository { return NewProductRepository(r.db) }
+func (r txRepositories) StockMovements() repositories.StockMovementRepository {
+	return NewStockMovementRepository(r.db)
+}
+func (r txRepositories) Categories() repositories.CategoryRepository {
+	return NewCategoryRepository(r.db)
+}
+func (r txRepositories) Tags() repositories.TagRepository   { return NewTagRepository(r.db) }
+func (r txRepositories) Carts() repositories.CartRepository { return NewCartRepository(r.db) }
+func (r txRepositories) Addresses() repositories.AddressRepository {
+	return NewAddressRepository(r.db)
+}
+func (r txRepositories) Regions() repositories.RegionRepository { return NewRegionRepository(r.db) }
+func (r txRepositories) Coupons() repositories.CouponRepository { return NewCouponRepository(r.db) }
+func (r txRepositories) ShippingRates() repositories.ShippingRateRepository {
+	return NewShippingRateRepository(r.db)
+}
+func (r txRepositories) Orders() repositories.OrderRepository     { return NewOrderRepository(r.db) }
+func (r txRepositories) Payments() repositories.PaymentRepository { return NewPaymentRepository(r.db) }
+func (r txRepositories) IdempotencyKeys() repositories.IdempotencyKeyRepository {
+	return NewIdempotencyKeyRepository(r.db)
+}
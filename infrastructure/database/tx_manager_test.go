@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// newTestTag returns an unsaved tag with a unique slug, and removes it when
+// the test ends.
+func newTestTag(t *testing.T, db *gorm.DB, name string) *entities.Tag {
+	t.Helper()
+
+	slug := fmt.Sprintf("tx-%s-%d", name, time.Now().UnixNano())
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM tags WHERE slug = ?", slug)
+	})
+	return &entities.Tag{Name: name, Slug: slug}
+}
+
+func tagExists(t *testing.T, db *gorm.DB, tag *entities.Tag) bool {
+	t.Helper()
+
+	exists, err := NewTagRepository(db).SlugExists(context.Background(), tag.Slug, 0)
+	if err != nil {
+		t.Fatalf("failed to look up tag: %v", err)
+	}
+	return exists
+}
+
+func TestTxManager_RollsBackSavepoints(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	txManager := NewTxManager(db)
+	outer, inner, failed := newTestTag(t, db, "outer"), newTestTag(t, db, "inner"), newTestTag(t, db, "failed")
+	errInner := errors.New("inner failed")
+
+	err := txManager.Do(ctx, func(ctx context.Context, repos repositories.Repositories) error {
+		if err := repos.Tags().Create(ctx, outer); err != nil {
+			return err
+		}
+		err := txManager.Do(ctx, func(ctx context.Context, repos repositories.Repositories) error {
+			if err := repos.Tags().Create(ctx, failed); err != nil {
+				return err
+			}
+			return errInner
+		})
+		assert.ErrorIs(t, err, errInner)
+
+		return txManager.Do(ctx, func(ctx context.Context, repos repositories.Repositories) error {
+			return repos.Tags().Create(ctx, inner)
+		})
+	})
+	assert.NoError(t, err)
+	assert.True(t, tagExists(t, db, outer))
+	assert.True(t, tagExists(t, db, inner))
+	assert.False(t, tagExists(t, db, failed), "a failed savepoint is rolled back on its own")
+
+	rolledBack := newTestTag(t, db, "rolled-back")
+	err = txManager.Do(ctx, func(ctx context.Context, repos repositories.Repositories) error {
+		if err := repos.Tags().Create(ctx, rolledBack); err != nil {
+			return err
+		}
+		return errInner
+	})
+	assert.ErrorIs(t, err, errInner)
+	assert.False(t, tagExists(t, db, rolledBack))
+}
+
+func TestTxManager_RetriesSerializationFailures(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	txManager := NewTxManager(db)
+	tag := newTestTag(t, db, "retried")
+
+	attempts := 0
+	err := txManager.Do(ctx, func(ctx context.Context, repos repositories.Repositories) error {
+		attempts++
+		tag.ID = 0
+		if err := repos.Tags().Create(ctx, tag); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			return &pgconn.PgError{Code: pgSerializationFailure}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.True(t, tagExists(t, db, tag))
+
+	attempts = 0
+	err = txManager.Do(ctx, func(ctx context.Context, repos repositories.Repositories) error {
+		attempts++
+		return &pgconn.PgError{Code: pgDeadlockDetected}
+	})
+	assert.True(t, isRetryable(err))
+	assert.Equal(t, txMaxAttempts, attempts, "gives up after txMaxAttempts")
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+)
+
+func TestUserRepository_SoftDeleteTakesProductsAlong(t *testing.T) {
+	db := openTestDB(t)
+	userID, product := createTestSeller(t, db, 5)
+	ctx := context.Background()
+	users, products := NewUserRepository(db), NewProductRepository(db)
+
+	assert.NoError(t, users.Delete(ctx, userID))
+	_, err := users.GetByID(ctx, userID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+	_, err = products.GetByID(ctx, product.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound), "products are deleted with their seller")
+
+	deleted, err := products.GetDeletedByID(ctx, product.ID)
+	assert.NoError(t, err)
+	assert.True(t, deleted.DeletedAt.Valid)
+
+	assert.NoError(t, users.Restore(ctx, userID))
+	_, err = products.GetByID(ctx, product.ID)
+	assert.NoError(t, err, "products come back with their seller")
+}
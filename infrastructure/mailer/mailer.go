@@ -0,0 +1,29 @@
+package mailer
+
+import (
+	"fmt"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
+)
+
+// New returns the mailer selected by MAIL_DRIVER: "smtp", "file" or "memory".
+func New(cfg *config.Config) (ports.Mailer, error) {
+	switch cfg.MailDriver {
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom), nil
+	case "file":
+		return NewFileMailer(cfg.MailDir, cfg.MailFrom)
+	case "memory":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.MailDriver)
+	}
+}
+
+func buildMessage(from string, message *ports.MailMessage) []byte {
+	return []byte(fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		from, message.To, message.Subject, message.Body,
+	))
+}
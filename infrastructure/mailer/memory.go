@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"sync"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+)
+
+// MemoryMailer keeps sent messages in memory. It is intended for tests.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []ports.MailMessage
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(message *ports.MailMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, *message)
+	return nil
+}
+
+// Messages returns a copy of every message sent so far.
+func (m *MemoryMailer) Messages() []ports.MailMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ports.MailMessage(nil), m.messages...)
+}
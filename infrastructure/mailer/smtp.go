@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"net"
+	"net/smtp"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+)
+
+type SMTPMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (m *SMTPMailer) Send(message *ports.MailMessage) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	return smtp.SendMail(
+		net.JoinHostPort(m.host, m.port),
+		auth,
+		m.from,
+		[]string{message.To},
+		buildMessage(m.from, message),
+	)
+}
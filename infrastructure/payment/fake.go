@@ -0,0 +1,184 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+// FakeSignatureHeader carries the hex encoded HMAC-SHA256 of the webhook body,
+// keyed with the webhook secret.
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// FakeGateway is an in-process payment gateway for development and tests.
+// Charges live in memory and are settled with Notify, which returns the
+// webhook call the gateway would send.
+type FakeGateway struct {
+	secret  string
+	mu      sync.Mutex
+	charges map[string]*ports.Charge
+	// refunds maps refund references to the charge they refunded.
+	refunds map[string]string
+}
+
+// fakeNotification is the webhook body sent by the fake gateway.
+type fakeNotification struct {
+	EventID   string  `json:"event_id"`
+	Reference string  `json:"reference"`
+	Status    string  `json:"status"`
+	Amount    float64 `json:"amount"`
+}
+
+func NewFakeGateway(secret string) *FakeGateway {
+	return &FakeGateway{
+		secret:  secret,
+		charges: make(map[string]*ports.Charge),
+		refunds: make(map[string]string),
+	}
+}
+
+func (g *FakeGateway) Name() string {
+	return "fake"
+}
+
+func (g *FakeGateway) CreateCharge(ctx context.Context, request *ports.ChargeRequest) (*ports.Charge, error) {
+	reference, err := randomID("FAKE-")
+	if err != nil {
+		return nil, err
+	}
+
+	charge := &ports.Charge{
+		Reference: reference,
+		Status:    entities.PaymentStatusPending,
+		Amount:    request.Amount,
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.charges[reference] = charge
+	clone := *charge
+	return &clone, nil
+}
+
+func (g *FakeGateway) GetCharge(ctx context.Context, reference string) (*ports.Charge, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	charge, ok := g.charges[reference]
+	if !ok {
+		return nil, ports.ErrChargeNotFound
+	}
+	clone := *charge
+	return &clone, nil
+}
+
+// Refund refunds a paid charge in full or in part. Repeating a refund
+// reference that was already used for the charge succeeds without refunding
+// again.
+func (g *FakeGateway) Refund(ctx context.Context, request *ports.RefundRequest) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.refunds[request.RefundReference] == request.Reference {
+		return nil
+	}
+	charge, ok := g.charges[request.Reference]
+	if !ok {
+		return ports.ErrChargeNotFound
+	}
+	if charge.Status != entities.PaymentStatusPaid {
+		return fmt.Errorf("charge %s is %s and cannot be refunded", request.Reference, charge.Status)
+	}
+	if request.Amount > charge.Amount {
+		return fmt.Errorf("refund of %.2f exceeds the charge of %.2f", request.Amount, charge.Amount)
+	}
+	charge.Status = entities.PaymentStatusRefunded
+	g.refunds[request.RefundReference] = request.Reference
+	return nil
+}
+
+func (g *FakeGateway) ParseNotification(ctx context.Context, payload []byte, headers http.Header) (*ports.PaymentNotification, error) {
+	signature, err := hex.DecodeString(headers.Get(FakeSignatureHeader))
+	if err != nil || !hmac.Equal(signature, g.sign(payload)) {
+		return nil, ports.ErrInvalidSignature
+	}
+
+	var body fakeNotification
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("invalid notification body: %w", err)
+	}
+	if body.EventID == "" || body.Reference == "" || body.Status == "" {
+		return nil, fmt.Errorf("notification is missing event_id, reference or status")
+	}
+
+	// Keep charges settled by hand in step with their notifications.
+	g.mu.Lock()
+	if charge, ok := g.charges[body.Reference]; ok {
+		charge.Status = body.Status
+	}
+	g.mu.Unlock()
+
+	return &ports.PaymentNotification{
+		EventID:   body.EventID,
+		Reference: body.Reference,
+		Status:    body.Status,
+		Amount:    body.Amount,
+		Payload:   payload,
+	}, nil
+}
+
+// Notify settles a charge with status and returns the signed webhook body and
+// headers the gateway sends for it.
+func (g *FakeGateway) Notify(reference, status string) ([]byte, http.Header, error) {
+	g.mu.Lock()
+	charge, ok := g.charges[reference]
+	var amount float64
+	if ok {
+		charge.Status = status
+		amount = charge.Amount
+	}
+	g.mu.Unlock()
+	if !ok {
+		return nil, nil, ports.ErrChargeNotFound
+	}
+
+	eventID, err := randomID("evt-")
+	if err != nil {
+		return nil, nil, err
+	}
+	payload, err := json.Marshal(fakeNotification{
+		EventID:   eventID,
+		Reference: reference,
+		Status:    status,
+		Amount:    amount,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+	headers.Set(FakeSignatureHeader, hex.EncodeToString(g.sign(payload)))
+	return payload, headers, nil
+}
+
+func (g *FakeGateway) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(g.secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func randomID(prefix string) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,30 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
+)
+
+// New returns the payment gateway selected by PAYMENT_GATEWAY. Only the
+// "fake" gateway is built in; providers such as Midtrans or Xendit plug in by
+// implementing ports.PaymentGateway. Webhooks are signed with
+// PAYMENT_WEBHOOK_SECRET, so it must be set.
+func New(cfg *config.Config) (ports.PaymentGateway, error) {
+	if cfg.PaymentWebhookSecret == "" {
+		return nil, errors.New("PAYMENT_WEBHOOK_SECRET is required")
+	}
+
+	switch cfg.PaymentGateway {
+	case "fake":
+		// Anyone holding the secret can settle fake charges.
+		if cfg.IsProduction() {
+			return nil, errors.New("the fake payment gateway cannot be used in production")
+		}
+		return NewFakeGateway(cfg.PaymentWebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment gateway: %s", cfg.PaymentGateway)
+	}
+}
@@ -0,0 +1,34 @@
+package shipping
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
+)
+
+// New returns the shipping rate provider selected by SHIPPING_PROVIDER:
+// "table" quotes from the shipping_rates table and "stub" stands in for a
+// courier API during development. Courier APIs such as RajaOngkir plug in by
+// implementing ports.ShippingRateProvider.
+func New(cfg *config.Config, rateRepo repositories.ShippingRateRepository) (ports.ShippingRateProvider, error) {
+	switch cfg.ShippingProvider {
+	case "table":
+		return NewTableProvider(rateRepo), nil
+	case "stub":
+		return NewStubProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown shipping provider: %s", cfg.ShippingProvider)
+	}
+}
+
+// chargeableWeight rounds weight up to whole kilograms, as couriers charge
+// per started kilogram.
+func chargeableWeight(weight float64) float64 {
+	if weight <= 1 {
+		return 1
+	}
+	return math.Ceil(weight - 1e-9)
+}
@@ -0,0 +1,69 @@
+package shipping
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+// stubService is a courier service offered by StubProvider. Prices are per
+// kilogram and depend on how far apart origin and destination are.
+type stubService struct {
+	courier     string
+	service     string
+	description string
+	perKg       [3]float64 // same city, same province, elsewhere
+	etdMinDays  [3]int
+	etdMaxDays  [3]int
+}
+
+var stubServices = []stubService{
+	{"jne", "REG", "Layanan Reguler", [3]float64{9000, 11000, 18000}, [3]int{1, 1, 2}, [3]int{2, 2, 4}},
+	{"jne", "YES", "Yakin Esok Sampai", [3]float64{18000, 22000, 34000}, [3]int{1, 1, 1}, [3]int{1, 1, 1}},
+	{"sicepat", "SIUNT", "SiUntung", [3]float64{8000, 10000, 16000}, [3]int{1, 1, 2}, [3]int{2, 3, 5}},
+}
+
+// StubProvider mimics a courier API without network access, for development
+// and tests. Its quotes are deterministic.
+type StubProvider struct{}
+
+func NewStubProvider() *StubProvider {
+	return &StubProvider{}
+}
+
+func (p *StubProvider) Name() string {
+	return "stub"
+}
+
+func (p *StubProvider) Quote(ctx context.Context, request *ports.ShippingQuoteRequest) ([]*entities.ShippingQuote, error) {
+	zone := 2
+	switch {
+	case request.OriginCityCode == request.DestinationCityCode:
+		zone = 0
+	case provinceOf(request.OriginCityCode) == provinceOf(request.DestinationCityCode):
+		zone = 1
+	}
+
+	weight := chargeableWeight(request.Weight)
+	quotes := make([]*entities.ShippingQuote, 0, len(stubServices))
+	for _, service := range stubServices {
+		quotes = append(quotes, &entities.ShippingQuote{
+			Courier:     service.courier,
+			Service:     service.service,
+			Description: service.description,
+			Weight:      weight,
+			Price:       service.perKg[zone] * weight,
+			EtdMinDays:  service.etdMinDays[zone],
+			EtdMaxDays:  service.etdMaxDays[zone],
+		})
+	}
+	sortQuotes(quotes)
+	return quotes, nil
+}
+
+func provinceOf(cityCode string) string {
+	province, _, _ := strings.Cut(cityCode, ".")
+	return province
+}
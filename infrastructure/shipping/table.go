@@ -0,0 +1,75 @@
+package shipping
+
+import (
+	"context"
+	"sort"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/application/ports"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+// TableProvider quotes from the shipping_rates table. Rates are flat per
+// weight bracket; when several rates of a courier service match the route,
+// the most specific one wins.
+type TableProvider struct {
+	rateRepo repositories.ShippingRateRepository
+}
+
+func NewTableProvider(rateRepo repositories.ShippingRateRepository) *TableProvider {
+	return &TableProvider{rateRepo: rateRepo}
+}
+
+func (p *TableProvider) Name() string {
+	return "table"
+}
+
+func (p *TableProvider) Quote(ctx context.Context, request *ports.ShippingQuoteRequest) ([]*entities.ShippingQuote, error) {
+	weight := chargeableWeight(request.Weight)
+	rates, err := p.rateRepo.ListMatching(
+		ctx,
+		entities.ShippingRegionCodes(request.OriginCityCode),
+		entities.ShippingRegionCodes(request.DestinationCityCode),
+		weight,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	best := make(map[string]*entities.ShippingRate)
+	for _, rate := range rates {
+		key := rate.Courier + "/" + rate.Service
+		if current, ok := best[key]; !ok || rate.Specificity() > current.Specificity() {
+			best[key] = rate
+		}
+	}
+
+	quotes := make([]*entities.ShippingQuote, 0, len(best))
+	for _, rate := range best {
+		quotes = append(quotes, &entities.ShippingQuote{
+			Courier:     rate.Courier,
+			Service:     rate.Service,
+			Description: rate.Description,
+			Weight:      weight,
+			Price:       rate.Price,
+			EtdMinDays:  rate.EtdMinDays,
+			EtdMaxDays:  rate.EtdMaxDays,
+		})
+	}
+	sortQuotes(quotes)
+	return quotes, nil
+}
+
+// sortQuotes orders quotes by price, then by courier and service so equal
+// prices come out in a stable order.
+func sortQuotes(quotes []*entities.ShippingQuote) {
+	sort.Slice(quotes, func(i, j int) bool {
+		if quotes[i].Price != quotes[j].Price {
+			return quotes[i].Price < quotes[j].Price
+		}
+		if quotes[i].Courier != quotes[j].Courier {
+			return quotes[i].Courier < quotes[j].Courier
+		}
+		return quotes[i].Service < quotes[j].Service
+	})
+}
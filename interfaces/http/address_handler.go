@@ -0,0 +1,175 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+type AddressHandler struct {
+	addressUseCase usecases.AddressUseCase
+}
+
+func NewAddressHandler(addressUseCase usecases.AddressUseCase) *AddressHandler {
+	return &AddressHandler{
+		addressUseCase: addressUseCase,
+	}
+}
+
+func (h *AddressHandler) ListAddresses(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := h.addressUseCase.ListAddresses(c.UserContext(), actor)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": addresses,
+	})
+}
+
+func (h *AddressHandler) GetAddress(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "address")
+	if err != nil {
+		return err
+	}
+
+	address, err := h.addressUseCase.GetAddress(c.UserContext(), actor, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": address,
+	})
+}
+
+func (h *AddressHandler) CreateAddress(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	var req dtos.AddressRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	address := newAddress(&req)
+	if err := h.addressUseCase.CreateAddress(c.UserContext(), actor, address); err != nil {
+		return err
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Address created successfully",
+		"data":    address,
+	})
+}
+
+func (h *AddressHandler) UpdateAddress(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "address")
+	if err != nil {
+		return err
+	}
+
+	var req dtos.AddressRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	address := newAddress(&req)
+	address.ID = id
+	if err := h.addressUseCase.UpdateAddress(c.UserContext(), actor, address); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Address updated successfully",
+		"data":    address,
+	})
+}
+
+func (h *AddressHandler) DeleteAddress(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "address")
+	if err != nil {
+		return err
+	}
+
+	if err := h.addressUseCase.DeleteAddress(c.UserContext(), actor, id); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Address deleted successfully",
+		"data": fiber.Map{
+			"id": id,
+		},
+	})
+}
+
+func (h *AddressHandler) ListProvinces(c *fiber.Ctx) error {
+	provinces, err := h.addressUseCase.ListProvinces(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": provinces,
+	})
+}
+
+func (h *AddressHandler) ListCities(c *fiber.Ctx) error {
+	cities, err := h.addressUseCase.ListCities(c.UserContext(), c.Params("code"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": cities,
+	})
+}
+
+func (h *AddressHandler) ListDistricts(c *fiber.Ctx) error {
+	districts, err := h.addressUseCase.ListDistricts(c.UserContext(), c.Params("code"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": districts,
+	})
+}
+
+func newAddress(req *dtos.AddressRequest) *entities.Address {
+	return &entities.Address{
+		Label:         req.Label,
+		RecipientName: req.RecipientName,
+		Phone:         req.Phone,
+		Street:        req.Street,
+		ProvinceCode:  req.ProvinceCode,
+		CityCode:      req.CityCode,
+		DistrictCode:  req.DistrictCode,
+		PostalCode:    req.PostalCode,
+		IsDefault:     req.IsDefault,
+	}
+}
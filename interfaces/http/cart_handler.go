@@ -0,0 +1,134 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+// CartTokenHeader carries the anonymous cart token of guests.
+const CartTokenHeader = "X-Cart-Token"
+
+type CartHandler struct {
+	cartUseCase usecases.CartUseCase
+}
+
+func NewCartHandler(cartUseCase usecases.CartUseCase) *CartHandler {
+	return &CartHandler{
+		cartUseCase: cartUseCase,
+	}
+}
+
+func (h *CartHandler) GetCart(c *fiber.Ctx) error {
+	cart, err := h.cartUseCase.GetCart(c.UserContext(), cartOwner(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": cart,
+	})
+}
+
+func (h *CartHandler) AddItem(c *fiber.Ctx) error {
+	var req dtos.AddCartItemRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	cart, err := h.cartUseCase.AddItem(c.UserContext(), cartOwner(c), req.ProductID, req.Quantity)
+	if err != nil {
+		return err
+	}
+
+	return h.respond(c, "Item added to cart", cart)
+}
+
+func (h *CartHandler) UpdateItem(c *fiber.Ctx) error {
+	productID, err := paramID(c, "productId", "product")
+	if err != nil {
+		return err
+	}
+
+	var req dtos.UpdateCartItemRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	cart, err := h.cartUseCase.UpdateItem(c.UserContext(), cartOwner(c), productID, req.Quantity)
+	if err != nil {
+		return err
+	}
+
+	return h.respond(c, "Cart item updated", cart)
+}
+
+func (h *CartHandler) RemoveItem(c *fiber.Ctx) error {
+	productID, err := paramID(c, "productId", "product")
+	if err != nil {
+		return err
+	}
+
+	cart, err := h.cartUseCase.RemoveItem(c.UserContext(), cartOwner(c), productID)
+	if err != nil {
+		return err
+	}
+
+	return h.respond(c, "Item removed from cart", cart)
+}
+
+func (h *CartHandler) ClearCart(c *fiber.Ctx) error {
+	cart, err := h.cartUseCase.ClearCart(c.UserContext(), cartOwner(c))
+	if err != nil {
+		return err
+	}
+
+	return h.respond(c, "Cart cleared", cart)
+}
+
+func (h *CartHandler) ApplyCoupon(c *fiber.Ctx) error {
+	var req dtos.ApplyCouponRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	cart, err := h.cartUseCase.ApplyCoupon(c.UserContext(), cartOwner(c), req.Code)
+	if err != nil {
+		return err
+	}
+
+	return h.respond(c, "Coupon applied", cart)
+}
+
+func (h *CartHandler) RemoveCoupon(c *fiber.Ctx) error {
+	cart, err := h.cartUseCase.RemoveCoupon(c.UserContext(), cartOwner(c))
+	if err != nil {
+		return err
+	}
+
+	return h.respond(c, "Coupon removed", cart)
+}
+
+// respond also returns a newly issued guest cart token in the X-Cart-Token
+// header.
+func (h *CartHandler) respond(c *fiber.Ctx, message string, cart *entities.Cart) error {
+	if cart.Token != "" {
+		c.Set(CartTokenHeader, cart.Token)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": message,
+		"data":    cart,
+	})
+}
+
+// cartOwner identifies the cart of the request: the signed in user's cart, or
+// the guest cart named by the X-Cart-Token header.
+func cartOwner(c *fiber.Ctx) usecases.CartOwner {
+	if userID, ok := middleware.GetUserID(c); ok {
+		return usecases.CartOwner{UserID: userID}
+	}
+	return usecases.CartOwner{GuestToken: c.Get(CartTokenHeader)}
+}
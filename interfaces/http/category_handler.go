@@ -0,0 +1,140 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+type CategoryHandler struct {
+	categoryUseCase usecases.CategoryUseCase
+}
+
+func NewCategoryHandler(categoryUseCase usecases.CategoryUseCase) *CategoryHandler {
+	return &CategoryHandler{
+		categoryUseCase: categoryUseCase,
+	}
+}
+
+func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
+	var req dtos.CreateCategoryRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	category := &entities.Category{
+		Name:        req.Name,
+		Description: req.Description,
+		ImageURL:    req.ImageURL,
+		IsActive:    true,
+	}
+	if req.IsActive != nil {
+		category.IsActive = *req.IsActive
+	}
+
+	if err := h.categoryUseCase.CreateCategory(c.UserContext(), category); err != nil {
+		return err
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Category created successfully",
+		"data":    category,
+	})
+}
+
+func (h *CategoryHandler) GetCategory(c *fiber.Ctx) error {
+	category, err := h.categoryUseCase.GetCategoryBySlug(c.UserContext(), c.Params("slug"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": category,
+	})
+}
+
+func (h *CategoryHandler) UpdateCategory(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid category ID")
+	}
+
+	var req dtos.UpdateCategoryRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	category, err := h.categoryUseCase.GetCategoryByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	if req.Name != nil {
+		category.Name = *req.Name
+	}
+	if req.Description != nil {
+		category.Description = *req.Description
+	}
+	if req.ImageURL != nil {
+		category.ImageURL = *req.ImageURL
+	}
+	if req.IsActive != nil {
+		category.IsActive = *req.IsActive
+	}
+
+	if err := h.categoryUseCase.UpdateCategory(c.UserContext(), category); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Category updated successfully",
+		"data":    category,
+	})
+}
+
+func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid category ID")
+	}
+
+	if err := h.categoryUseCase.DeleteCategory(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Category deleted successfully",
+		"data": fiber.Map{
+			"id": id,
+		},
+	})
+}
+
+func (h *CategoryHandler) ListCategories(c *fiber.Ctx) error {
+	return h.listCategories(c, false)
+}
+
+// ListAllCategories includes inactive categories for administrators.
+func (h *CategoryHandler) ListAllCategories(c *fiber.Ctx) error {
+	return h.listCategories(c, true)
+}
+
+func (h *CategoryHandler) listCategories(c *fiber.Ctx, includeInactive bool) error {
+	params, err := pageParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.categoryUseCase.ListCategories(c.UserContext(), includeInactive, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+type CouponHandler struct {
+	couponUseCase usecases.CouponUseCase
+}
+
+func NewCouponHandler(couponUseCase usecases.CouponUseCase) *CouponHandler {
+	return &CouponHandler{
+		couponUseCase: couponUseCase,
+	}
+}
+
+func (h *CouponHandler) ListCoupons(c *fiber.Ctx) error {
+	params, err := pageParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.couponUseCase.ListCoupons(c.UserContext(), params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *CouponHandler) GetCoupon(c *fiber.Ctx) error {
+	id, err := paramID(c, "id", "coupon")
+	if err != nil {
+		return err
+	}
+
+	coupon, err := h.couponUseCase.GetCoupon(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": coupon,
+	})
+}
+
+func (h *CouponHandler) CreateCoupon(c *fiber.Ctx) error {
+	var req dtos.CouponRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	coupon := newCoupon(&req)
+	if err := h.couponUseCase.CreateCoupon(c.UserContext(), coupon); err != nil {
+		return err
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Coupon created successfully",
+		"data":    coupon,
+	})
+}
+
+func (h *CouponHandler) UpdateCoupon(c *fiber.Ctx) error {
+	id, err := paramID(c, "id", "coupon")
+	if err != nil {
+		return err
+	}
+
+	var req dtos.CouponRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	coupon := newCoupon(&req)
+	coupon.ID = id
+	if err := h.couponUseCase.UpdateCoupon(c.UserContext(), coupon); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Coupon updated successfully",
+		"data":    coupon,
+	})
+}
+
+func (h *CouponHandler) DeleteCoupon(c *fiber.Ctx) error {
+	id, err := paramID(c, "id", "coupon")
+	if err != nil {
+		return err
+	}
+
+	if err := h.couponUseCase.DeleteCoupon(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Coupon deleted successfully",
+		"data": fiber.Map{
+			"id": id,
+		},
+	})
+}
+
+func newCoupon(req *dtos.CouponRequest) *entities.Coupon {
+	coupon := &entities.Coupon{
+		Code:         req.Code,
+		Description:  req.Description,
+		Type:         req.Type,
+		Value:        req.Value,
+		MaxDiscount:  req.MaxDiscount,
+		MinSpend:     req.MinSpend,
+		UsageLimit:   req.UsageLimit,
+		PerUserLimit: req.PerUserLimit,
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAt,
+		IsActive:     true,
+		ProductIDs:   req.ProductIDs,
+		CategoryIDs:  req.CategoryIDs,
+		TagIDs:       req.TagIDs,
+		SellerIDs:    req.SellerIDs,
+	}
+	if req.IsActive != nil {
+		coupon.IsActive = *req.IsActive
+	}
+	return coupon
+}
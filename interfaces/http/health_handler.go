@@ -0,0 +1,45 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/health"
+)
+
+type HealthHandler struct {
+	registry *health.Registry
+}
+
+func NewHealthHandler(registry *health.Registry) *HealthHandler {
+	return &HealthHandler{
+		registry: registry,
+	}
+}
+
+// Livez godoc
+// @Summary Liveness probe
+// @Description Reports that the process is running; it checks no dependencies
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string "Process is alive"
+// @Router /livez [get]
+func (h *HealthHandler) Livez(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"status": health.StatusUp,
+	})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Runs the registered health checks, such as a database ping, and reports each component
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report "Ready to take traffic"
+// @Failure 503 {object} health.Report "A dependency is down"
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *fiber.Ctx) error {
+	report := h.registry.Check(c.UserContext())
+	if !report.IsUp() {
+		c.Status(503)
+	}
+	return c.JSON(report)
+}
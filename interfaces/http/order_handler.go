@@ -0,0 +1,181 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+)
+
+type OrderHandler struct {
+	orderUseCase usecases.OrderUseCase
+}
+
+func NewOrderHandler(orderUseCase usecases.OrderUseCase) *OrderHandler {
+	return &OrderHandler{
+		orderUseCase: orderUseCase,
+	}
+}
+
+func (h *OrderHandler) Checkout(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	var req dtos.CheckoutRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	order, err := h.orderUseCase.Checkout(c.UserContext(), actor, req.AddressID, req.Courier, req.Service, req.Notes)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Order placed successfully",
+		"data":    order,
+	})
+}
+
+// QuoteShipping lists the courier services to choose from at checkout.
+func (h *OrderHandler) QuoteShipping(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	var query dtos.ShippingQuoteQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+
+	quotes, err := h.orderUseCase.QuoteShipping(c.UserContext(), actor, query.AddressID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": quotes,
+	})
+}
+
+func (h *OrderHandler) GetOrder(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "order")
+	if err != nil {
+		return err
+	}
+
+	order, err := h.orderUseCase.GetOrder(c.UserContext(), actor, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": order,
+	})
+}
+
+func (h *OrderHandler) ListOwnOrders(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	status, params, err := orderListParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.orderUseCase.ListOwnOrders(c.UserContext(), actor, status, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *OrderHandler) ListSales(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	status, params, err := orderListParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.orderUseCase.ListSales(c.UserContext(), actor, status, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *OrderHandler) ListAllOrders(c *fiber.Ctx) error {
+	status, params, err := orderListParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.orderUseCase.ListAllOrders(c.UserContext(), status, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *OrderHandler) UpdateStatus(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "order")
+	if err != nil {
+		return err
+	}
+
+	var req dtos.UpdateOrderStatusRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	order, err := h.orderUseCase.UpdateStatus(c.UserContext(), actor, id, req.Status, req.Note)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Order status updated",
+		"data":    order,
+	})
+}
+
+func orderListParams(c *fiber.Ctx) (string, pagination.Params, error) {
+	var query dtos.OrderListQuery
+	if err := parseQuery(c, &query); err != nil {
+		return "", pagination.Params{}, err
+	}
+
+	params, err := pageParams(c)
+	if err != nil {
+		return "", pagination.Params{}, err
+	}
+	return query.Status, params, nil
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	nethttp "net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+)
+
+type PaymentHandler struct {
+	paymentUseCase usecases.PaymentUseCase
+}
+
+func NewPaymentHandler(paymentUseCase usecases.PaymentUseCase) *PaymentHandler {
+	return &PaymentHandler{
+		paymentUseCase: paymentUseCase,
+	}
+}
+
+func (h *PaymentHandler) CreatePayment(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "order")
+	if err != nil {
+		return err
+	}
+
+	payment, err := h.paymentUseCase.CreatePayment(c.UserContext(), actor, id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Payment created successfully",
+		"data":    payment,
+	})
+}
+
+func (h *PaymentHandler) ListPayments(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "order")
+	if err != nil {
+		return err
+	}
+
+	payments, err := h.paymentUseCase.ListPayments(c.UserContext(), actor, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": payments,
+	})
+}
+
+func (h *PaymentHandler) Refund(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "order")
+	if err != nil {
+		return err
+	}
+
+	var req dtos.RefundOrderRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	order, err := h.paymentUseCase.Refund(c.UserContext(), actor, id, req.Reason)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Order refunded successfully",
+		"data":    order,
+	})
+}
+
+// Webhook receives payment notifications from the gateway. The raw body is
+// passed on untouched because the signature covers it.
+func (h *PaymentHandler) Webhook(c *fiber.Ctx) error {
+	payment, err := h.paymentUseCase.HandleNotification(c.UserContext(), c.Body(), nethttp.Header(c.GetReqHeaders()))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Notification processed",
+		"data": fiber.Map{
+			"payment_id": payment.ID,
+			"status":     payment.Status,
+		},
+	})
+}
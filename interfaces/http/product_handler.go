@@ -0,0 +1,507 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/repositories"
+)
+
+type ProductHandler struct {
+	productUseCase usecases.ProductUseCase
+}
+
+func NewProductHandler(productUseCase usecases.ProductUseCase) *ProductHandler {
+	return &ProductHandler{
+		productUseCase: productUseCase,
+	}
+}
+
+func (h *ProductHandler) CreateProduct(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	var req dtos.CreateProductRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	product := &entities.Product{
+		Name:             req.Name,
+		SKU:              req.SKU,
+		Description:      req.Description,
+		ShortDescription: req.ShortDescription,
+		Price:            req.Price,
+		DiscountPrice:    req.DiscountPrice,
+		StockQuantity:    req.StockQuantity,
+		Weight:           req.Weight,
+		IsActive:         true,
+		IsFeatured:       req.IsFeatured,
+		Status:           req.Status,
+	}
+	if req.IsActive != nil {
+		product.IsActive = *req.IsActive
+	}
+
+	if err := h.productUseCase.CreateProduct(c.UserContext(), actor, product); err != nil {
+		return err
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Product created successfully",
+		"data":    product,
+	})
+}
+
+func (h *ProductHandler) GetProduct(c *fiber.Ctx) error {
+	var (
+		product *entities.Product
+		err     error
+	)
+
+	// The parameter accepts either the numeric ID or the slug.
+	if id, parseErr := strconv.ParseInt(c.Params("id"), 10, 64); parseErr == nil {
+		product, err = h.productUseCase.GetProduct(c.UserContext(), id)
+	} else {
+		product, err = h.productUseCase.GetProductBySlug(c.UserContext(), c.Params("id"))
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": product,
+	})
+}
+
+func (h *ProductHandler) GetOwnProduct(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "product")
+	if err != nil {
+		return err
+	}
+
+	product, err := h.productUseCase.GetManagedProduct(c.UserContext(), actor, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": product,
+	})
+}
+
+func (h *ProductHandler) UpdateProduct(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "product")
+	if err != nil {
+		return err
+	}
+
+	var req dtos.UpdateProductRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	product, err := h.productUseCase.GetManagedProduct(c.UserContext(), actor, id)
+	if err != nil {
+		return err
+	}
+
+	if req.Name != nil {
+		product.Name = *req.Name
+	}
+	if req.SKU != nil {
+		product.SKU = *req.SKU
+	}
+	if req.Description != nil {
+		product.Description = *req.Description
+	}
+	if req.ShortDescription != nil {
+		product.ShortDescription = *req.ShortDescription
+	}
+	if req.Price != nil {
+		product.Price = *req.Price
+	}
+	if req.DiscountPrice != nil {
+		// A discount price of 0 removes the discount.
+		if *req.DiscountPrice == 0 {
+			product.DiscountPrice = nil
+		} else {
+			product.DiscountPrice = req.DiscountPrice
+		}
+	}
+	if req.StockQuantity != nil {
+		product.StockQuantity = *req.StockQuantity
+	}
+	if req.Weight != nil {
+		product.Weight = req.Weight
+	}
+	if req.IsActive != nil {
+		product.IsActive = *req.IsActive
+	}
+	if req.IsFeatured != nil {
+		product.IsFeatured = *req.IsFeatured
+	}
+	if req.Status != nil {
+		product.Status = *req.Status
+	}
+
+	if err := h.productUseCase.UpdateProduct(c.UserContext(), actor, product); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Product updated successfully",
+		"data":    product,
+	})
+}
+
+func (h *ProductHandler) DeleteProduct(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "product")
+	if err != nil {
+		return err
+	}
+
+	if err := h.productUseCase.DeleteProduct(c.UserContext(), actor, id); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Product deleted successfully",
+		"data": fiber.Map{
+			"id": id,
+		},
+	})
+}
+
+func (h *ProductHandler) ListProducts(c *fiber.Ctx) error {
+	filter, params, err := productListParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.productUseCase.ListProducts(c.UserContext(), filter, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *ProductHandler) SearchProducts(c *fiber.Ctx) error {
+	var query dtos.ProductSearchQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+
+	filter, params, err := productListParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.productUseCase.SearchProducts(c.UserContext(), query.Q, filter, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *ProductHandler) SuggestProducts(c *fiber.Ctx) error {
+	var query dtos.ProductSuggestQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+
+	suggestions, err := h.productUseCase.SuggestProducts(c.UserContext(), query.Q, query.Limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": suggestions,
+	})
+}
+
+func (h *ProductHandler) ListOwnProducts(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	filter, params, err := productListParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.productUseCase.ListOwnProducts(c.UserContext(), actor, filter, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *ProductHandler) ListCategoryProducts(c *fiber.Ctx) error {
+	filter, params, err := productListParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.productUseCase.ListProductsByCategory(c.UserContext(), c.Params("slug"), filter, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *ProductHandler) ListTagProducts(c *fiber.Ctx) error {
+	filter, params, err := productListParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.productUseCase.ListProductsByTag(c.UserContext(), c.Params("slug"), filter, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *ProductHandler) AttachCategories(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "product")
+	if err != nil {
+		return err
+	}
+
+	var req dtos.AttachCategoriesRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	product, err := h.productUseCase.AttachCategories(c.UserContext(), actor, id, req.CategoryIDs)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Categories attached successfully",
+		"data":    product,
+	})
+}
+
+func (h *ProductHandler) DetachCategory(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "product")
+	if err != nil {
+		return err
+	}
+
+	categoryID, err := strconv.Atoi(c.Params("categoryId"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid category ID")
+	}
+
+	product, err := h.productUseCase.DetachCategory(c.UserContext(), actor, id, categoryID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Category detached successfully",
+		"data":    product,
+	})
+}
+
+func (h *ProductHandler) AttachTags(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "product")
+	if err != nil {
+		return err
+	}
+
+	var req dtos.AttachTagsRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	product, err := h.productUseCase.AttachTags(c.UserContext(), actor, id, req.TagIDs)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tags attached successfully",
+		"data":    product,
+	})
+}
+
+func (h *ProductHandler) DetachTag(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "product")
+	if err != nil {
+		return err
+	}
+
+	tagID, err := strconv.Atoi(c.Params("tagId"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid tag ID")
+	}
+
+	product, err := h.productUseCase.DetachTag(c.UserContext(), actor, id, tagID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tag detached successfully",
+		"data":    product,
+	})
+}
+
+func (h *ProductHandler) AdjustStock(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "product")
+	if err != nil {
+		return err
+	}
+
+	var req dtos.AdjustStockRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	movement := &entities.StockMovement{
+		ProductID:   id,
+		Type:        req.Type,
+		StockChange: req.Quantity,
+		OrderID:     req.OrderID,
+		Reason:      req.Reason,
+	}
+	if err := h.productUseCase.AdjustStock(c.UserContext(), actor, movement); err != nil {
+		return err
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Stock adjusted successfully",
+		"data":    movement,
+	})
+}
+
+func (h *ProductHandler) GetStockHistory(c *fiber.Ctx) error {
+	actor, err := currentActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := paramID(c, "id", "product")
+	if err != nil {
+		return err
+	}
+
+	params, err := pageParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.productUseCase.ListStockHistory(c.UserContext(), actor, id, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+// productListParams reads the product filters and pagination parameters from
+// the query string.
+func productListParams(c *fiber.Ctx) (repositories.ProductFilter, pagination.Params, error) {
+	var query dtos.ProductListQuery
+	if err := parseQuery(c, &query); err != nil {
+		return repositories.ProductFilter{}, pagination.Params{}, err
+	}
+
+	params, err := pageParams(c)
+	if err != nil {
+		return repositories.ProductFilter{}, pagination.Params{}, err
+	}
+
+	filter := repositories.ProductFilter{
+		UserID:        query.SellerID,
+		Status:        query.Status,
+		Featured:      query.Featured,
+		InStock:       query.InStock,
+		MinPrice:      query.MinPrice,
+		MaxPrice:      query.MaxPrice,
+		CategorySlugs: splitList(query.Category),
+		TagSlugs:      splitList(query.Tag),
+		Sort:          query.Sort,
+	}
+	return filter, params, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/pagination"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/validation"
+)
+
+// parseBody parses the request body into req and validates it against its
+// `validate` struct tags. It returns a 400 AppError for malformed bodies and
+// a 422 AppError listing the invalid fields when validation fails.
+func parseBody(c *fiber.Ctx, req interface{}) error {
+	if err := c.BodyParser(req); err != nil {
+		return apperrors.NewBadRequestError("Invalid request body")
+	}
+
+	if err := validation.Validate(req); err != nil {
+		if validationErrs, ok := err.(validation.Errors); ok {
+			return apperrors.NewValidationFailedError(validationErrs)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// parseQuery parses the query string into req and validates it like
+// parseBody does for bodies.
+func parseQuery(c *fiber.Ctx, req interface{}) error {
+	if err := c.QueryParser(req); err != nil {
+		return apperrors.NewBadRequestError("Invalid query parameters")
+	}
+
+	if err := validation.Validate(req); err != nil {
+		if validationErrs, ok := err.(validation.Errors); ok {
+			return apperrors.NewValidationFailedError(validationErrs)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// pageParams reads the limit, cursor and include_total query parameters
+// shared by every list endpoint.
+func pageParams(c *fiber.Ctx) (pagination.Params, error) {
+	var query dtos.PageQuery
+	if err := parseQuery(c, &query); err != nil {
+		return pagination.Params{}, err
+	}
+	return pagination.NewParams(query.Limit, query.Cursor, query.IncludeTotal)
+}
+
+// currentActor returns the authenticated user as a use case Actor.
+func currentActor(c *fiber.Ctx) (usecases.Actor, error) {
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		return usecases.Actor{}, apperrors.ErrUnauthorized
+	}
+	return usecases.Actor{UserID: claims.UserID, RoleID: claims.RoleID}, nil
+}
+
+// paramID parses the int64 route parameter name, returning a 400 AppError
+// mentioning resource when it is not a number.
+func paramID(c *fiber.Ctx, name, resource string) (int64, error) {
+	id, err := strconv.ParseInt(c.Params(name), 10, 64)
+	if err != nil {
+		return 0, apperrors.NewBadRequestError("Invalid " + resource + " ID")
+	}
+	return id, nil
+}
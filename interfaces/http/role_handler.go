@@ -0,0 +1,158 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+type RoleHandler struct {
+	roleUseCase usecases.RoleUseCase
+}
+
+func NewRoleHandler(roleUseCase usecases.RoleUseCase) *RoleHandler {
+	return &RoleHandler{
+		roleUseCase: roleUseCase,
+	}
+}
+
+func (h *RoleHandler) CreateRole(c *fiber.Ctx) error {
+	var req dtos.CreateRoleRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	role := &entities.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := h.roleUseCase.CreateRole(c.UserContext(), role); err != nil {
+		return err
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Role created successfully",
+		"data":    role,
+	})
+}
+
+func (h *RoleHandler) GetRole(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid role ID")
+	}
+
+	role, err := h.roleUseCase.GetRoleByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": role,
+	})
+}
+
+func (h *RoleHandler) UpdateRole(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid role ID")
+	}
+
+	var req dtos.UpdateRoleRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	role, err := h.roleUseCase.GetRoleByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	if req.Name != "" {
+		role.Name = req.Name
+	}
+	if req.Description != "" {
+		role.Description = req.Description
+	}
+
+	if err := h.roleUseCase.UpdateRole(c.UserContext(), role); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Role updated successfully",
+		"data":    role,
+	})
+}
+
+func (h *RoleHandler) DeleteRole(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid role ID")
+	}
+
+	if err := h.roleUseCase.DeleteRole(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Role deleted successfully",
+		"data": fiber.Map{
+			"id": id,
+		},
+	})
+}
+
+func (h *RoleHandler) ListRoles(c *fiber.Ctx) error {
+	params, err := pageParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.roleUseCase.ListRoles(c.UserContext(), params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *RoleHandler) SetRolePermissions(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid role ID")
+	}
+
+	var req dtos.SetRolePermissionsRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	role, err := h.roleUseCase.SetRolePermissions(c.UserContext(), id, req.Permissions)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Role permissions updated successfully",
+		"data":    role,
+	})
+}
+
+func (h *RoleHandler) ListPermissions(c *fiber.Ctx) error {
+	permissions, err := h.roleUseCase.ListPermissions(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": permissions,
+	})
+}
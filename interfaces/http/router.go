@@ -1,45 +1,264 @@
 package http
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
 )
 
 type Router struct {
-	app         *fiber.App
-	userHandler *UserHandler
+	app                 *fiber.App
+	cfg                 *config.Config
+	permissions         middleware.PermissionChecker
+	revocations         middleware.TokenRevocationChecker
+	verifications       middleware.VerificationChecker
+	idempotencyKeys     middleware.IdempotencyStore
+	healthHandler       *HealthHandler
+	userHandler         *UserHandler
+	roleHandler         *RoleHandler
+	productHandler      *ProductHandler
+	categoryHandler     *CategoryHandler
+	tagHandler          *TagHandler
+	cartHandler         *CartHandler
+	orderHandler        *OrderHandler
+	paymentHandler      *PaymentHandler
+	addressHandler      *AddressHandler
+	shippingRateHandler *ShippingRateHandler
+	couponHandler       *CouponHandler
+	trashHandler        *TrashHandler
+}
+
+// RouterDeps holds what the routes need. Named fields keep main.go from
+// silently swapping two dependencies that share an interface.
+type RouterDeps struct {
+	Config              *config.Config
+	Permissions         middleware.PermissionChecker
+	Revocations         middleware.TokenRevocationChecker
+	Verifications       middleware.VerificationChecker
+	IdempotencyKeys     middleware.IdempotencyStore
+	HealthHandler       *HealthHandler
+	UserHandler         *UserHandler
+	RoleHandler         *RoleHandler
+	ProductHandler      *ProductHandler
+	CategoryHandler     *CategoryHandler
+	TagHandler          *TagHandler
+	CartHandler         *CartHandler
+	OrderHandler        *OrderHandler
+	PaymentHandler      *PaymentHandler
+	AddressHandler      *AddressHandler
+	ShippingRateHandler *ShippingRateHandler
+	CouponHandler       *CouponHandler
+	TrashHandler        *TrashHandler
 }
 
-func NewRouter(app *fiber.App, userHandler *UserHandler) *Router {
+func NewRouter(app *fiber.App, deps RouterDeps) *Router {
 	return &Router{
-		app:         app,
-		userHandler: userHandler,
+		app:                 app,
+		cfg:                 deps.Config,
+		permissions:         deps.Permissions,
+		revocations:         deps.Revocations,
+		verifications:       deps.Verifications,
+		idempotencyKeys:     deps.IdempotencyKeys,
+		healthHandler:       deps.HealthHandler,
+		userHandler:         deps.UserHandler,
+		roleHandler:         deps.RoleHandler,
+		productHandler:      deps.ProductHandler,
+		categoryHandler:     deps.CategoryHandler,
+		tagHandler:          deps.TagHandler,
+		cartHandler:         deps.CartHandler,
+		orderHandler:        deps.OrderHandler,
+		paymentHandler:      deps.PaymentHandler,
+		addressHandler:      deps.AddressHandler,
+		shippingRateHandler: deps.ShippingRateHandler,
+		couponHandler:       deps.CouponHandler,
+		trashHandler:        deps.TrashHandler,
 	}
 }
 
 func (r *Router) SetupRoutes() {
-	api := r.app.Group("/api/v1")
+	// Probes for the orchestrator: /livez only says the process runs, /readyz
+	// checks the dependencies too.
+	r.app.Get("/livez", r.healthHandler.Livez)
+	r.app.Get("/readyz", r.healthHandler.Readyz)
+
+	api := r.app.Group("/api/v1", middleware.RequestTimeout(r.requestTimeout()))
 
-	api.Get("/health", r.HealthCheck)
+	api.Get("/health", r.healthHandler.Readyz)
+
+	auth := middleware.AuthMiddleware(r.cfg.JWTSecret, r.revocations)
+	ownerOrAdmin := middleware.RequireOwnerOrPermission(r.permissions, "id", entities.PermissionUsersManage)
+	// Every POST and PUT can be retried safely with an Idempotency-Key
+	// header, except login, token refresh, logout, verification and password
+	// resets, whose tokens must never be stored for replay, and the payment
+	// webhook, which has its own deduplication.
+	idempotent := middleware.Idempotency(r.idempotencyKeys, r.idempotencyKeyTTL())
 
 	users := api.Group("/users")
-	users.Post("/register", r.userHandler.Register)
+	users.Post("/register", idempotent, r.userHandler.Register)
 	users.Post("/login", r.userHandler.Login)
-	users.Get("/:id", r.userHandler.GetUser)
-	users.Put("/:id", r.userHandler.UpdateUser)
-	users.Delete("/:id", r.userHandler.DeleteUser)
-	users.Get("/", r.userHandler.ListUsers)
-}
-
-// HealthCheck godoc
-// @Summary Health check endpoint
-// @Description Check if the API is running
-// @Tags health
-// @Accept json
-// @Produce json
-// @Success 200 {object} map[string]string "API is healthy"
-// @Router /health [get]
-func (r *Router) HealthCheck(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{
-		"status": "healthy",
+	users.Post("/refresh", r.userHandler.Refresh)
+	users.Get("/verify", r.userHandler.VerifyEmail)
+	users.Post("/verify/resend", emailLimiter(), r.userHandler.ResendVerification)
+	users.Post("/forgot-password", emailLimiter(), r.userHandler.ForgotPassword)
+	users.Post("/reset-password", r.userHandler.ResetPassword)
+	users.Post("/logout", auth, r.userHandler.Logout)
+	users.Post("/logout-all", auth, r.userHandler.LogoutAll)
+	users.Get("/me", auth, r.userHandler.Me)
+
+	addresses := users.Group("/me/addresses", auth, idempotent)
+	addresses.Get("/", r.addressHandler.ListAddresses)
+	addresses.Post("/", r.addressHandler.CreateAddress)
+	addresses.Get("/:id", r.addressHandler.GetAddress)
+	addresses.Put("/:id", r.addressHandler.UpdateAddress)
+	addresses.Delete("/:id", r.addressHandler.DeleteAddress)
+
+	users.Get("/:id", auth, ownerOrAdmin, r.userHandler.GetUser)
+	users.Put("/:id", auth, idempotent, ownerOrAdmin, r.userHandler.UpdateUser)
+	users.Delete("/:id", auth, ownerOrAdmin, r.userHandler.DeleteUser)
+	users.Get("/", auth, r.require(entities.PermissionUsersList), r.userHandler.ListUsers)
+
+	regions := api.Group("/regions")
+	regions.Get("/provinces", r.addressHandler.ListProvinces)
+	regions.Get("/provinces/:code/cities", r.addressHandler.ListCities)
+	regions.Get("/cities/:code/districts", r.addressHandler.ListDistricts)
+
+	roles := api.Group("/roles", auth, idempotent, r.require(entities.PermissionRolesManage))
+	roles.Get("/", r.roleHandler.ListRoles)
+	roles.Post("/", r.roleHandler.CreateRole)
+	roles.Get("/:id", r.roleHandler.GetRole)
+	roles.Put("/:id", r.roleHandler.UpdateRole)
+	roles.Delete("/:id", r.roleHandler.DeleteRole)
+	roles.Put("/:id/permissions", r.roleHandler.SetRolePermissions)
+
+	api.Get("/permissions", auth, r.require(entities.PermissionRolesManage), r.roleHandler.ListPermissions)
+
+	productWriter := r.require(entities.PermissionProductsWrite)
+	products := api.Group("/products")
+	products.Get("/", r.productHandler.ListProducts)
+	products.Get("/search", r.productHandler.SearchProducts)
+	products.Get("/suggest", r.productHandler.SuggestProducts)
+	products.Get("/mine", auth, productWriter, r.productHandler.ListOwnProducts)
+	products.Get("/mine/:id", auth, productWriter, r.productHandler.GetOwnProduct)
+	products.Post("/", auth, idempotent, productWriter, r.productHandler.CreateProduct)
+	products.Get("/:id", r.productHandler.GetProduct)
+	products.Put("/:id", auth, idempotent, productWriter, r.productHandler.UpdateProduct)
+	products.Delete("/:id", auth, productWriter, r.productHandler.DeleteProduct)
+	products.Post("/:id/categories", auth, idempotent, productWriter, r.productHandler.AttachCategories)
+	products.Delete("/:id/categories/:categoryId", auth, productWriter, r.productHandler.DetachCategory)
+	products.Post("/:id/tags", auth, idempotent, productWriter, r.productHandler.AttachTags)
+	products.Delete("/:id/tags/:tagId", auth, productWriter, r.productHandler.DetachTag)
+	products.Get("/:id/stock-history", auth, productWriter, r.productHandler.GetStockHistory)
+	products.Post("/:id/stock", auth, idempotent, productWriter, r.productHandler.AdjustStock)
+
+	categoryManager := r.require(entities.PermissionCategoriesManage)
+	categories := api.Group("/categories")
+	categories.Get("/", r.categoryHandler.ListCategories)
+	categories.Get("/all", auth, categoryManager, r.categoryHandler.ListAllCategories)
+	categories.Get("/:slug", r.categoryHandler.GetCategory)
+	categories.Get("/:slug/products", r.productHandler.ListCategoryProducts)
+	categories.Post("/", auth, idempotent, categoryManager, r.categoryHandler.CreateCategory)
+	categories.Put("/:id", auth, idempotent, categoryManager, r.categoryHandler.UpdateCategory)
+	categories.Delete("/:id", auth, categoryManager, r.categoryHandler.DeleteCategory)
+
+	tags := api.Group("/tags")
+	tags.Get("/", r.tagHandler.ListTags)
+	tags.Get("/:slug/products", r.productHandler.ListTagProducts)
+	tags.Post("/", auth, idempotent, categoryManager, r.tagHandler.CreateTag)
+	tags.Put("/:id", auth, idempotent, categoryManager, r.tagHandler.UpdateTag)
+	tags.Delete("/:id", auth, categoryManager, r.tagHandler.DeleteTag)
+
+	// Carts work for guests too; signed in users always get their own cart.
+	cart := api.Group("/cart", middleware.OptionalAuth(r.cfg.JWTSecret, r.revocations), idempotent)
+	cart.Get("/", r.cartHandler.GetCart)
+	cart.Delete("/", r.cartHandler.ClearCart)
+	cart.Post("/items", r.cartHandler.AddItem)
+	cart.Put("/items/:productId", r.cartHandler.UpdateItem)
+	cart.Delete("/items/:productId", r.cartHandler.RemoveItem)
+	cart.Post("/coupon", r.cartHandler.ApplyCoupon)
+	cart.Delete("/coupon", r.cartHandler.RemoveCoupon)
+
+	orders := api.Group("/orders", auth, idempotent)
+	orders.Post("/", r.require(entities.PermissionOrdersCreate), r.requireVerified("checkout"), r.orderHandler.Checkout)
+	orders.Get("/", r.orderHandler.ListOwnOrders)
+	orders.Get("/sales", productWriter, r.orderHandler.ListSales)
+	orders.Get("/all", r.require(entities.PermissionOrdersManage), r.orderHandler.ListAllOrders)
+	orders.Get("/shipping-quotes", r.orderHandler.QuoteShipping)
+	orders.Get("/:id", r.orderHandler.GetOrder)
+	orders.Put("/:id/status", r.orderHandler.UpdateStatus)
+	orders.Post("/:id/payments", r.paymentHandler.CreatePayment)
+	orders.Get("/:id/payments", r.paymentHandler.ListPayments)
+	orders.Post("/:id/refund", r.require(entities.PermissionOrdersManage), r.paymentHandler.Refund)
+
+	shippingRates := api.Group("/shipping-rates", auth, idempotent, r.require(entities.PermissionShippingManage))
+	shippingRates.Get("/", r.shippingRateHandler.ListRates)
+	shippingRates.Get("/:id", r.shippingRateHandler.GetRate)
+	shippingRates.Post("/", r.shippingRateHandler.CreateRate)
+	shippingRates.Put("/:id", r.shippingRateHandler.UpdateRate)
+	shippingRates.Delete("/:id", r.shippingRateHandler.DeleteRate)
+
+	coupons := api.Group("/coupons", auth, idempotent, r.require(entities.PermissionCouponsManage))
+	coupons.Get("/", r.couponHandler.ListCoupons)
+	coupons.Get("/:id", r.couponHandler.GetCoupon)
+	coupons.Post("/", r.couponHandler.CreateCoupon)
+	coupons.Put("/:id", r.couponHandler.UpdateCoupon)
+	coupons.Delete("/:id", r.couponHandler.DeleteCoupon)
+
+	// Deleted users, products and categories until they are purged.
+	trash := api.Group("/trash", auth, idempotent)
+	trash.Get("/users", r.require(entities.PermissionUsersManage), r.trashHandler.ListUsers)
+	trash.Post("/users/:id/restore", r.require(entities.PermissionUsersManage), r.trashHandler.RestoreUser)
+	trash.Get("/products", r.require(entities.PermissionProductsManage), r.trashHandler.ListProducts)
+	trash.Post("/products/:id/restore", r.require(entities.PermissionProductsManage), r.trashHandler.RestoreProduct)
+	trash.Get("/categories", categoryManager, r.trashHandler.ListCategories)
+	trash.Post("/categories/:id/restore", categoryManager, r.trashHandler.RestoreCategory)
+
+	// Called by the payment gateway, which authenticates with a signature.
+	api.Post("/payments/webhook", r.paymentHandler.Webhook)
+}
+
+func (r *Router) require(permissions ...string) fiber.Handler {
+	return middleware.RequirePermission(r.permissions, permissions...)
+}
+
+// requireVerified blocks unverified users from the given action when it is
+// listed in VERIFIED_ACTIONS.
+func (r *Router) requireVerified(action string) fiber.Handler {
+	if !r.cfg.RequiresVerification(action) {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+	return middleware.RequireVerified(r.verifications)
+}
+
+// idempotencyKeyTTL is how long responses are kept for retries with the same
+// Idempotency-Key.
+func (r *Router) idempotencyKeyTTL() time.Duration {
+	ttl, err := time.ParseDuration(r.cfg.IdempotencyKeyTTL)
+	if err != nil {
+		return 24 * time.Hour
+	}
+	return ttl
+}
+
+// requestTimeout is how long a request may take before the database calls
+// it makes are cancelled.
+func (r *Router) requestTimeout() time.Duration {
+	timeout, err := time.ParseDuration(r.cfg.RequestTimeout)
+	if err != nil {
+		return 30 * time.Second
+	}
+	return timeout
+}
+
+// emailLimiter limits how often a client can trigger outgoing emails.
+func emailLimiter() fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        5,
+		Expiration: time.Minute,
 	})
 }
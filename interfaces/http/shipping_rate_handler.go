@@ -0,0 +1,128 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+type ShippingRateHandler struct {
+	rateUseCase usecases.ShippingRateUseCase
+}
+
+func NewShippingRateHandler(rateUseCase usecases.ShippingRateUseCase) *ShippingRateHandler {
+	return &ShippingRateHandler{
+		rateUseCase: rateUseCase,
+	}
+}
+
+func (h *ShippingRateHandler) ListRates(c *fiber.Ctx) error {
+	params, err := pageParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.rateUseCase.ListRates(c.UserContext(), params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *ShippingRateHandler) GetRate(c *fiber.Ctx) error {
+	id, err := paramID(c, "id", "shipping rate")
+	if err != nil {
+		return err
+	}
+
+	rate, err := h.rateUseCase.GetRate(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": rate,
+	})
+}
+
+func (h *ShippingRateHandler) CreateRate(c *fiber.Ctx) error {
+	var req dtos.ShippingRateRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	rate := newShippingRate(&req)
+	if err := h.rateUseCase.CreateRate(c.UserContext(), rate); err != nil {
+		return err
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Shipping rate created successfully",
+		"data":    rate,
+	})
+}
+
+func (h *ShippingRateHandler) UpdateRate(c *fiber.Ctx) error {
+	id, err := paramID(c, "id", "shipping rate")
+	if err != nil {
+		return err
+	}
+
+	var req dtos.ShippingRateRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	rate := newShippingRate(&req)
+	rate.ID = id
+	if err := h.rateUseCase.UpdateRate(c.UserContext(), rate); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Shipping rate updated successfully",
+		"data":    rate,
+	})
+}
+
+func (h *ShippingRateHandler) DeleteRate(c *fiber.Ctx) error {
+	id, err := paramID(c, "id", "shipping rate")
+	if err != nil {
+		return err
+	}
+
+	if err := h.rateUseCase.DeleteRate(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Shipping rate deleted successfully",
+		"data": fiber.Map{
+			"id": id,
+		},
+	})
+}
+
+func newShippingRate(req *dtos.ShippingRateRequest) *entities.ShippingRate {
+	rate := &entities.ShippingRate{
+		Courier:         req.Courier,
+		Service:         req.Service,
+		Description:     req.Description,
+		OriginCode:      req.OriginCode,
+		DestinationCode: req.DestinationCode,
+		MinWeight:       req.MinWeight,
+		MaxWeight:       req.MaxWeight,
+		Price:           req.Price,
+		EtdMinDays:      req.EtdMinDays,
+		EtdMaxDays:      req.EtdMaxDays,
+		IsActive:        true,
+	}
+	if req.IsActive != nil {
+		rate.IsActive = *req.IsActive
+	}
+	return rate
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+)
+
+type TagHandler struct {
+	tagUseCase usecases.TagUseCase
+}
+
+func NewTagHandler(tagUseCase usecases.TagUseCase) *TagHandler {
+	return &TagHandler{
+		tagUseCase: tagUseCase,
+	}
+}
+
+func (h *TagHandler) CreateTag(c *fiber.Ctx) error {
+	var req dtos.CreateTagRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	tag := &entities.Tag{
+		Name: req.Name,
+	}
+
+	if err := h.tagUseCase.CreateTag(c.UserContext(), tag); err != nil {
+		return err
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"message": "Tag created successfully",
+		"data":    tag,
+	})
+}
+
+func (h *TagHandler) UpdateTag(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid tag ID")
+	}
+
+	var req dtos.UpdateTagRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	tag, err := h.tagUseCase.GetTagByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	tag.Name = req.Name
+
+	if err := h.tagUseCase.UpdateTag(c.UserContext(), tag); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tag updated successfully",
+		"data":    tag,
+	})
+}
+
+func (h *TagHandler) DeleteTag(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid tag ID")
+	}
+
+	if err := h.tagUseCase.DeleteTag(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tag deleted successfully",
+		"data": fiber.Map{
+			"id": id,
+		},
+	})
+}
+
+func (h *TagHandler) ListTags(c *fiber.Ctx) error {
+	params, err := pageParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.tagUseCase.ListTags(c.UserContext(), params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
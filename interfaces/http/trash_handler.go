@@ -0,0 +1,118 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+)
+
+type TrashHandler struct {
+	trashUseCase usecases.TrashUseCase
+}
+
+func NewTrashHandler(trashUseCase usecases.TrashUseCase) *TrashHandler {
+	return &TrashHandler{
+		trashUseCase: trashUseCase,
+	}
+}
+
+func (h *TrashHandler) ListUsers(c *fiber.Ctx) error {
+	params, err := pageParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.trashUseCase.ListDeletedUsers(c.UserContext(), params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *TrashHandler) RestoreUser(c *fiber.Ctx) error {
+	id, err := paramID(c, "id", "user")
+	if err != nil {
+		return err
+	}
+
+	if err := h.trashUseCase.RestoreUser(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "User restored successfully",
+		"data": fiber.Map{
+			"id": id,
+		},
+	})
+}
+
+func (h *TrashHandler) ListProducts(c *fiber.Ctx) error {
+	params, err := pageParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.trashUseCase.ListDeletedProducts(c.UserContext(), params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *TrashHandler) RestoreProduct(c *fiber.Ctx) error {
+	id, err := paramID(c, "id", "product")
+	if err != nil {
+		return err
+	}
+
+	if err := h.trashUseCase.RestoreProduct(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Product restored successfully",
+		"data": fiber.Map{
+			"id": id,
+		},
+	})
+}
+
+func (h *TrashHandler) ListCategories(c *fiber.Ctx) error {
+	params, err := pageParams(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.trashUseCase.ListDeletedCategories(c.UserContext(), params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": page,
+	})
+}
+
+func (h *TrashHandler) RestoreCategory(c *fiber.Ctx) error {
+	id, err := paramID(c, "id", "category")
+	if err != nil {
+		return err
+	}
+
+	if err := h.trashUseCase.RestoreCategory(c.UserContext(), int(id)); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Category restored successfully",
+		"data": fiber.Map{
+			"id": id,
+		},
+	})
+}
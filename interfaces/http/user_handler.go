@@ -6,26 +6,29 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/yourusername/ecommerce-go-vue/backend/application/dtos"
 	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	apperrors "github.com/yourusername/ecommerce-go-vue/backend/common/errors"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/validation"
 	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
 )
 
 type UserHandler struct {
 	userUseCase usecases.UserUseCase
+	cartUseCase usecases.CartUseCase
 }
 
-func NewUserHandler(userUseCase usecases.UserUseCase) *UserHandler {
+func NewUserHandler(userUseCase usecases.UserUseCase, cartUseCase usecases.CartUseCase) *UserHandler {
 	return &UserHandler{
 		userUseCase: userUseCase,
+		cartUseCase: cartUseCase,
 	}
 }
 
 func (h *UserHandler) Register(c *fiber.Ctx) error {
 	var req dtos.RegisterRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	if err := parseBody(c, &req); err != nil {
+		return err
 	}
 
 	user := &entities.User{
@@ -34,7 +37,7 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 		FullName:     req.FullName,
 		Phone:        req.Phone,
 		Gender:       req.Gender,
-		RoleID:       1,
+		RoleID:       entities.RoleCustomer,
 		IsActive:     true,
 		IsVerified:   false,
 	}
@@ -43,10 +46,18 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 		user.Gender = "other"
 	}
 
-	if err := h.userUseCase.Register(user); err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if req.DateOfBirth != "" {
+		dateOfBirth, _ := validation.ParseDate(req.DateOfBirth)
+		user.DateOfBirth = &dateOfBirth
+	}
+
+	var address *entities.Address
+	if req.Address != nil {
+		address = newAddress(req.Address)
+	}
+
+	if err := h.userUseCase.Register(c.UserContext(), user, address); err != nil {
+		return err
 	}
 
 	return c.Status(201).JSON(fiber.Map{
@@ -62,23 +73,30 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 func (h *UserHandler) Login(c *fiber.Ctx) error {
 	var req dtos.LoginRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	if err := parseBody(c, &req); err != nil {
+		return err
 	}
 
-	token, user, err := h.userUseCase.Login(req.Email, req.Password)
+	tokens, user, err := h.userUseCase.Login(c.UserContext(), req.Email, req.Password)
 	if err != nil {
-		return c.Status(401).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+
+	// Items collected as a guest move into the user's cart.
+	cartToken := req.CartToken
+	if cartToken == "" {
+		cartToken = c.Get(CartTokenHeader)
+	}
+	if err := h.cartUseCase.MergeGuestCart(c.UserContext(), user.ID, cartToken); err != nil {
+		return err
 	}
 
 	return c.JSON(fiber.Map{
 		"message": "Login successful",
 		"data": dtos.AuthResponse{
-			Token: token,
+			Token:        tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresIn:    tokens.ExpiresIn,
 			User: fiber.Map{
 				"id":          user.ID,
 				"email":       user.Email,
@@ -91,19 +109,148 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 	})
 }
 
+func (h *UserHandler) VerifyEmail(c *fiber.Ctx) error {
+	if err := h.userUseCase.VerifyEmail(c.UserContext(), c.Query("token")); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Email verified successfully",
+	})
+}
+
+func (h *UserHandler) ResendVerification(c *fiber.Ctx) error {
+	var req dtos.ResendVerificationRequest
+
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.userUseCase.ResendVerification(c.UserContext(), req.Email); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "If the account exists and is not verified yet, a verification email has been sent",
+	})
+}
+
+func (h *UserHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dtos.ForgotPasswordRequest
+
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.userUseCase.ForgotPassword(c.UserContext(), req.Email); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "If the account exists, a password reset email has been sent",
+	})
+}
+
+func (h *UserHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dtos.ResetPasswordRequest
+
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.userUseCase.ResetPassword(c.UserContext(), req.Token, req.Password); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Password has been reset successfully",
+	})
+}
+
+func (h *UserHandler) Refresh(c *fiber.Ctx) error {
+	var req dtos.RefreshTokenRequest
+
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	tokens, err := h.userUseCase.RefreshToken(c.UserContext(), req.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Token refreshed successfully",
+		"data": dtos.AuthResponse{
+			Token:        tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresIn:    tokens.ExpiresIn,
+		},
+	})
+}
+
+func (h *UserHandler) Logout(c *fiber.Ctx) error {
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		return apperrors.ErrUnauthorized
+	}
+
+	var req dtos.LogoutRequest
+	if len(c.Body()) > 0 {
+		if err := parseBody(c, &req); err != nil {
+			return err
+		}
+	}
+
+	if err := h.userUseCase.Logout(c.UserContext(), claims.UserID, claims.ID, req.RefreshToken); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Logged out successfully",
+	})
+}
+
+func (h *UserHandler) LogoutAll(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return apperrors.ErrUnauthorized
+	}
+
+	if err := h.userUseCase.LogoutAll(c.UserContext(), userID); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Logged out from all sessions successfully",
+	})
+}
+
+func (h *UserHandler) Me(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return apperrors.ErrUnauthorized
+	}
+
+	user, err := h.userUseCase.GetUserByID(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"data": user,
+	})
+}
+
 func (h *UserHandler) GetUser(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid user ID",
-		})
+		return apperrors.NewBadRequestError("Invalid user ID")
 	}
 
-	user, err := h.userUseCase.GetUserByID(id)
+	user, err := h.userUseCase.GetUserByID(c.UserContext(), id)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{
-			"error": "User not found",
-		})
+		return err
 	}
 
 	return c.JSON(fiber.Map{
@@ -114,23 +261,17 @@ func (h *UserHandler) GetUser(c *fiber.Ctx) error {
 func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid user ID",
-		})
+		return apperrors.NewBadRequestError("Invalid user ID")
 	}
 
 	var req dtos.UpdateUserRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	if err := parseBody(c, &req); err != nil {
+		return err
 	}
 
-	user, err := h.userUseCase.GetUserByID(id)
+	user, err := h.userUseCase.GetUserByID(c.UserContext(), id)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{
-			"error": "User not found",
-		})
+		return err
 	}
 
 	if req.FullName != "" {
@@ -145,11 +286,13 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	if req.Gender != "" {
 		user.Gender = req.Gender
 	}
+	if req.DateOfBirth != "" {
+		dateOfBirth, _ := validation.ParseDate(req.DateOfBirth)
+		user.DateOfBirth = &dateOfBirth
+	}
 
-	if err := h.userUseCase.UpdateUser(user); err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if err := h.userUseCase.UpdateUser(c.UserContext(), user); err != nil {
+		return err
 	}
 
 	return c.JSON(fiber.Map{
@@ -161,15 +304,11 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid user ID",
-		})
+		return apperrors.NewBadRequestError("Invalid user ID")
 	}
 
-	if err := h.userUseCase.DeleteUser(id); err != nil {
-		return c.Status(404).JSON(fiber.Map{
-			"error": "User not found",
-		})
+	if err := h.userUseCase.DeleteUser(c.UserContext(), id); err != nil {
+		return err
 	}
 
 	return c.JSON(fiber.Map{
@@ -181,22 +320,17 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 }
 
 func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	offset := (page - 1) * limit
+	params, err := pageParams(c)
+	if err != nil {
+		return err
+	}
 
-	users, err := h.userUseCase.ListUsers(offset, limit)
+	page, err := h.userUseCase.ListUsers(c.UserContext(), params)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(fiber.Map{
-		"data": fiber.Map{
-			"users": users,
-			"page":  page,
-			"limit": limit,
-		},
+		"data": page,
 	})
 }
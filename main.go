@@ -1,18 +1,28 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 	"github.com/yourusername/ecommerce-go-vue/backend/application/usecases"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/health"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/logging"
+	"github.com/yourusername/ecommerce-go-vue/backend/common/middleware"
 	_ "github.com/yourusername/ecommerce-go-vue/backend/docs"
 	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/config"
 	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/database"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/mailer"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/payment"
+	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/shipping"
 	http "github.com/yourusername/ecommerce-go-vue/backend/interfaces/http"
 )
 
@@ -32,49 +42,207 @@ import (
 // @BasePath /api/v1
 func main() {
 	cfg := config.LoadConfig()
+	logger := logging.Setup(cfg.AppEnv, cfg.LogLevel)
 
-	if err := database.InitDB(cfg); err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+	db, err := database.Connect(cfg)
+	if err != nil {
+		fatal("Failed to initialize database", err)
 	}
 
 	app := fiber.New(fiber.Config{
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			return c.Status(code).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		},
+		ErrorHandler: middleware.ErrorHandler(cfg.IsProduction()),
 	})
 
+	app.Use(middleware.RequestID())
+	app.Use(middleware.AccessLog(logger))
 	app.Use(recover.New())
-	app.Use(logger.New())
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
+		AllowOrigins:  "*",
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, X-Request-ID, X-Cart-Token, Idempotency-Key",
+		ExposeHeaders: "X-Request-ID, X-Cart-Token, Idempotent-Replayed",
+		AllowMethods:  "GET, POST, PUT, DELETE, OPTIONS",
 	}))
 
-	userRepo := database.NewUserRepository()
-	database.NewRoleRepository()
-	userUseCase := usecases.NewUserUseCase(userRepo, cfg)
+	userRepo := database.NewUserRepository(db)
+	roleRepo := database.NewRoleRepository(db)
+	permissionRepo := database.NewPermissionRepository(db)
+	refreshTokenRepo := database.NewRefreshTokenRepository(db)
+	revokedTokenRepo := database.NewRevokedTokenRepository(db)
+	productRepo := database.NewProductRepository(db)
+	stockMovementRepo := database.NewStockMovementRepository(db)
+	categoryRepo := database.NewCategoryRepository(db)
+	tagRepo := database.NewTagRepository(db)
+	cartRepo := database.NewCartRepository(db)
+	orderRepo := database.NewOrderRepository(db)
+	paymentRepo := database.NewPaymentRepository(db)
+	addressRepo := database.NewAddressRepository(db)
+	regionRepo := database.NewRegionRepository(db)
+	idempotencyKeyRepo := database.NewIdempotencyKeyRepository(db)
+	shippingRateRepo := database.NewShippingRateRepository(db)
+	couponRepo := database.NewCouponRepository(db)
+	txManager := database.NewTxManager(db)
+	mail, err := mailer.New(cfg)
+	if err != nil {
+		fatal("Failed to initialize mailer", err)
+	}
+	gateway, err := payment.New(cfg)
+	if err != nil {
+		fatal("Failed to initialize payment gateway", err)
+	}
+	shippingProvider, err := shipping.New(cfg, shippingRateRepo)
+	if err != nil {
+		fatal("Failed to initialize shipping provider", err)
+	}
 
-	userHandler := http.NewUserHandler(userUseCase)
+	userUseCase := usecases.NewUserUseCase(userRepo, refreshTokenRepo, revokedTokenRepo, txManager, mail, cfg)
+	roleUseCase := usecases.NewRoleUseCase(roleRepo, permissionRepo)
+	productUseCase := usecases.NewProductUseCase(productRepo, categoryRepo, tagRepo, permissionRepo, stockMovementRepo)
+	categoryUseCase := usecases.NewCategoryUseCase(categoryRepo)
+	tagUseCase := usecases.NewTagUseCase(tagRepo)
+	cartUseCase := usecases.NewCartUseCase(cartRepo, productRepo, couponRepo, cfg)
+	orderUseCase := usecases.NewOrderUseCase(orderRepo, cartRepo, addressRepo, couponRepo, permissionRepo, shippingProvider, cfg)
+	paymentUseCase := usecases.NewPaymentUseCase(paymentRepo, orderRepo, orderUseCase, gateway)
+	addressUseCase := usecases.NewAddressUseCase(addressRepo, regionRepo)
+	shippingRateUseCase := usecases.NewShippingRateUseCase(shippingRateRepo)
+	couponUseCase := usecases.NewCouponUseCase(couponRepo)
+	trashUseCase := usecases.NewTrashUseCase(userRepo, productRepo, categoryRepo, cfg)
+
+	userHandler := http.NewUserHandler(userUseCase, cartUseCase)
+	roleHandler := http.NewRoleHandler(roleUseCase)
+	productHandler := http.NewProductHandler(productUseCase)
+	categoryHandler := http.NewCategoryHandler(categoryUseCase)
+	tagHandler := http.NewTagHandler(tagUseCase)
+	cartHandler := http.NewCartHandler(cartUseCase)
+	orderHandler := http.NewOrderHandler(orderUseCase)
+	paymentHandler := http.NewPaymentHandler(paymentUseCase)
+	addressHandler := http.NewAddressHandler(addressUseCase)
+	shippingRateHandler := http.NewShippingRateHandler(shippingRateUseCase)
+	couponHandler := http.NewCouponHandler(couponUseCase)
+	trashHandler := http.NewTrashHandler(trashUseCase)
+
+	// Readiness checks; register further dependencies here.
+	healthRegistry := health.NewRegistry(parseDuration(cfg.HealthCheckTimeout, 2*time.Second))
+	healthRegistry.Register("database", func(ctx context.Context) error {
+		return database.Ping(ctx, db)
+	})
+	healthHandler := http.NewHealthHandler(healthRegistry)
 
-	router := http.NewRouter(app, userHandler)
+	router := http.NewRouter(app, http.RouterDeps{
+		Config:              cfg,
+		Permissions:         roleUseCase,
+		Revocations:         userUseCase,
+		Verifications:       userUseCase,
+		IdempotencyKeys:     idempotencyKeyRepo,
+		HealthHandler:       healthHandler,
+		UserHandler:         userHandler,
+		RoleHandler:         roleHandler,
+		ProductHandler:      productHandler,
+		CategoryHandler:     categoryHandler,
+		TagHandler:          tagHandler,
+		CartHandler:         cartHandler,
+		OrderHandler:        orderHandler,
+		PaymentHandler:      paymentHandler,
+		AddressHandler:      addressHandler,
+		ShippingRateHandler: shippingRateHandler,
+		CouponHandler:       couponHandler,
+		TrashHandler:        trashHandler,
+	})
 	router.SetupRoutes()
 
 	app.Get("/swagger/*", fiberSwagger.WrapHandler)
 
+	// SIGINT and SIGTERM stop the background jobs and the server; requests in
+	// flight get SHUTDOWN_TIMEOUT to finish before the database is closed.
+	shutdown, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Unpaid orders give their reserved stock back once the payment is overdue.
+	// Expired payments are checked with the gateway first, so late payments
+	// still count. Expired idempotency keys, revoked tokens and guest carts,
+	// and deleted records past their retention are cleaned up along the way.
+	var jobs sync.WaitGroup
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		// A signal cancels the pass in flight, so a slow gateway call or purge
+		// cannot hold up shutdown while the database is still open.
+		ctx := shutdown
+		for {
+			select {
+			case <-shutdown.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if _, err := paymentUseCase.ExpireOverduePayments(ctx); err != nil {
+				slog.Error("Failed to expire overdue payments", "error", err)
+			}
+			if _, err := orderUseCase.CancelOverdueOrders(ctx); err != nil {
+				slog.Error("Failed to cancel overdue orders", "error", err)
+			}
+			if err := idempotencyKeyRepo.DeleteExpired(ctx); err != nil {
+				slog.Error("Failed to delete expired idempotency keys", "error", err)
+			}
+			if err := revokedTokenRepo.DeleteExpired(ctx); err != nil {
+				slog.Error("Failed to delete expired revoked tokens", "error", err)
+			}
+			if err := cartRepo.DeleteExpiredGuestCarts(ctx, time.Now()); err != nil {
+				slog.Error("Failed to delete expired guest carts", "error", err)
+			}
+			if _, err := trashUseCase.PurgeExpired(ctx); err != nil {
+				slog.Error("Failed to purge deleted records", "error", err)
+			}
+		}
+	}()
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 
-	log.Printf("Server starting on port %s", port)
-	if err := app.Listen(":" + port); err != nil {
-		log.Fatal(err)
+	listenErr := make(chan error, 1)
+	go func() {
+		slog.Info("Server starting", "port", port)
+		listenErr <- app.Listen(":" + port)
+	}()
+
+	select {
+	case err := <-listenErr:
+		if err != nil {
+			fatal("Server failed", err)
+		}
+	case <-shutdown.Done():
+		// A second signal stops the process right away.
+		stop()
+		slog.Info("Shutting down, draining requests")
+		if err := app.ShutdownWithTimeout(parseDuration(cfg.ShutdownTimeout, 15*time.Second)); err != nil {
+			slog.Error("Failed to drain requests", "error", err)
+		}
 	}
+
+	stop()
+	jobs.Wait()
+	if err := database.Close(db); err != nil {
+		slog.Error("Failed to close database", "error", err)
+	}
+	slog.Info("Server stopped")
+}
+
+// parseDuration parses a duration from the config, falling back when it is
+// not a valid duration.
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fallback
+	}
+	return duration
+}
+
+// fatal logs err and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_permissions_name;
+DROP TABLE IF EXISTS permissions;
@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_role_permissions_permission_id;
+DROP TABLE IF EXISTS role_permissions;
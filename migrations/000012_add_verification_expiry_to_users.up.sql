@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE users ADD COLUMN verification_token_expires TIMESTAMP;
+ALTER TABLE users ADD COLUMN verification_sent_at TIMESTAMP;
+
+CREATE INDEX idx_users_verification_token ON users(verification_token);
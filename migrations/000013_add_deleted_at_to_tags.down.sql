@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_tags_deleted_at;
+ALTER TABLE tags DROP COLUMN IF EXISTS deleted_at;
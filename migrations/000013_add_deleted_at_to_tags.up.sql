@@ -0,0 +1,4 @@
+-- +migrate Up
+ALTER TABLE tags ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_tags_deleted_at ON tags(deleted_at);
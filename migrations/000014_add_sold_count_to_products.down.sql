@@ -0,0 +1,5 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_products_effective_price;
+DROP INDEX IF EXISTS idx_products_created_at;
+DROP INDEX IF EXISTS idx_products_sold_count;
+ALTER TABLE products DROP COLUMN IF EXISTS sold_count;
@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE products ADD COLUMN sold_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_products_sold_count ON products(sold_count DESC, id DESC);
+CREATE INDEX idx_products_created_at ON products(created_at DESC, id DESC);
+CREATE INDEX idx_products_effective_price ON products((COALESCE(discount_price, price)), id);
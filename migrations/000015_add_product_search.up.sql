@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- The 'simple' configuration is used because product data is mostly
+-- Indonesian, which PostgreSQL has no stemmer for.
+ALTER TABLE products ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(short_description, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+
+CREATE INDEX idx_products_search_vector ON products USING GIN(search_vector);
+CREATE INDEX idx_products_name_trgm ON products USING GIN(name gin_trgm_ops);
+CREATE INDEX idx_categories_name_trgm ON categories USING GIN(name gin_trgm_ops);
+CREATE INDEX idx_tags_name_trgm ON tags USING GIN(name gin_trgm_ops);
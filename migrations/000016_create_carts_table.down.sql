@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_carts_expires_at;
+DROP TABLE IF EXISTS carts;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE carts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT UNIQUE,
+    token_hash VARCHAR(64) UNIQUE,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_carts_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_carts_owner CHECK (user_id IS NOT NULL OR token_hash IS NOT NULL)
+);
+
+CREATE INDEX idx_carts_expires_at ON carts(expires_at);
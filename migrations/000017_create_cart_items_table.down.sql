@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_cart_items_product_id;
+DROP TABLE IF EXISTS cart_items;
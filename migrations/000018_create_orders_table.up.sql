@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TYPE order_status_enum AS ENUM ('pending_payment', 'paid', 'processing', 'shipped', 'delivered', 'cancelled', 'refunded');
+
+CREATE TABLE orders (
+    id BIGSERIAL PRIMARY KEY,
+    order_number VARCHAR(32) NOT NULL UNIQUE,
+    user_id BIGINT NOT NULL,
+    status order_status_enum NOT NULL DEFAULT 'pending_payment',
+    item_count INTEGER NOT NULL DEFAULT 0,
+    subtotal DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    discount_total DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    total DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    notes VARCHAR(500),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_orders_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_orders_user_id ON orders(user_id, created_at DESC);
+CREATE INDEX idx_orders_status ON orders(status);
+CREATE INDEX idx_orders_created_at ON orders(created_at DESC, id DESC);
@@ -0,0 +1,25 @@
+-- +migrate Up
+-- Items snapshot the product as it was at checkout; product_id is only kept
+-- as a reference and may become NULL when the product row is removed.
+CREATE TABLE order_items (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    product_id BIGINT,
+    seller_id BIGINT NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    product_sku VARCHAR(100) NOT NULL,
+    unit_price DECIMAL(15, 2) NOT NULL,
+    original_price DECIMAL(15, 2) NOT NULL,
+    quantity INTEGER NOT NULL,
+    line_total DECIMAL(15, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_order_items_orders FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    CONSTRAINT fk_order_items_products FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE SET NULL,
+    CONSTRAINT fk_order_items_sellers FOREIGN KEY (seller_id) REFERENCES users(id) ON DELETE RESTRICT,
+    CONSTRAINT chk_order_items_quantity CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_order_items_order_id ON order_items(order_id);
+CREATE INDEX idx_order_items_product_id ON order_items(product_id);
+CREATE INDEX idx_order_items_seller_id ON order_items(seller_id, order_id);
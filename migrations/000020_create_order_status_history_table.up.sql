@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE order_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    from_status order_status_enum,
+    to_status order_status_enum NOT NULL,
+    changed_by BIGINT,
+    note VARCHAR(500),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_order_status_history_orders FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    CONSTRAINT fk_order_status_history_users FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_order_status_history_order_id ON order_status_history(order_id, id);
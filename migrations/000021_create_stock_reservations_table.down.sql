@@ -0,0 +1,6 @@
+-- +migrate Down
+ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_stock_quantity;
+DROP INDEX IF EXISTS idx_stock_reservations_active_expires_at;
+DROP INDEX IF EXISTS idx_stock_reservations_product_id;
+DROP TABLE IF EXISTS stock_reservations;
+DROP TYPE IF EXISTS stock_reservation_status_enum;
@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TYPE stock_reservation_status_enum AS ENUM ('active', 'committed', 'released');
+
+-- Stock is taken out of products.stock_quantity when it is reserved at
+-- checkout. Reservations are committed when the order is paid and released,
+-- returning the stock, when it is cancelled or the payment window expires.
+CREATE TABLE stock_reservations (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status stock_reservation_status_enum NOT NULL DEFAULT 'active',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_stock_reservations_orders FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    CONSTRAINT fk_stock_reservations_products FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT uq_stock_reservations_order_product UNIQUE (order_id, product_id)
+);
+
+CREATE INDEX idx_stock_reservations_product_id ON stock_reservations(product_id);
+CREATE INDEX idx_stock_reservations_active_expires_at ON stock_reservations(expires_at) WHERE status = 'active';
+
+ALTER TABLE products ADD CONSTRAINT chk_products_stock_quantity CHECK (stock_quantity >= 0);
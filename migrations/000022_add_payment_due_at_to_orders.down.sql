@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_orders_payment_due_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_due_at;
@@ -0,0 +1,4 @@
+-- +migrate Up
+ALTER TABLE orders ADD COLUMN payment_due_at TIMESTAMP;
+
+CREATE INDEX idx_orders_payment_due_at ON orders(payment_due_at) WHERE status = 'pending_payment';
@@ -0,0 +1,7 @@
+-- +migrate Down
+DROP TRIGGER IF EXISTS trg_stock_movements_append_only ON stock_movements;
+DROP FUNCTION IF EXISTS stock_movements_append_only();
+DROP INDEX IF EXISTS idx_stock_movements_order_id;
+DROP INDEX IF EXISTS idx_stock_movements_product_id;
+DROP TABLE IF EXISTS stock_movements;
+DROP TYPE IF EXISTS stock_movement_type_enum;
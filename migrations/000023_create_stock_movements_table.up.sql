@@ -0,0 +1,53 @@
+-- +migrate Up
+CREATE TYPE stock_movement_type_enum AS ENUM (
+    'initial', 'restock', 'sale', 'reservation', 'release', 'return', 'adjustment'
+);
+
+-- Append-only ledger of products.stock_quantity. stock_change is what the
+-- movement did to the column, so a product's stock always equals the sum of
+-- its changes; quantity is the number of units involved. Sales have no stock
+-- change because their units were taken out when they were reserved.
+CREATE TABLE stock_movements (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    type stock_movement_type_enum NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    stock_change INTEGER NOT NULL,
+    balance_after INTEGER NOT NULL,
+    order_id BIGINT,
+    actor_id BIGINT,
+    reason VARCHAR(500),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_stock_movements_products FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT fk_stock_movements_orders FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL,
+    CONSTRAINT fk_stock_movements_users FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_stock_movements_product_id ON stock_movements(product_id, id);
+CREATE INDEX idx_stock_movements_order_id ON stock_movements(order_id);
+
+-- Movements cannot be edited. The only update allowed is clearing the order
+-- or actor reference when that record is deleted.
+CREATE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    IF (NEW.order_id IS NULL OR NEW.order_id = OLD.order_id)
+       AND (NEW.actor_id IS NULL OR NEW.actor_id = OLD.actor_id)
+       AND (NEW.id, NEW.product_id, NEW.type, NEW.quantity, NEW.stock_change, NEW.balance_after, NEW.reason, NEW.created_at)
+           IS NOT DISTINCT FROM
+           (OLD.id, OLD.product_id, OLD.type, OLD.quantity, OLD.stock_change, OLD.balance_after, OLD.reason, OLD.created_at)
+    THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_stock_movements_append_only
+    BEFORE UPDATE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+-- Open the ledger of existing products with their current stock.
+INSERT INTO stock_movements (product_id, type, quantity, stock_change, balance_after, actor_id, reason)
+SELECT id, 'initial', COALESCE(stock_quantity, 0), COALESCE(stock_quantity, 0), COALESCE(stock_quantity, 0), user_id, 'Opening balance'
+FROM products;
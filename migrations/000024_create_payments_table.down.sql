@@ -0,0 +1,8 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_payment_notifications_payment_id;
+DROP TABLE IF EXISTS payment_notifications;
+DROP INDEX IF EXISTS idx_payments_pending_expires_at;
+DROP INDEX IF EXISTS uq_payments_pending_order_id;
+DROP INDEX IF EXISTS idx_payments_order_id;
+DROP TABLE IF EXISTS payments;
+DROP TYPE IF EXISTS payment_status_enum;
@@ -0,0 +1,44 @@
+-- +migrate Up
+CREATE TYPE payment_status_enum AS ENUM ('pending', 'paid', 'failed', 'expired', 'refunded');
+
+-- Charges created at a payment gateway. reference is the gateway's ID of the
+-- charge; an order has at most one pending charge at a time.
+CREATE TABLE payments (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    gateway VARCHAR(50) NOT NULL,
+    reference VARCHAR(100) NOT NULL,
+    status payment_status_enum NOT NULL DEFAULT 'pending',
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    payment_url TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    paid_at TIMESTAMP,
+    refunded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_payments_orders FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    CONSTRAINT uq_payments_gateway_reference UNIQUE (gateway, reference)
+);
+
+CREATE INDEX idx_payments_order_id ON payments(order_id);
+CREATE UNIQUE INDEX uq_payments_pending_order_id ON payments(order_id) WHERE status = 'pending';
+CREATE INDEX idx_payments_pending_expires_at ON payments(expires_at) WHERE status = 'pending';
+
+-- Webhook calls received from gateways. Gateways resend notifications until
+-- they are acknowledged, so each event is only applied once.
+CREATE TABLE payment_notifications (
+    id BIGSERIAL PRIMARY KEY,
+    gateway VARCHAR(50) NOT NULL,
+    event_id VARCHAR(150) NOT NULL,
+    payment_id BIGINT,
+    status VARCHAR(20) NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_payment_notifications_payments FOREIGN KEY (payment_id) REFERENCES payments(id) ON DELETE CASCADE,
+    CONSTRAINT uq_payment_notifications_gateway_event UNIQUE (gateway, event_id)
+);
+
+CREATE INDEX idx_payment_notifications_payment_id ON payment_notifications(payment_id);
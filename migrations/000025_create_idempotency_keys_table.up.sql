@@ -0,0 +1,22 @@
+-- +migrate Up
+-- Requests sent with an Idempotency-Key header and the responses they got.
+-- scope is who sent the request, e.g. 'user:42' or 'anonymous'; a row with
+-- no completed_at belongs to a request that is still running.
+CREATE TABLE idempotency_keys (
+    id BIGSERIAL PRIMARY KEY,
+    scope VARCHAR(50) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    response_headers JSONB,
+    response_body BYTEA,
+    expires_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_idempotency_keys_scope_key UNIQUE (scope, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,27 @@
+-- +migrate Up
+-- Indonesian administrative regions, loaded by the RegionSeeder. Codes follow
+-- the Kemendagri format: '31' for a province, '31.74' for a city or regency
+-- and '31.74.01' for a district (kecamatan).
+CREATE TABLE provinces (
+    code VARCHAR(2) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE cities (
+    code VARCHAR(5) PRIMARY KEY,
+    province_code VARCHAR(2) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+
+    CONSTRAINT fk_cities_provinces FOREIGN KEY (province_code) REFERENCES provinces(code) ON DELETE RESTRICT
+);
+
+CREATE TABLE districts (
+    code VARCHAR(8) PRIMARY KEY,
+    city_code VARCHAR(5) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+
+    CONSTRAINT fk_districts_cities FOREIGN KEY (city_code) REFERENCES cities(code) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_cities_province_code ON cities(province_code);
+CREATE INDEX idx_districts_city_code ON districts(city_code);
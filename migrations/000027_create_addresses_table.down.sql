@@ -0,0 +1,4 @@
+-- +migrate Down
+DROP INDEX IF EXISTS uq_addresses_default_user_id;
+DROP INDEX IF EXISTS idx_addresses_user_id;
+DROP TABLE IF EXISTS addresses;
@@ -0,0 +1,26 @@
+-- +migrate Up
+-- Address book of users. A user with addresses has exactly one default
+-- address, which checkout uses unless another one is chosen.
+CREATE TABLE addresses (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    label VARCHAR(50) NOT NULL,
+    recipient_name VARCHAR(255) NOT NULL,
+    phone VARCHAR(20) NOT NULL,
+    street VARCHAR(500) NOT NULL,
+    province_code VARCHAR(2) NOT NULL,
+    city_code VARCHAR(5) NOT NULL,
+    district_code VARCHAR(8) NOT NULL,
+    postal_code VARCHAR(5) NOT NULL CHECK (postal_code ~ '^[0-9]{5}$'),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_addresses_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_addresses_provinces FOREIGN KEY (province_code) REFERENCES provinces(code) ON DELETE RESTRICT,
+    CONSTRAINT fk_addresses_cities FOREIGN KEY (city_code) REFERENCES cities(code) ON DELETE RESTRICT,
+    CONSTRAINT fk_addresses_districts FOREIGN KEY (district_code) REFERENCES districts(code) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_addresses_user_id ON addresses(user_id);
+CREATE UNIQUE INDEX uq_addresses_default_user_id ON addresses(user_id) WHERE is_default;
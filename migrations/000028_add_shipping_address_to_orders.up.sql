@@ -0,0 +1,4 @@
+-- +migrate Up
+-- Snapshot of the address an order ships to, so editing or deleting the
+-- address later does not alter the order.
+ALTER TABLE orders ADD COLUMN shipping_address JSONB;
@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_shipping_rates_regions;
+DROP TABLE IF EXISTS shipping_rates;
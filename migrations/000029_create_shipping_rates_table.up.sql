@@ -0,0 +1,27 @@
+-- +migrate Up
+-- Rate table of the built-in shipping provider. origin_code and
+-- destination_code hold a province or city code, or '*' for any region; the
+-- most specific active rate of a courier service wins. A rate applies to
+-- parcels weighing more than min_weight and up to max_weight kg.
+CREATE TABLE shipping_rates (
+    id BIGSERIAL PRIMARY KEY,
+    courier VARCHAR(50) NOT NULL,
+    service VARCHAR(50) NOT NULL,
+    description VARCHAR(255),
+    origin_code VARCHAR(5) NOT NULL,
+    destination_code VARCHAR(5) NOT NULL,
+    min_weight DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (min_weight >= 0),
+    max_weight DECIMAL(10, 2) NOT NULL,
+    price DECIMAL(15, 2) NOT NULL CHECK (price >= 0),
+    etd_min_days INTEGER NOT NULL DEFAULT 1 CHECK (etd_min_days >= 0),
+    etd_max_days INTEGER NOT NULL DEFAULT 1,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_shipping_rates_weight CHECK (max_weight > min_weight),
+    CONSTRAINT chk_shipping_rates_etd CHECK (etd_max_days >= etd_min_days),
+    CONSTRAINT uq_shipping_rates_bracket UNIQUE (courier, service, origin_code, destination_code, min_weight)
+);
+
+CREATE INDEX idx_shipping_rates_regions ON shipping_rates(destination_code, origin_code) WHERE is_active;
@@ -0,0 +1,5 @@
+-- +migrate Down
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_cost;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_weight;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_service;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_courier;
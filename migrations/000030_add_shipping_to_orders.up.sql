@@ -0,0 +1,6 @@
+-- +migrate Up
+-- The courier service chosen at checkout. shipping_cost is part of total.
+ALTER TABLE orders ADD COLUMN shipping_courier VARCHAR(50);
+ALTER TABLE orders ADD COLUMN shipping_service VARCHAR(50);
+ALTER TABLE orders ADD COLUMN shipping_weight DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN shipping_cost DECIMAL(15, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,45 @@
+-- +migrate Up
+-- Coupons take a percentage or a fixed amount off the items they apply to,
+-- or pay for shipping. The *_ids columns hold JSON arrays scoping the coupon
+-- to products, categories, tags or sellers; NULL everywhere means the whole
+-- cart. used_count is maintained together with coupon_redemptions.
+CREATE TABLE coupons (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255),
+    type VARCHAR(20) NOT NULL CHECK (type IN ('percentage', 'fixed', 'free_shipping')),
+    value DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (value >= 0),
+    max_discount DECIMAL(15, 2) CHECK (max_discount > 0),
+    min_spend DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+    usage_limit INTEGER CHECK (usage_limit > 0),
+    per_user_limit INTEGER CHECK (per_user_limit > 0),
+    used_count INTEGER NOT NULL DEFAULT 0 CHECK (used_count >= 0),
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    product_ids JSONB,
+    category_ids JSONB,
+    tag_ids JSONB,
+    seller_ids JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_coupons_percentage CHECK (type <> 'percentage' OR value <= 100),
+    CONSTRAINT chk_coupons_window CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+-- One row per order that used a coupon; removed when the order is cancelled.
+CREATE TABLE coupon_redemptions (
+    id BIGSERIAL PRIMARY KEY,
+    coupon_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    order_id BIGINT NOT NULL UNIQUE,
+    discount DECIMAL(15, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_coupon_redemptions_coupons FOREIGN KEY (coupon_id) REFERENCES coupons(id) ON DELETE RESTRICT,
+    CONSTRAINT fk_coupon_redemptions_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_coupon_redemptions_orders FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_coupon_redemptions_coupon_user ON coupon_redemptions(coupon_id, user_id);
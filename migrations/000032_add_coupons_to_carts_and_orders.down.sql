@@ -0,0 +1,9 @@
+-- +migrate Down
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS fk_orders_coupons;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS coupon_discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS coupon_code;
+ALTER TABLE orders DROP COLUMN IF EXISTS coupon_id;
+
+ALTER TABLE carts DROP CONSTRAINT IF EXISTS fk_carts_coupons;
+ALTER TABLE carts DROP COLUMN IF EXISTS coupon_id;
@@ -0,0 +1,11 @@
+-- +migrate Up
+-- The coupon applied to a cart, and the coupon an order was placed with.
+-- Orders keep the code and the discounts when the coupon is deleted.
+ALTER TABLE carts ADD COLUMN coupon_id BIGINT;
+ALTER TABLE carts ADD CONSTRAINT fk_carts_coupons FOREIGN KEY (coupon_id) REFERENCES coupons(id) ON DELETE SET NULL;
+
+ALTER TABLE orders ADD COLUMN coupon_id BIGINT;
+ALTER TABLE orders ADD COLUMN coupon_code VARCHAR(50);
+ALTER TABLE orders ADD COLUMN coupon_discount DECIMAL(15, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN shipping_discount DECIMAL(15, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD CONSTRAINT fk_orders_coupons FOREIGN KEY (coupon_id) REFERENCES coupons(id) ON DELETE SET NULL;
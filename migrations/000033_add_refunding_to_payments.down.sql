@@ -0,0 +1,4 @@
+-- +migrate Down
+-- Postgres cannot drop enum values, so 'refunding' stays unused.
+UPDATE payments SET status = 'paid' WHERE status = 'refunding';
+ALTER TABLE payments DROP COLUMN IF EXISTS refund_reference;
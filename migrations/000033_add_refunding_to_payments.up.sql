@@ -0,0 +1,6 @@
+-- +migrate Up
+-- A refund is recorded as refunding, with the reference the gateway refund is
+-- requested under, before the gateway is called. Retries reuse the reference,
+-- so the money is returned once.
+ALTER TYPE payment_status_enum ADD VALUE IF NOT EXISTS 'refunding' AFTER 'paid';
+ALTER TABLE payments ADD COLUMN refund_reference VARCHAR(100);
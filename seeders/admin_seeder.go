@@ -1,27 +1,27 @@
 package seeders
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/yourusername/ecommerce-go-vue/backend/common/utils"
-	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/database"
+	"gorm.io/gorm"
 )
 
 type AdminSeeder struct{}
 
-func (s *AdminSeeder) Seed() error {
+func (s *AdminSeeder) Seed(db *gorm.DB) error {
 	var count int64
 
-	database.DB.Table("users").Where("role_id = ?", 3).Count(&count)
+	db.Table("users").Where("role_id = ?", 3).Count(&count)
 
 	if count > 0 {
-		log.Println("✓ Admin user already seeded, skipping...")
+		slog.Info("Admin user already seeded, skipping")
 		return nil
 	}
 
 	passwordHash, err := utils.HashPassword("12341234")
 	if err != nil {
-		log.Printf("✗ Failed to hash password: %v", err)
+		slog.Error("Failed to hash password", "error", err)
 		return err
 	}
 
@@ -34,15 +34,12 @@ func (s *AdminSeeder) Seed() error {
 		"is_verified":   true,
 	}
 
-	if err := database.DB.Table("users").Create(adminUser).Error; err != nil {
-		log.Printf("✗ Failed to seed admin user: %v", err)
+	if err := db.Table("users").Create(adminUser).Error; err != nil {
+		slog.Error("Failed to seed admin user", "error", err)
 		return err
 	}
 
-	log.Println("✓ Admin user seeded successfully:")
-	log.Println("  - Email: admin@ecommerce.com")
-	log.Println("  - Password: 12341234")
-	log.Println("  - Role: Admin")
+	slog.Info("Seeded admin user", "email", adminUser["email"])
 
 	return nil
 }
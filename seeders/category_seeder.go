@@ -1,20 +1,20 @@
 package seeders
 
 import (
-	"log"
+	"log/slog"
 
-	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/database"
+	"gorm.io/gorm"
 )
 
 type CategorySeeder struct{}
 
-func (s *CategorySeeder) Seed() error {
+func (s *CategorySeeder) Seed(db *gorm.DB) error {
 	var count int64
 
-	database.DB.Table("categories").Count(&count)
+	db.Table("categories").Count(&count)
 
 	if count > 0 {
-		log.Println("✓ Categories already seeded, skipping...")
+		slog.Info("Categories already seeded, skipping")
 		return nil
 	}
 
@@ -182,33 +182,18 @@ func (s *CategorySeeder) Seed() error {
 	}
 
 	for _, category := range categories {
-		if err := database.DB.Table("categories").Create(category).Error; err != nil {
-			log.Printf("✗ Failed to seed category %s: %v", category["name"], err)
+		if err := db.Table("categories").Create(category).Error; err != nil {
+			slog.Error("Failed to seed category", "category", category["name"], "error", err)
 			return err
 		}
 	}
 
-	log.Println("✓ Categories seeded successfully:")
-	log.Println("  - Electronics")
-	log.Println("  - Men's Fashion")
-	log.Println("  - Women's Fashion")
-	log.Println("  - Health & Beauty")
-	log.Println("  - Sports & Outdoor")
-	log.Println("  - Home & Living")
-	log.Println("  - Books & Stationery")
-	log.Println("  - Automotive")
-	log.Println("  - Baby & Kids")
-	log.Println("  - Food & Beverages")
-	log.Println("  - Computer & Accessories")
-	log.Println("  - Toys & Hobbies")
-	log.Println("  - Jewelry & Watches")
-	log.Println("  - Pet Supplies")
-	log.Println("  - Home Appliances")
-	log.Println("  - Furniture")
-	log.Println("  - Garden & Plants")
-	log.Println("  - Music & Instruments")
-	log.Println("  - Camera & Photo")
-	log.Println("  - Gaming")
+	if err := db.Exec("SELECT setval('categories_id_seq', (SELECT MAX(id) FROM categories))").Error; err != nil {
+		slog.Error("Failed to reset categories sequence", "error", err)
+		return err
+	}
+
+	slog.Info("Seeded categories", "count", len(categories))
 
 	return nil
 }
@@ -0,0 +1,95 @@
+package seeders
+
+import (
+	"log/slog"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PermissionSeeder struct{}
+
+// Permissions are inserted one by one with ON CONFLICT DO NOTHING so that
+// permissions added in later releases are picked up by existing databases.
+// Roles only get the default mappings of permissions inserted by this run;
+// mappings of existing permissions are left alone, so permissions an admin
+// took away from a role stay removed.
+func (s *PermissionSeeder) Seed(db *gorm.DB) error {
+	permissions := []map[string]interface{}{
+		{"name": entities.PermissionUsersList, "description": "Melihat daftar semua pengguna"},
+		{"name": entities.PermissionUsersManage, "description": "Mengelola data semua pengguna"},
+		{"name": entities.PermissionRolesManage, "description": "Mengelola role dan permission"},
+		{"name": entities.PermissionProductsWrite, "description": "Membuat dan mengubah produk milik sendiri"},
+		{"name": entities.PermissionProductsManage, "description": "Mengelola semua produk"},
+		{"name": entities.PermissionCategoriesManage, "description": "Mengelola kategori dan tag"},
+		{"name": entities.PermissionOrdersCreate, "description": "Membuat pesanan"},
+		{"name": entities.PermissionOrdersManage, "description": "Mengelola semua pesanan"},
+		{"name": entities.PermissionShippingManage, "description": "Mengelola tarif pengiriman"},
+		{"name": entities.PermissionCouponsManage, "description": "Mengelola kupon dan promosi"},
+	}
+
+	inserted := map[string]bool{}
+	for _, permission := range permissions {
+		result := db.Table("permissions").
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+			Create(permission)
+		if result.Error != nil {
+			slog.Error("Failed to seed permission", "permission", permission["name"], "error", result.Error)
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			inserted[permission["name"].(string)] = true
+		}
+	}
+	if len(inserted) == 0 {
+		slog.Info("Permissions already seeded, skipping")
+		return nil
+	}
+
+	rolePermissions := map[int][]string{
+		entities.RoleCustomer: {
+			entities.PermissionOrdersCreate,
+		},
+		entities.RoleToko: {
+			entities.PermissionProductsWrite,
+			entities.PermissionOrdersCreate,
+		},
+		entities.RoleAdmin: {
+			entities.PermissionUsersList,
+			entities.PermissionUsersManage,
+			entities.PermissionRolesManage,
+			entities.PermissionProductsWrite,
+			entities.PermissionProductsManage,
+			entities.PermissionCategoriesManage,
+			entities.PermissionOrdersCreate,
+			entities.PermissionOrdersManage,
+			entities.PermissionShippingManage,
+			entities.PermissionCouponsManage,
+		},
+	}
+
+	for roleID, defaults := range rolePermissions {
+		var names []string
+		for _, name := range defaults {
+			if inserted[name] {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		if err := db.Exec(`
+			INSERT INTO role_permissions (role_id, permission_id)
+			SELECT ?, id FROM permissions WHERE name IN ?
+			ON CONFLICT DO NOTHING`, roleID, names).Error; err != nil {
+			slog.Error("Failed to seed role permissions", "role_id", roleID, "error", err)
+			return err
+		}
+	}
+
+	slog.Info("Seeded permissions", "count", len(inserted))
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package seeders
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// regionsCSV lists Indonesian regions as "code,name" rows with Kemendagri
+// codes: '31' is a province, '31.74' a city or regency in it and '31.74.01'
+// a district in that city. Replace it with the full dataset to cover the
+// whole country.
+//
+//go:embed data/regions.csv
+var regionsCSV []byte
+
+const regionBatchSize = 500
+
+type RegionSeeder struct{}
+
+// Seed loads the bundled regions. Existing regions are updated, so the seeder
+// can be run again after the data file changed.
+func (s *RegionSeeder) Seed(db *gorm.DB) error {
+	provinces, cities, districts, err := parseRegions(regionsCSV)
+	if err != nil {
+		slog.Error("Failed to read regions", "error", err)
+		return err
+	}
+
+	upsert := clause.OnConflict{UpdateAll: true}
+	if err := db.Clauses(upsert).CreateInBatches(provinces, regionBatchSize).Error; err != nil {
+		slog.Error("Failed to seed provinces", "error", err)
+		return err
+	}
+	if err := db.Clauses(upsert).CreateInBatches(cities, regionBatchSize).Error; err != nil {
+		slog.Error("Failed to seed cities", "error", err)
+		return err
+	}
+	if err := db.Clauses(upsert).CreateInBatches(districts, regionBatchSize).Error; err != nil {
+		slog.Error("Failed to seed districts", "error", err)
+		return err
+	}
+
+	slog.Info("Seeded regions",
+		"provinces", len(provinces),
+		"cities", len(cities),
+		"districts", len(districts),
+	)
+	return nil
+}
+
+func parseRegions(data []byte) ([]*entities.Province, []*entities.City, []*entities.District, error) {
+	var (
+		provinces []*entities.Province
+		cities    []*entities.City
+		districts []*entities.District
+	)
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = 2
+	if _, err := reader.Read(); err != nil {
+		return nil, nil, nil, err
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		code, name := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		switch strings.Count(code, ".") {
+		case 0:
+			provinces = append(provinces, &entities.Province{Code: code, Name: name})
+		case 1:
+			cities = append(cities, &entities.City{Code: code, ProvinceCode: code[:strings.LastIndex(code, ".")], Name: name})
+		case 2:
+			districts = append(districts, &entities.District{Code: code, CityCode: code[:strings.LastIndex(code, ".")], Name: name})
+		default:
+			return nil, nil, nil, fmt.Errorf("invalid region code %q", code)
+		}
+	}
+	return provinces, cities, districts, nil
+}
@@ -1,20 +1,20 @@
 package seeders
 
 import (
-	"log"
+	"log/slog"
 
-	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/database"
+	"gorm.io/gorm"
 )
 
 type RoleSeeder struct{}
 
-func (s *RoleSeeder) Seed() error {
+func (s *RoleSeeder) Seed(db *gorm.DB) error {
 	var count int64
 
-	database.DB.Table("roles").Count(&count)
+	db.Table("roles").Count(&count)
 
 	if count > 0 {
-		log.Println("✓ Roles already seeded, skipping...")
+		slog.Info("Roles already seeded, skipping")
 		return nil
 	}
 
@@ -37,16 +37,20 @@ func (s *RoleSeeder) Seed() error {
 	}
 
 	for _, role := range roles {
-		if err := database.DB.Table("roles").Create(role).Error; err != nil {
-			log.Printf("✗ Failed to seed role %s: %v", role["name"], err)
+		if err := db.Table("roles").Create(role).Error; err != nil {
+			slog.Error("Failed to seed role", "role", role["name"], "error", err)
 			return err
 		}
 	}
 
-	log.Println("✓ Roles seeded successfully:")
-	log.Println("  - ID 1: customer")
-	log.Println("  - ID 2: toko")
-	log.Println("  - ID 3: admin")
+	// Roles are inserted with explicit IDs, so move the sequence past them
+	// before new roles are created through the API.
+	if err := db.Exec("SELECT setval('roles_id_seq', (SELECT MAX(id) FROM roles))").Error; err != nil {
+		slog.Error("Failed to reset roles sequence", "error", err)
+		return err
+	}
+
+	slog.Info("Seeded roles", "count", len(roles))
 
 	return nil
 }
@@ -1,27 +1,32 @@
 package seeders
 
 import (
-	"log"
+	"log/slog"
+
+	"gorm.io/gorm"
 )
 
 type Seeder interface {
-	Seed() error
+	Seed(db *gorm.DB) error
 }
 
-func RunSeeders() error {
+func RunSeeders(db *gorm.DB) error {
 	seeders := []Seeder{
 		&RoleSeeder{},
+		&PermissionSeeder{},
 		&AdminSeeder{},
 		&CategorySeeder{},
 		&TagSeeder{},
+		&RegionSeeder{},
+		&ShippingRateSeeder{},
 	}
 
 	for _, seeder := range seeders {
-		if err := seeder.Seed(); err != nil {
+		if err := seeder.Seed(db); err != nil {
 			return err
 		}
 	}
 
-	log.Println("All seeders completed successfully")
+	slog.Info("All seeders completed")
 	return nil
 }
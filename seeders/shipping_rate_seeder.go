@@ -0,0 +1,96 @@
+package seeders
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/ecommerce-go-vue/backend/domain/entities"
+	"gorm.io/gorm"
+)
+
+// shippingRatesCSV is the starter rate table for a store shipping from
+// Jakarta: rates within DKI Jakarta, to the Thousand Islands and to anywhere
+// else, in weight brackets up to 30 kg.
+//
+//go:embed data/shipping_rates.csv
+var shippingRatesCSV []byte
+
+type ShippingRateSeeder struct{}
+
+// Seed loads the bundled shipping rates into an empty rate table. Once the
+// rates are managed through the API the seeder leaves them alone.
+func (s *ShippingRateSeeder) Seed(db *gorm.DB) error {
+	var count int64
+
+	db.Table("shipping_rates").Count(&count)
+
+	if count > 0 {
+		slog.Info("Shipping rates already seeded, skipping")
+		return nil
+	}
+
+	rates, err := parseShippingRates(shippingRatesCSV)
+	if err != nil {
+		slog.Error("Failed to read shipping rates", "error", err)
+		return err
+	}
+
+	if err := db.CreateInBatches(rates, regionBatchSize).Error; err != nil {
+		slog.Error("Failed to seed shipping rates", "error", err)
+		return err
+	}
+
+	slog.Info("Seeded shipping rates", "count", len(rates))
+	return nil
+}
+
+func parseShippingRates(data []byte) ([]*entities.ShippingRate, error) {
+	var rates []*entities.ShippingRate
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = 10
+	if _, err := reader.Read(); err != nil {
+		return nil, err
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		rate := &entities.ShippingRate{
+			Courier:         record[0],
+			Service:         record[1],
+			Description:     record[2],
+			OriginCode:      record[3],
+			DestinationCode: record[4],
+			IsActive:        true,
+		}
+		numbers := []*float64{&rate.MinWeight, &rate.MaxWeight, &rate.Price}
+		for i, number := range numbers {
+			if *number, err = strconv.ParseFloat(record[5+i], 64); err != nil {
+				return nil, fmt.Errorf("invalid shipping rate %v: %w", record, err)
+			}
+		}
+		if rate.EtdMinDays, err = strconv.Atoi(record[8]); err != nil {
+			return nil, fmt.Errorf("invalid shipping rate %v: %w", record, err)
+		}
+		if rate.EtdMaxDays, err = strconv.Atoi(record[9]); err != nil {
+			return nil, fmt.Errorf("invalid shipping rate %v: %w", record, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
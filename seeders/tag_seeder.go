@@ -1,20 +1,20 @@
 package seeders
 
 import (
-	"log"
+	"log/slog"
 
-	"github.com/yourusername/ecommerce-go-vue/backend/infrastructure/database"
+	"gorm.io/gorm"
 )
 
 type TagSeeder struct{}
 
-func (s *TagSeeder) Seed() error {
+func (s *TagSeeder) Seed(db *gorm.DB) error {
 	var count int64
 
-	database.DB.Table("tags").Count(&count)
+	db.Table("tags").Count(&count)
 
 	if count > 0 {
-		log.Println("✓ Tags already seeded, skipping...")
+		slog.Info("Tags already seeded, skipping")
 		return nil
 	}
 
@@ -147,38 +147,18 @@ func (s *TagSeeder) Seed() error {
 	}
 
 	for _, tag := range tags {
-		if err := database.DB.Table("tags").Create(tag).Error; err != nil {
-			log.Printf("✗ Failed to seed tag %s: %v", tag["name"], err)
+		if err := db.Table("tags").Create(tag).Error; err != nil {
+			slog.Error("Failed to seed tag", "tag", tag["name"], "error", err)
 			return err
 		}
 	}
 
-	log.Println("✓ Tags seeded successfully:")
-	log.Println("  - Discount")
-	log.Println("  - Sale")
-	log.Println("  - Best Seller")
-	log.Println("  - New")
-	log.Println("  - Flash Sale")
-	log.Println("  - Premium")
-	log.Println("  - Free Shipping")
-	log.Println("  - Local")
-	log.Println("  - Import")
-	log.Println("  - Limited Edition")
-	log.Println("  - Exclusive")
-	log.Println("  - Trending")
-	log.Println("  - Eco-Friendly")
-	log.Println("  - Organic")
-	log.Println("  - Handmade")
-	log.Println("  - Vintage")
-	log.Println("  - Clearance")
-	log.Println("  - Bundle")
-	log.Println("  - Top Rated")
-	log.Println("  - Featured")
-	log.Println("  - Hot Deal")
-	log.Println("  - Cashback")
-	log.Println("  - Buy 1 Get 1")
-	log.Println("  - Wholesale")
-	log.Println("  - Pre-order")
+	if err := db.Exec("SELECT setval('tags_id_seq', (SELECT MAX(id) FROM tags))").Error; err != nil {
+		slog.Error("Failed to reset tags sequence", "error", err)
+		return err
+	}
+
+	slog.Info("Seeded tags", "count", len(tags))
 
 	return nil
 }